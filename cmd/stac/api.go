@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/robert-malhotra/go-stac-client/pkg/client"
+	"github.com/urfave/cli/v3"
+)
+
+func landingCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "landing",
+		Usage: "print the API landing page",
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := newClient(ctx, cmd)
+			if err != nil {
+				return err
+			}
+			landing, err := api.GetLandingPage(ctx)
+			if err != nil {
+				return err
+			}
+			return printJSON(cmd, landing)
+		},
+	}
+}
+
+func conformanceCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "conformance",
+		Usage: "list the conformance classes of the API, or check for some",
+		Flags: []cli.Flag{
+			&cli.StringSliceFlag{
+				Name:  "check",
+				Usage: "exit with status 1 unless the API conforms to this class, e.g. item-search (repeatable)",
+			},
+		},
+		Action: runConformance,
+	}
+}
+
+func runConformance(ctx context.Context, cmd *cli.Command) error {
+	api, err := newClient(ctx, cmd)
+	if err != nil {
+		return err
+	}
+	classes, err := api.GetConformance(ctx)
+	if err != nil {
+		return err
+	}
+
+	checks := cmd.StringSlice("check")
+	if len(checks) == 0 {
+		for _, c := range classes {
+			fmt.Fprintln(cmd.Writer, c)
+		}
+		return nil
+	}
+
+	missing := 0
+	for _, class := range checks {
+		if client.ConformsTo(classes, class) {
+			fmt.Fprintf(cmd.Writer, "%s: yes\n", class)
+		} else {
+			missing++
+			fmt.Fprintf(cmd.Writer, "%s: no\n", class)
+		}
+	}
+	if missing > 0 {
+		return cli.Exit(fmt.Sprintf("API does not conform to %d of %d classes", missing, len(checks)), exitError)
+	}
+	return nil
+}
+
+func queryablesCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "queryables",
+		Usage:     "print the queryable properties of the API or of a collection",
+		ArgsUsage: "[COLLECTION]",
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			if cmd.Args().Len() > 1 {
+				return usageErrorf("queryables: expected at most one collection ID")
+			}
+			api, err := newClient(ctx, cmd)
+			if err != nil {
+				return err
+			}
+
+			var q any
+			if id := cmd.Args().First(); id != "" {
+				q, err = api.GetQueryables(ctx, id)
+			} else {
+				q, err = api.GetGlobalQueryables(ctx)
+			}
+			if err != nil {
+				return err
+			}
+			return printJSON(cmd, q)
+		},
+	}
+}
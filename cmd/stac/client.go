@@ -0,0 +1,171 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"iter"
+	"net/http"
+	"strings"
+
+	"github.com/robert-malhotra/go-stac-client/pkg/client"
+	"github.com/robert-malhotra/go-stac-client/pkg/config"
+	"github.com/robert-malhotra/go-stac-client/pkg/output"
+	"github.com/urfave/cli/v3"
+)
+
+// newClient creates a client for --url with the authentication flags and
+// --timeout applied. Settings that are not given as flags are taken from
+// the selected profile.
+func newClient(ctx context.Context, cmd *cli.Command, opts ...client.ClientOption) (*client.Client, error) {
+	p, err := loadProfile(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	baseURL := cmd.String("url")
+	if baseURL == "" && p != nil {
+		baseURL = p.URL
+	}
+	if baseURL == "" {
+		return nil, usageErrorf("%s: --url, STAC_URL or a profile with a url is required", cmd.FullName())
+	}
+
+	mw, err := authMiddleware(ctx, cmd, p)
+	if err != nil {
+		return nil, err
+	}
+	timeout := cmd.Duration("timeout")
+	if p != nil && p.Timeout > 0 && !cmd.IsSet("timeout") {
+		timeout = p.Timeout
+	}
+	base := []client.ClientOption{client.WithTimeout(timeout)}
+	if p != nil && p.S3 != nil {
+		base = append(base, client.WithS3Options(p.S3.Options()))
+	}
+	opts = append(base, opts...)
+	if mw != nil {
+		opts = append(opts, client.WithMiddleware(mw))
+	}
+	return client.NewClient(baseURL, opts...)
+}
+
+// authMiddleware builds the middleware for --token, --username/--password
+// and --header, or for the profile's auth when none of them is set. It
+// returns nil when there is nothing to authenticate with.
+func authMiddleware(ctx context.Context, cmd *cli.Command, p *config.Profile) (client.Middleware, error) {
+	token := strings.TrimSpace(cmd.String("token"))
+	username := strings.TrimSpace(cmd.String("username"))
+	password := cmd.String("password")
+	if token != "" && username != "" {
+		return nil, usageErrorf("--token and --username are mutually exclusive")
+	}
+
+	headers := make(http.Header)
+	for _, h := range cmd.StringSlice("header") {
+		name, value, ok := strings.Cut(h, ":")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, usageErrorf(`invalid --header %q, expected "Name: value"`, h)
+		}
+		headers.Add(name, strings.TrimSpace(value))
+	}
+
+	if token == "" && username == "" && len(headers) == 0 && p != nil {
+		creds, err := p.Auth.Resolve(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("profile auth: %w", err)
+		}
+		token, username, password = creds.Token, creds.Username, creds.Password
+		if creds.Header != "" {
+			headers.Set(creds.Header, creds.HeaderValue)
+		}
+	}
+
+	if token == "" && username == "" && len(headers) == 0 {
+		return nil, nil
+	}
+	return func(_ context.Context, r *http.Request) error {
+		switch {
+		case token != "":
+			r.Header.Set("Authorization", "Bearer "+token)
+		case username != "":
+			r.SetBasicAuth(username, password)
+		}
+		for name, values := range headers {
+			r.Header[name] = values
+		}
+		return nil
+	}, nil
+}
+
+// printJSON writes v to the command's output as indented JSON.
+func printJSON(cmd *cli.Command, v any) error {
+	enc := json.NewEncoder(cmd.Writer)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// printSeq writes the documents of seq in --format, or only their IDs with
+// --ids, stopping after --max-items documents.
+func printSeq[T any](cmd *cli.Command, seq iter.Seq2[*T, error], id func(*T) string) error {
+	format, err := output.ParseFormat(cmd.String("format"))
+	if err != nil {
+		return usageErrorf("--format: %v", err)
+	}
+	if cmd.Bool("ids") && cmd.IsSet("format") {
+		return usageErrorf("--ids and --format are mutually exclusive")
+	}
+	var columns []string
+	for _, c := range cmd.StringSlice("columns") {
+		columns = append(columns, splitList(c)...)
+	}
+	w, err := output.NewWriter(cmd.Writer, format, output.WithColumns(columns...))
+	if err != nil {
+		return err
+	}
+	write := w.Write
+	if cmd.Bool("ids") {
+		write = func(doc any) error {
+			_, err := io.WriteString(cmd.Writer, id(doc.(*T))+"\n")
+			return err
+		}
+	}
+
+	maxItems := cmd.Int("max-items")
+	n := 0
+	for doc, err := range seq {
+		if err != nil {
+			w.Close()
+			return err
+		}
+		if err := write(doc); err != nil {
+			return err
+		}
+		if n++; maxItems > 0 && n >= maxItems {
+			break
+		}
+	}
+	return w.Close()
+}
+
+// outputFlags select the output of list commands.
+func outputFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.BoolFlag{
+			Name:  "ids",
+			Usage: "print only document IDs, one per line",
+		},
+		&cli.StringFlag{
+			Name:    "format",
+			Aliases: []string{"o"},
+			Usage:   "output format: ndjson, geojson (a FeatureCollection), csv or table",
+			Value:   string(output.NDJSON),
+		},
+		&cli.StringSliceFlag{
+			Name:  "columns",
+			Usage: "csv and table columns: fields or properties, dotted for nested values (repeatable or comma-separated)",
+		},
+	}
+}
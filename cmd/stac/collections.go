@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+
+	"github.com/robert-malhotra/go-stac-client/pkg/client"
+	"github.com/robert-malhotra/go-stac-client/pkg/stac"
+	"github.com/urfave/cli/v3"
+)
+
+func collectionsCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "collections",
+		Usage: "list or fetch collections",
+		Commands: []*cli.Command{
+			{
+				Name:  "list",
+				Usage: "list collections, optionally filtered with the Collection Search extension",
+				Flags: append(outputFlags(),
+					&cli.StringFlag{Name: "q", Usage: "free-text query"},
+					&cli.StringFlag{Name: "bbox", Usage: "minLon,minLat,maxLon,maxLat"},
+					&cli.StringFlag{Name: "datetime", Usage: "RFC 3339 instant or interval, e.g. 2024-01-01T00:00:00Z/.."},
+				),
+				Action: runCollectionsList,
+			},
+			{
+				Name:      "get",
+				Usage:     "print a collection",
+				ArgsUsage: "COLLECTION",
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					if cmd.Args().Len() != 1 {
+						return usageErrorf("collections get: expected a collection ID")
+					}
+					api, err := newClient(ctx, cmd)
+					if err != nil {
+						return err
+					}
+					col, err := api.GetCollection(ctx, cmd.Args().First())
+					if err != nil {
+						return err
+					}
+					return printJSON(cmd, col)
+				},
+			},
+		},
+	}
+}
+
+func runCollectionsList(ctx context.Context, cmd *cli.Command) error {
+	bbox, err := parseBbox(cmd.String("bbox"))
+	if err != nil {
+		return err
+	}
+	api, err := newClient(ctx, cmd)
+	if err != nil {
+		return err
+	}
+
+	seq := api.GetCollections(ctx)
+	if cmd.String("q") != "" || bbox != nil || cmd.String("datetime") != "" {
+		seq = api.SearchCollections(ctx, client.CollectionSearchParams{
+			Q:        cmd.String("q"),
+			Bbox:     bbox,
+			Datetime: cmd.String("datetime"),
+		})
+	}
+	return printSeq(cmd, seq, func(col *stac.Collection) string { return col.Id })
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"iter"
+	"maps"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/robert-malhotra/go-stac-client/pkg/client"
+	"github.com/robert-malhotra/go-stac-client/pkg/stac"
+	"github.com/urfave/cli/v3"
+)
+
+func downloadCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "download",
+		Usage: "download the assets of an item, or of NDJSON items read from stdin",
+		Description: "Assets are saved as DIR/ITEM/ASSET with the extension of the asset href, and\n" +
+			"each saved path is printed; IDs and keys are escaped like URL path segments. Pass - instead of a collection and item to\n" +
+			"download the items printed by search or items list, e.g.\n\n" +
+			"   stac-cli search -c sentinel-2-l2a --max-items 5 | stac-cli download --asset visual -",
+		ArgsUsage: "COLLECTION ITEM | -",
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: "dir", Usage: "destination directory; a profile's download_dir replaces the default", Value: "."},
+			&cli.StringSliceFlag{Name: "asset", Usage: "asset keys to download (repeatable, default all)"},
+		},
+		Action: runDownload,
+	}
+}
+
+func runDownload(ctx context.Context, cmd *cli.Command) error {
+	args := cmd.Args()
+	fromStdin := args.Len() == 1 && args.First() == "-"
+	if !fromStdin && args.Len() != 2 {
+		return usageErrorf("download: expected a collection ID and an item ID, or -")
+	}
+
+	// Large assets would not finish within the API request timeout, so it
+	// only applies to downloads when given explicitly.
+	var opts []client.ClientOption
+	if !cmd.IsSet("timeout") {
+		opts = append(opts, client.WithTimeout(0))
+	}
+	api, err := newClient(ctx, cmd, opts...)
+	if err != nil {
+		return err
+	}
+
+	var items iter.Seq2[*stac.Item, error]
+	if fromStdin {
+		items = readItems(cmd.Reader)
+	} else {
+		items = func(yield func(*stac.Item, error) bool) {
+			yield(api.GetItem(ctx, args.Get(0), args.Get(1)))
+		}
+	}
+
+	dir := cmd.String("dir")
+	if !cmd.IsSet("dir") {
+		p, err := loadProfile(cmd)
+		if err != nil {
+			return err
+		}
+		if p != nil && p.DownloadDir != "" {
+			dir = p.DownloadDirectory()
+		}
+	}
+
+	keys := cmd.StringSlice("asset")
+	var (
+		total, failed int
+		firstErr      error
+	)
+	for item, err := range items {
+		if err != nil {
+			return err
+		}
+		for _, key := range slices.Sorted(maps.Keys(item.Assets)) {
+			if len(keys) > 0 && !slices.Contains(keys, key) {
+				continue
+			}
+			total++
+			dest, err := downloadAsset(ctx, api, dir, item, key)
+			if err != nil {
+				failed++
+				if firstErr == nil {
+					firstErr = err
+				}
+				fmt.Fprintf(cmd.ErrWriter, "%s/%s: %v\n", item.Id, key, err)
+				continue
+			}
+			fmt.Fprintln(cmd.Writer, dest)
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d downloads failed: %w", failed, total, firstErr)
+	}
+	return nil
+}
+
+// downloadAsset saves one asset of item under dir and returns its path.
+func downloadAsset(ctx context.Context, api *client.Client, dir string, item *stac.Item, key string) (string, error) {
+	asset := item.Assets[key]
+	base := ""
+	if self := item.Self(); self != nil {
+		base = self.Href
+	}
+	href, err := asset.ResolveHref(base)
+	if err != nil {
+		return "", err
+	}
+
+	itemDir, err := pathName(item.Id)
+	if err != nil {
+		return "", fmt.Errorf("item ID: %w", err)
+	}
+	name, err := pathName(key)
+	if err != nil {
+		return "", fmt.Errorf("asset key: %w", err)
+	}
+	if u, err := url.Parse(href); err == nil {
+		name += path.Ext(u.Path)
+	}
+	dest := filepath.Join(dir, itemDir, name)
+	if err := os.MkdirAll(filepath.Dir(dest), 0o755); err != nil {
+		return "", err
+	}
+	if err := api.DownloadAsset(ctx, href, dest); err != nil {
+		return "", err
+	}
+	return dest, nil
+}
+
+// pathName returns the file name used for an item ID or asset key. IDs and
+// keys come from the server or stdin, so they are escaped to stay inside
+// --dir.
+func pathName(id string) (string, error) {
+	name := url.PathEscape(id)
+	if name == "" || name == "." || name == ".." {
+		return "", fmt.Errorf("%q cannot be used as a file name", id)
+	}
+	return name, nil
+}
+
+// readItems decodes NDJSON items from r, skipping blank lines.
+func readItems(r io.Reader) iter.Seq2[*stac.Item, error] {
+	return func(yield func(*stac.Item, error) bool) {
+		sc := bufio.NewScanner(r)
+		sc.Buffer(make([]byte, 0, 64<<10), 64<<20)
+		for line := 1; sc.Scan(); line++ {
+			text := strings.TrimSpace(sc.Text())
+			if text == "" {
+				continue
+			}
+			var item stac.Item
+			if err := json.Unmarshal([]byte(text), &item); err != nil {
+				yield(nil, fmt.Errorf("stdin line %d: %w", line, err))
+				return
+			}
+			if !yield(&item, nil) {
+				return
+			}
+		}
+		if err := sc.Err(); err != nil {
+			yield(nil, fmt.Errorf("reading stdin: %w", err))
+		}
+	}
+}
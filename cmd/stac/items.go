@@ -0,0 +1,55 @@
+package main
+
+import (
+	"context"
+
+	"github.com/robert-malhotra/go-stac-client/pkg/stac"
+	"github.com/urfave/cli/v3"
+)
+
+func itemsCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "items",
+		Usage: "list or fetch the items of a collection",
+		Commands: []*cli.Command{
+			{
+				Name:      "list",
+				Usage:     "list the items of a collection",
+				ArgsUsage: "COLLECTION",
+				Flags:     outputFlags(),
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					if cmd.Args().Len() != 1 {
+						return usageErrorf("items list: expected a collection ID")
+					}
+					api, err := newClient(ctx, cmd)
+					if err != nil {
+						return err
+					}
+					seq := api.GetItems(ctx, cmd.Args().First())
+					return printSeq(cmd, seq, itemID)
+				},
+			},
+			{
+				Name:      "get",
+				Usage:     "print an item",
+				ArgsUsage: "COLLECTION ITEM",
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					if cmd.Args().Len() != 2 {
+						return usageErrorf("items get: expected a collection ID and an item ID")
+					}
+					api, err := newClient(ctx, cmd)
+					if err != nil {
+						return err
+					}
+					item, err := api.GetItem(ctx, cmd.Args().Get(0), cmd.Args().Get(1))
+					if err != nil {
+						return err
+					}
+					return printJSON(cmd, item)
+				},
+			},
+		},
+	}
+}
+
+func itemID(item *stac.Item) string { return item.Id }
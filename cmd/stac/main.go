@@ -0,0 +1,156 @@
+// Command stac-cli is a scriptable command-line client for STAC APIs.
+//
+// Documents are written to stdout as JSON: single documents indented, lists
+// one document per line (NDJSON) so they can be piped into jq or back into
+// stac-cli download. List commands can also write a GeoJSON
+// FeatureCollection, CSV or a table with --format. Errors go to stderr and
+// the exit status tells scripts what went wrong; see the exit* constants.
+//
+// The URL, authentication and other defaults can come from a named profile
+// in the config file, selected with --profile; see stac-cli profiles.
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/robert-malhotra/go-stac-client/pkg/client"
+	"github.com/urfave/cli/v3"
+)
+
+// Exit statuses.
+const (
+	exitOK           = 0
+	exitError        = 1 // any other failure, including invalid documents
+	exitUsage        = 2 // invalid flags or arguments
+	exitNotFound     = 3 // the API answered 404
+	exitUnauthorized = 4 // the API answered 401 or 403
+	exitTimeout      = 5 // --timeout or a context deadline expired
+	exitInterrupted  = 130
+)
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	app := &cli.Command{
+		Name:  "stac-cli",
+		Usage: "work with STAC APIs and documents",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "profile",
+				Aliases: []string{"p"},
+				Usage:   "connection profile from the config file (default: the file's default profile)",
+				Sources: cli.EnvVars("STAC_PROFILE"),
+			},
+			&cli.StringFlag{
+				Name:    "config",
+				Usage:   "config file with connection profiles (default: $XDG_CONFIG_HOME/go-stac-client/config.yaml)",
+				Sources: cli.EnvVars("STAC_CONFIG"),
+			},
+			&cli.StringFlag{
+				Name:    "url",
+				Usage:   "root URL of the STAC API",
+				Sources: cli.EnvVars("STAC_URL"),
+			},
+			&cli.DurationFlag{
+				Name:  "timeout",
+				Usage: "timeout for each HTTP request (0 disables it)",
+				Value: 30 * time.Second,
+			},
+			&cli.IntFlag{
+				Name:  "max-items",
+				Usage: "stop list and search output after this many documents (0 for no limit)",
+			},
+			&cli.StringFlag{
+				Name:    "token",
+				Usage:   "bearer token sent in the Authorization header",
+				Sources: cli.EnvVars("STAC_TOKEN"),
+			},
+			&cli.StringFlag{
+				Name:    "username",
+				Usage:   "username for HTTP basic authentication",
+				Sources: cli.EnvVars("STAC_USERNAME"),
+			},
+			&cli.StringFlag{
+				Name:    "password",
+				Usage:   "password for HTTP basic authentication",
+				Sources: cli.EnvVars("STAC_PASSWORD"),
+			},
+			&cli.StringSliceFlag{
+				Name:  "header",
+				Usage: `extra request header as "Name: value" (repeatable)`,
+			},
+		},
+		Commands: []*cli.Command{
+			landingCommand(),
+			conformanceCommand(),
+			collectionsCommand(),
+			itemsCommand(),
+			queryablesCommand(),
+			searchCommand(),
+			watchCommand(),
+			downloadCommand(),
+			syncCommand(),
+			validateCommand(),
+			profilesCommand(),
+		},
+		// Errors are reported by main so that every failure maps to an
+		// exit status.
+		ExitErrHandler: func(context.Context, *cli.Command, error) {},
+	}
+	setUsageErrorHandler(app)
+
+	if err := app.Run(ctx, os.Args); err != nil {
+		if msg := err.Error(); msg != "" {
+			fmt.Fprintf(os.Stderr, "error: %s\n", msg)
+		}
+		os.Exit(exitCode(ctx, err))
+	}
+}
+
+// exitCode maps an error returned by a command to an exit status.
+func exitCode(ctx context.Context, err error) int {
+	var exitErr cli.ExitCoder
+	switch {
+	case err == nil:
+		return exitOK
+	case errors.As(err, &exitErr):
+		return exitErr.ExitCode()
+	case ctx.Err() != nil && errors.Is(err, context.Canceled):
+		return exitInterrupted
+	case errors.Is(err, context.DeadlineExceeded), isTimeout(err):
+		return exitTimeout
+	case errors.Is(err, client.ErrNotFound):
+		return exitNotFound
+	case errors.Is(err, client.ErrUnauthorized):
+		return exitUnauthorized
+	}
+	return exitError
+}
+
+func isTimeout(err error) bool {
+	var t interface{ Timeout() bool }
+	return errors.As(err, &t) && t.Timeout()
+}
+
+// usageErrorf reports invalid arguments with exitUsage.
+func usageErrorf(format string, args ...any) error {
+	return cli.Exit(fmt.Sprintf(format, args...), exitUsage)
+}
+
+// setUsageErrorHandler makes flag parsing errors of cmd and its
+// subcommands exit with exitUsage.
+func setUsageErrorHandler(cmd *cli.Command) {
+	cmd.OnUsageError = func(_ context.Context, c *cli.Command, err error, _ bool) error {
+		return usageErrorf("%s: %v", c.FullName(), err)
+	}
+	for _, sub := range cmd.Commands {
+		setUsageErrorHandler(sub)
+	}
+}
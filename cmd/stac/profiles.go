@@ -0,0 +1,246 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/robert-malhotra/go-stac-client/pkg/config"
+	"github.com/urfave/cli/v3"
+	"gopkg.in/yaml.v3"
+)
+
+// configPath returns --config, or the default config file location.
+func configPath(cmd *cli.Command) (string, error) {
+	if path := cmd.String("config"); path != "" {
+		return path, nil
+	}
+	path, err := config.DefaultPath()
+	if err != nil {
+		return "", fmt.Errorf("locating config file: %w", err)
+	}
+	return path, nil
+}
+
+// loadConfig reads the config file. A missing file yields an empty one.
+func loadConfig(cmd *cli.Command) (*config.File, string, error) {
+	path, err := configPath(cmd)
+	if err != nil {
+		return nil, "", err
+	}
+	f, err := config.Load(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return &config.File{}, path, nil
+	}
+	return f, path, err
+}
+
+// loadProfile returns the profile named by --profile, the default profile
+// of the config file, or nil when there is neither.
+func loadProfile(cmd *cli.Command) (*config.Profile, error) {
+	f, _, err := loadConfig(cmd)
+	if err != nil {
+		return nil, err
+	}
+	p, err := f.Profile(cmd.String("profile"))
+	if errors.Is(err, config.ErrProfileNotFound) {
+		return nil, usageErrorf("--profile: %v", err)
+	}
+	return p, err
+}
+
+func profilesCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "profiles",
+		Usage: "list, show and save connection profiles",
+		Description: "Profiles are read from --config, by default config.yaml in the go-stac-client\n" +
+			"directory of the user configuration directory. Select one with --profile or\n" +
+			"STAC_PROFILE; flags given on the command line override its settings.",
+		Commands: []*cli.Command{
+			{
+				Name:  "list",
+				Usage: "list profiles, marking the default with *",
+				Action: func(_ context.Context, cmd *cli.Command) error {
+					f, _, err := loadConfig(cmd)
+					if err != nil {
+						return err
+					}
+					tw := tabwriter.NewWriter(cmd.Writer, 0, 0, 2, ' ', 0)
+					for _, name := range f.Names() {
+						mark := " "
+						if name == f.Default {
+							mark = "*"
+						}
+						fmt.Fprintf(tw, "%s %s\t%s\n", mark, name, f.Profiles[name].URL)
+					}
+					return tw.Flush()
+				},
+			},
+			{
+				Name:      "show",
+				Usage:     "print a profile, or the selected one, as YAML with secret values hidden",
+				ArgsUsage: "[NAME]",
+				Action: func(_ context.Context, cmd *cli.Command) error {
+					f, _, err := loadConfig(cmd)
+					if err != nil {
+						return err
+					}
+					name := cmd.Args().First()
+					if name == "" {
+						name = cmd.String("profile")
+					}
+					p, err := f.Profile(name)
+					if err != nil {
+						return usageErrorf("profiles show: %v", err)
+					}
+					if p == nil {
+						return usageErrorf("profiles show: no profile selected and no default profile")
+					}
+					shown := *p
+					for _, s := range []*config.Secret{&shown.Auth.Token, &shown.Auth.Password, &shown.Auth.HeaderValue} {
+						if s.Value != "" {
+							s.Value = "********"
+						}
+					}
+					enc := yaml.NewEncoder(cmd.Writer)
+					enc.SetIndent(2)
+					if err := enc.Encode(&shown); err != nil {
+						return err
+					}
+					return enc.Close()
+				},
+			},
+			{
+				Name:  "save",
+				Usage: "save --url, --timeout and the authentication flags as a profile",
+				Description: "Settings of an existing profile that are not given are kept. Secrets are\n" +
+					"saved as references to an environment variable or a command; --token and\n" +
+					"--password are only written in plain text with --plaintext-secrets. The\n" +
+					"authentication flags are those given after save: the global --token,\n" +
+					"--username and --password and STAC_TOKEN, STAC_USERNAME and STAC_PASSWORD\n" +
+					"are not saved, e.g.\n\n" +
+					"   stac-cli --url https://stac.example.com profiles save --token-env STAC_TOKEN work\n\n" +
+					"The config file is rewritten: its comments and key order are kept, but blank\n" +
+					"lines and indentation are normalized.",
+				ArgsUsage: "NAME",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "token", Usage: "bearer token, saved in plain text"},
+					&cli.StringFlag{Name: "username", Usage: "username for HTTP basic authentication"},
+					&cli.StringFlag{Name: "password", Usage: "basic auth password, saved in plain text"},
+					&cli.StringFlag{Name: "token-env", Usage: "read the bearer token from this environment variable"},
+					&cli.StringFlag{Name: "token-command", Usage: "read the bearer token from the output of this command (split on spaces)"},
+					&cli.StringFlag{Name: "password-env", Usage: "read the basic auth password from this environment variable"},
+					&cli.StringFlag{Name: "password-command", Usage: "read the basic auth password from the output of this command (split on spaces)"},
+					&cli.BoolFlag{Name: "plaintext-secrets", Usage: "allow --token and --password to be written to the config file in plain text"},
+					&cli.IntFlag{Name: "page-size", Usage: "search page size"},
+					&cli.StringSliceFlag{Name: "collections", Usage: "collections searched by default (repeatable or comma-separated)"},
+					&cli.StringFlag{Name: "download-dir", Usage: "directory assets are downloaded to"},
+					&cli.BoolFlag{Name: "default", Usage: "make this the default profile"},
+				},
+				Action: runProfilesSave,
+			},
+		},
+	}
+}
+
+func runProfilesSave(_ context.Context, cmd *cli.Command) error {
+	if cmd.Args().Len() != 1 {
+		return usageErrorf("profiles save: expected a profile name")
+	}
+	name := cmd.Args().First()
+	if len(cmd.StringSlice("header")) > 0 {
+		return usageErrorf("profiles save: --header is not saved; add header auth to the config file with a header_value env or command")
+	}
+
+	f, path, err := loadConfig(cmd)
+	if err != nil {
+		return err
+	}
+	p := &config.Profile{}
+	if existing := f.Profiles[name]; existing != nil {
+		p = existing
+	}
+
+	if url := cmd.String("url"); url != "" {
+		p.URL = url
+	}
+	if p.URL == "" {
+		return usageErrorf("profiles save: --url is required for a new profile")
+	}
+	if cmd.IsSet("timeout") {
+		p.Timeout = cmd.Duration("timeout")
+	}
+	if cmd.IsSet("page-size") {
+		p.PageSize = int(cmd.Int("page-size"))
+	}
+	if cmd.IsSet("collections") {
+		p.Collections = nil
+		for _, c := range cmd.StringSlice("collections") {
+			p.Collections = append(p.Collections, splitList(c)...)
+		}
+	}
+	if cmd.IsSet("download-dir") {
+		p.DownloadDir = cmd.String("download-dir")
+	}
+
+	token, err := secretFlags(cmd, "token")
+	if err != nil {
+		return err
+	}
+	password, err := secretFlags(cmd, "password")
+	if err != nil {
+		return err
+	}
+	username := strings.TrimSpace(cmd.String("username"))
+	switch {
+	case !token.IsZero() && username != "":
+		return usageErrorf("--token and --username are mutually exclusive")
+	case !token.IsZero():
+		p.Auth = config.Auth{Mode: config.AuthBearer, Token: token}
+	case username != "":
+		p.Auth = config.Auth{Mode: config.AuthBasic, Username: username, Password: password}
+	case !password.IsZero():
+		return usageErrorf("profiles save: a password needs --username")
+	}
+
+	if cmd.Bool("plaintext-secrets") {
+		f.AllowPlaintextSecrets = true
+	}
+	f.Set(name, p)
+	if cmd.Bool("default") {
+		f.Default = name
+	}
+	if err := f.Save(path); err != nil {
+		if errors.Is(err, config.ErrPlaintextSecret) {
+			return usageErrorf("profiles save: %v (or pass --plaintext-secrets)", err)
+		}
+		return err
+	}
+	fmt.Fprintln(cmd.ErrWriter, "saved profile", name, "to", path)
+	return nil
+}
+
+// secretFlags returns the secret given by --NAME-env, --NAME-command or
+// --NAME, of which at most one may be set. --NAME is the flag of the save
+// command, which unlike the global one does not read STAC_TOKEN or
+// STAC_PASSWORD: those are what --NAME-env usually points at.
+func secretFlags(cmd *cli.Command, name string) (config.Secret, error) {
+	s := config.Secret{
+		Env:     cmd.String(name + "-env"),
+		Command: strings.Fields(cmd.String(name + "-command")),
+		Value:   cmd.String(name),
+	}
+	n := 0
+	for _, set := range []bool{s.Env != "", len(s.Command) > 0, s.Value != ""} {
+		if set {
+			n++
+		}
+	}
+	if n > 1 {
+		return s, usageErrorf("--%[1]s, --%[1]s-env and --%[1]s-command are mutually exclusive", name)
+	}
+	return s, nil
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/robert-malhotra/go-stac-client/pkg/client"
+	"github.com/urfave/cli/v3"
+)
+
+func searchCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "search",
+		Usage: "search items across collections",
+		Flags: append(append(outputFlags(), searchFlags()...),
+			&cli.BoolFlag{Name: "get", Usage: "search with GET query parameters instead of POST"},
+		),
+		Action: runSearch,
+	}
+}
+
+// searchFlags are the flags read by searchParams.
+func searchFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringSliceFlag{Name: "collections", Aliases: []string{"c"}, Usage: "collection IDs (repeatable or comma-separated, default: the profile's collections)"},
+		&cli.StringFlag{Name: "bbox", Usage: "minLon,minLat,maxLon,maxLat"},
+		&cli.StringFlag{Name: "datetime", Usage: "RFC 3339 instant or interval, e.g. 2024-01-01T00:00:00Z/2024-02-01T00:00:00Z"},
+		&cli.IntFlag{Name: "limit", Usage: "page size requested from the server (default: the profile's page size)"},
+		&cli.StringFlag{Name: "sortby", Usage: "comma-separated fields, prefixed with - for descending, e.g. -eo:cloud_cover,datetime"},
+		&cli.StringFlag{Name: "fields", Usage: "comma-separated fields to include, prefixed with - to exclude, e.g. id,-geometry"},
+		&cli.StringFlag{Name: "filter", Usage: "CQL2-JSON filter, or @FILE to read it from a file"},
+		&cli.StringFlag{Name: "query", Usage: "Query extension expression as JSON, e.g. '{\"eo:cloud_cover\":{\"lt\":10}}'"},
+		&cli.StringFlag{Name: "q", Usage: "free-text query"},
+	}
+}
+
+func runSearch(ctx context.Context, cmd *cli.Command) error {
+	params, err := searchParams(cmd)
+	if err != nil {
+		return err
+	}
+	api, err := newClient(ctx, cmd)
+	if err != nil {
+		return err
+	}
+
+	seq := api.SearchCQL2(ctx, params)
+	if cmd.Bool("get") {
+		seq = api.SearchSimple(ctx, params)
+	}
+	return printSeq(cmd, seq, itemID)
+}
+
+// searchParams builds the search request from the command's flags, with
+// the collections and page size of the profile as defaults.
+func searchParams(cmd *cli.Command) (client.SearchParams, error) {
+	params := client.SearchParams{
+		Datetime: cmd.String("datetime"),
+		Limit:    int(cmd.Int("limit")),
+		Q:        cmd.String("q"),
+	}
+	for _, c := range cmd.StringSlice("collections") {
+		params.Collections = append(params.Collections, splitList(c)...)
+	}
+
+	var err error
+	if params.Bbox, err = parseBbox(cmd.String("bbox")); err != nil {
+		return params, err
+	}
+	params.SortBy = parseSortBy(cmd.String("sortby"))
+	params.Fields = parseFields(cmd.String("fields"))
+
+	if s := cmd.String("filter"); s != "" {
+		data := []byte(s)
+		if name, ok := strings.CutPrefix(s, "@"); ok {
+			if data, err = os.ReadFile(name); err != nil {
+				return params, usageErrorf("--filter: %v", err)
+			}
+		}
+		params.Filter = &client.Filter{}
+		if err := json.Unmarshal(data, params.Filter); err != nil {
+			return params, usageErrorf("--filter: invalid CQL2-JSON: %v", err)
+		}
+	}
+	if s := cmd.String("query"); s != "" {
+		if err := json.Unmarshal([]byte(s), &params.Query); err != nil {
+			return params, usageErrorf("--query: invalid JSON: %v", err)
+		}
+	}
+
+	p, err := loadProfile(cmd)
+	if err != nil {
+		return params, err
+	}
+	if p != nil {
+		if len(params.Collections) == 0 {
+			params.Collections = p.Collections
+		}
+		if params.Limit == 0 {
+			params.Limit = p.PageSize
+		}
+	}
+	return params, nil
+}
+
+// parseBbox parses "minLon,minLat,maxLon,maxLat" (or six values for 3D);
+// an empty string yields nil.
+func parseBbox(s string) ([]float64, error) {
+	if s == "" {
+		return nil, nil
+	}
+	parts := splitList(s)
+	if len(parts) != 4 && len(parts) != 6 {
+		return nil, usageErrorf("--bbox: expected 4 or 6 comma-separated numbers, got %q", s)
+	}
+	bbox := make([]float64, len(parts))
+	for i, p := range parts {
+		v, err := strconv.ParseFloat(p, 64)
+		if err != nil {
+			return nil, usageErrorf("--bbox: invalid number %q", p)
+		}
+		bbox[i] = v
+	}
+	return bbox, nil
+}
+
+// parseSortBy parses "-field,+field,field" into sort fields; fields without
+// a "-" prefix sort ascending.
+func parseSortBy(s string) []client.SortField {
+	var fields []client.SortField
+	for _, f := range splitList(s) {
+		if name, ok := strings.CutPrefix(f, "-"); ok {
+			fields = append(fields, client.SortField{Field: name, Direction: "desc"})
+		} else {
+			fields = append(fields, client.SortField{Field: strings.TrimPrefix(f, "+"), Direction: "asc"})
+		}
+	}
+	return fields
+}
+
+// parseFields parses "a,-b" into a Fields extension filter including a and
+// excluding b.
+func parseFields(s string) *client.FieldsFilter {
+	parts := splitList(s)
+	if len(parts) == 0 {
+		return nil
+	}
+	fields := &client.FieldsFilter{}
+	for _, f := range parts {
+		if name, ok := strings.CutPrefix(f, "-"); ok {
+			fields.Exclude = append(fields.Exclude, name)
+		} else {
+			fields.Include = append(fields.Include, strings.TrimPrefix(f, "+"))
+		}
+	}
+	return fields
+}
+
+// splitList splits a comma-separated list, dropping empty entries.
+func splitList(s string) []string {
+	var out []string
+	for _, p := range strings.Split(s, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			out = append(out, p)
+		}
+	}
+	return out
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/robert-malhotra/go-stac-client/pkg/client"
+	"github.com/robert-malhotra/go-stac-client/pkg/mirror"
+	"github.com/urfave/cli/v3"
+)
+
+func syncCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "sync",
+		Usage: "mirror a collection into a local static catalog, fetching only what changed",
+		Description: "The collection is written to DIR/collection.json and each item to\n" +
+			"DIR/ITEM/ITEM.json, with the assets chosen by --assets or --asset next to it.\n" +
+			"Later runs rewrite only the items whose updated timestamp or checksum\n" +
+			"changed, as recorded in DIR/" + mirror.StateFile + ". Each change is printed as\n" +
+			"+ (added), ~ (updated) or - (deleted), followed by a summary, e.g.\n\n" +
+			"   stac-cli sync --asset thumbnail --delete sentinel-2-l2a mirror/s2",
+		ArgsUsage: "COLLECTION DIR",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{Name: "assets", Usage: "download all assets"},
+			&cli.StringSliceFlag{Name: "asset", Usage: "asset keys to download (repeatable)"},
+			&cli.BoolFlag{Name: "delete", Usage: "remove local items that are no longer in the collection"},
+			&cli.BoolFlag{Name: "dry-run", Usage: "print the changes without making them"},
+		},
+		Action: runSync,
+	}
+}
+
+func runSync(ctx context.Context, cmd *cli.Command) error {
+	args := cmd.Args()
+	if args.Len() != 2 {
+		return usageErrorf("sync: expected a collection ID and a directory")
+	}
+
+	var opts []mirror.Option
+	if keys := cmd.StringSlice("asset"); len(keys) > 0 {
+		opts = append(opts, mirror.WithAssets(keys...))
+	} else if cmd.Bool("assets") {
+		opts = append(opts, mirror.WithAssets())
+	}
+	if cmd.Bool("delete") {
+		opts = append(opts, mirror.WithDeletions())
+	}
+	if cmd.Bool("dry-run") {
+		opts = append(opts, mirror.WithDryRun())
+	}
+	opts = append(opts, mirror.WithProgress(func(c mirror.Change) {
+		fmt.Fprintln(cmd.Writer, c)
+	}))
+
+	// As with download, asset transfers are not bound by the API timeout
+	// unless it is given explicitly.
+	var clientOpts []client.ClientOption
+	if (cmd.Bool("assets") || cmd.IsSet("asset")) && !cmd.IsSet("timeout") {
+		clientOpts = append(clientOpts, client.WithTimeout(0))
+	}
+	api, err := newClient(ctx, cmd, clientOpts...)
+	if err != nil {
+		return err
+	}
+
+	summary, err := mirror.Sync(ctx, api, args.Get(0), args.Get(1), opts...)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(cmd.Writer, summary)
+	return nil
+}
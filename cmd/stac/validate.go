@@ -0,0 +1,136 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/robert-malhotra/go-stac-client/pkg/stac/validate"
+	"github.com/urfave/cli/v3"
+)
+
+func validateCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "validate",
+		Usage: "validate STAC documents against the STAC JSON Schemas, offline",
+		Description: "Core schemas are built in. Extension schemas are read from the --schemas\n" +
+			"directories; --fetch downloads the schemas of the extensions in pkg/stac/ext\n" +
+			"and of the documents' stac_extensions into the first one, e.g.\n\n" +
+			"   stac-cli validate --fetch            # fill the cache once\n" +
+			"   stac-cli validate item.json          # then validate offline",
+		ArgsUsage: "FILE|URL|- ...",
+		Flags: []cli.Flag{
+			&cli.StringSliceFlag{
+				Name:  "schemas",
+				Usage: "directory of cached schemas laid out by host and path (repeatable)",
+				Value: []string{validate.DefaultSchemaDir()},
+			},
+			&cli.BoolFlag{
+				Name:  "ignore-missing",
+				Usage: "skip extensions whose schemas are not cached",
+			},
+			&cli.BoolFlag{
+				Name:  "fetch",
+				Usage: "download missing extension schemas into the first --schemas directory",
+			},
+		},
+		Action: runValidate,
+	}
+}
+
+// runValidate validates each argument and prints one line per document, or
+// per violation. It exits with status 1 if any document is invalid.
+func runValidate(ctx context.Context, cmd *cli.Command) error {
+	sources := cmd.Args().Slice()
+	fetch := cmd.Bool("fetch")
+	if len(sources) == 0 && !fetch {
+		return fmt.Errorf("validate: expected at least one file, URL or -")
+	}
+
+	docs := make([][]byte, len(sources))
+	for i, src := range sources {
+		data, err := readSource(ctx, src)
+		if err != nil {
+			return err
+		}
+		docs[i] = data
+	}
+
+	dirs := cmd.StringSlice("schemas")
+	if fetch {
+		if len(dirs) == 0 || dirs[0] == "" {
+			return usageErrorf("validate: --fetch needs a --schemas directory")
+		}
+		urls := slices.Clone(validate.ExtensionSchemas)
+		for _, data := range docs {
+			urls = append(urls, validate.Extensions(data)...)
+		}
+		fetched, err := validate.FetchSchemas(ctx, nil, dirs[0], urls...)
+		for _, u := range fetched {
+			fmt.Fprintf(cmd.ErrWriter, "fetched %s\n", u)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	var opts []validate.Option
+	for _, dir := range dirs {
+		opts = append(opts, validate.WithSchemaDir(dir))
+	}
+	if cmd.Bool("ignore-missing") {
+		opts = append(opts, validate.IgnoreMissingSchemas())
+	}
+	v := validate.New(opts...)
+
+	invalid := 0
+	for i, src := range sources {
+		err := v.Validate(docs[i])
+		var verr *validate.ValidationError
+		switch {
+		case err == nil:
+			fmt.Fprintf(cmd.Writer, "%s: valid\n", src)
+		case errors.As(err, &verr):
+			invalid++
+			for _, e := range verr.Errors {
+				fmt.Fprintf(cmd.Writer, "%s: %s\n", src, e)
+			}
+		default:
+			invalid++
+			fmt.Fprintf(cmd.Writer, "%s: %v\n", src, err)
+		}
+	}
+
+	if invalid > 0 {
+		return cli.Exit(fmt.Sprintf("%d of %d documents invalid", invalid, len(sources)), 1)
+	}
+	return nil
+}
+
+// readSource reads a local file, an http(s) URL, or stdin for "-".
+func readSource(ctx context.Context, src string) ([]byte, error) {
+	switch {
+	case src == "-":
+		return io.ReadAll(os.Stdin)
+	case strings.HasPrefix(src, "http://"), strings.HasPrefix(src, "https://"):
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, src, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch %s: %w", src, err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("failed to fetch %s: unexpected status %s", src, resp.Status)
+		}
+		return io.ReadAll(resp.Body)
+	}
+	return os.ReadFile(src)
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"time"
+
+	"github.com/robert-malhotra/go-stac-client/pkg/client"
+	"github.com/urfave/cli/v3"
+)
+
+func watchCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "watch",
+		Usage: "poll a search and report newly published or updated items",
+		Description: "Each new or updated item is printed as an NDJSON event,\n" +
+			`{"type":"new"|"updated","time":...,"item":{...}}, or passed to --exec.` + "\n" +
+			"With --state the high-water mark is saved after every poll, so a restarted\n" +
+			"watch (or a cron job using --once) continues where the last one stopped, e.g.\n\n" +
+			"   stac-cli watch -c sentinel-1-grd --bbox 5.9,47.3,10.5,47.8 --state s1.json \\\n" +
+			"     --exec 'jq -r .item.id >> new-scenes.txt'",
+		Flags: append(searchFlags(),
+			&cli.DurationFlag{Name: "interval", Usage: "time between polls", Value: client.DefaultWatchInterval},
+			&cli.DurationFlag{Name: "lookback", Usage: "how far before the high-water mark each poll searches, for late arrivals and clock skew", Value: client.DefaultWatchLookback},
+			&cli.StringFlag{Name: "field", Usage: "item timestamp to watch: updated, created or datetime", Value: client.DefaultWatchField},
+			&cli.StringFlag{Name: "since", Usage: "RFC 3339 start of a new watch (default: now)"},
+			&cli.StringFlag{Name: "state", Usage: "file keeping the high-water mark between runs"},
+			&cli.StringFlag{Name: "exec", Usage: "shell command run for each event, with the event JSON on stdin and STAC_EVENT, STAC_COLLECTION and STAC_ITEM_ID set"},
+			&cli.BoolFlag{Name: "once", Usage: "poll once and exit"},
+		),
+		Action: runWatch,
+	}
+}
+
+func runWatch(ctx context.Context, cmd *cli.Command) error {
+	params, err := searchParams(cmd)
+	if err != nil {
+		return err
+	}
+
+	field := cmd.String("field")
+	switch field {
+	case "updated", "created", "datetime":
+	default:
+		return usageErrorf("--field: unsupported watch field %q", field)
+	}
+
+	opts := []client.WatchOption{
+		client.WithWatchInterval(cmd.Duration("interval")),
+		client.WithWatchLookback(cmd.Duration("lookback")),
+		client.WithWatchField(field),
+	}
+	if s := cmd.String("since"); s != "" {
+		since, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			return usageErrorf("--since: %v", err)
+		}
+		opts = append(opts, client.WithWatchSince(since))
+	}
+	if cmd.Bool("once") {
+		opts = append(opts, client.WithWatchMaxPolls(1))
+	}
+
+	state := &client.WatchState{}
+	if path := cmd.String("state"); path != "" {
+		if state, err = client.LoadWatchState(path); err != nil {
+			return err
+		}
+		if state.Field != "" && state.Field != field {
+			return fmt.Errorf("%s is the state of a watch on %q, not %q", path, state.Field, field)
+		}
+		opts = append(opts, client.WithWatchCheckpoint(func(s *client.WatchState) error {
+			return client.SaveWatchState(path, s)
+		}))
+	}
+
+	api, err := newClient(ctx, cmd)
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(cmd.Writer)
+	var lastErr error
+	for event, err := range api.Watch(ctx, params, state, opts...) {
+		if err != nil {
+			if ctx.Err() != nil || cmd.Bool("once") {
+				return err
+			}
+			// Keep polling through transient search errors.
+			fmt.Fprintf(cmd.ErrWriter, "watch: %v\n", err)
+			lastErr = err
+			continue
+		}
+		lastErr = nil
+		if hook := cmd.String("exec"); hook != "" {
+			if err := runHook(ctx, cmd, hook, event); err != nil {
+				// Stopping before the next checkpoint makes a restarted
+				// watch deliver the event again.
+				return err
+			}
+			continue
+		}
+		if err := enc.Encode(event); err != nil {
+			return err
+		}
+	}
+	// Without --once the watch only ends by itself after an error that
+	// polling again cannot fix.
+	return lastErr
+}
+
+// runHook runs the --exec command for one event.
+func runHook(ctx context.Context, cmd *cli.Command, hook string, event *client.WatchEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	c := exec.CommandContext(ctx, "sh", "-c", hook)
+	c.Stdin = bytes.NewReader(append(data, '\n'))
+	c.Stdout = cmd.Writer
+	c.Stderr = cmd.ErrWriter
+	c.Env = append(os.Environ(),
+		"STAC_EVENT="+event.Type,
+		"STAC_COLLECTION="+event.Item.Collection,
+		"STAC_ITEM_ID="+event.Item.Id,
+	)
+	if err := c.Run(); err != nil {
+		return fmt.Errorf("--exec for item %s: %w", event.Item.Id, err)
+	}
+	return nil
+}
@@ -2,42 +2,54 @@ package main
 
 import (
 	"context"
-	"errors"
 	"fmt"
-	"sync"
-	"sync/atomic"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
 
-	"github.com/robert-malhotra/go-stac-client/pkg/stac"
+	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 	"github.com/robert-malhotra/go-stac-client/cmd/tui/formatting"
+	"github.com/robert-malhotra/go-stac-client/pkg/client"
+	"github.com/robert-malhotra/go-stac-client/pkg/stac"
 )
 
-type downloadSession struct {
-	cancel func()
-}
+const (
+	downloadConcurrency   = 2
+	downloadsRefreshEvery = 500 * time.Millisecond
+	downloadsHelpControls = "[yellow]↑/↓[white] select  [yellow]p[white] pause/resume  [yellow]x[white] cancel  [yellow]c[white] clear finished  [yellow]Esc[white] back  [yellow]Ctrl+C[white] quit"
+)
 
-func (t *TUI) setActiveDownload(session *downloadSession) {
-	t.downloadMu.Lock()
-	t.activeDownload = session
-	t.downloadMu.Unlock()
+// downloadEntry is a queued asset download shown on the downloads page.
+type downloadEntry struct {
+	transfer *client.Transfer
+	dest     string
 }
 
-func (t *TUI) clearActiveDownload(session *downloadSession) {
+// currentDownloadQueue returns the queue for the active client, creating it
+// on first use. Queues of earlier clients keep running until the TUI stops.
+func (t *TUI) currentDownloadQueue() *client.DownloadQueue {
 	t.downloadMu.Lock()
-	if t.activeDownload == session {
-		t.activeDownload = nil
+	defer t.downloadMu.Unlock()
+
+	if t.downloadQueue == nil || t.downloadQueueClient != t.client {
+		t.downloadQueue = t.client.NewDownloadQueue(t.baseCtx, downloadConcurrency)
+		t.downloadQueueClient = t.client
+		t.downloadQueues = append(t.downloadQueues, t.downloadQueue)
 	}
-	t.downloadMu.Unlock()
+	return t.downloadQueue
 }
 
-func (t *TUI) cancelActiveDownload() {
+func (t *TUI) closeDownloadQueues() {
 	t.downloadMu.Lock()
-	session := t.activeDownload
-	t.activeDownload = nil
+	queues := t.downloadQueues
+	t.downloadQueues = nil
+	t.downloadQueue = nil
 	t.downloadMu.Unlock()
 
-	if session != nil && session.cancel != nil {
-		session.cancel()
+	for _, q := range queues {
+		q.Close()
 	}
 }
 
@@ -51,86 +63,189 @@ func (t *TUI) downloadAsset(asset *stac.Asset) {
 		return
 	}
 
-	ctx, cancel := context.WithCancel(t.baseCtx)
-
-	modal := tview.NewModal().
-		SetText(fmt.Sprintf("Preparing download...\n%s", asset.Href)).
-		AddButtons([]string{"Cancel"})
-
-	var (
-		cancelOnce    sync.Once
-		closePageOnce sync.Once
-		userCancelled atomic.Bool
-	)
-
-	closeDownloadPage := func() {
-		closePageOnce.Do(func() {
-			go t.app.QueueUpdateDraw(func() {
-				t.pages.HidePage(pageDownload)
-				t.pages.RemovePage(pageDownload)
-				t.restoreFocusAfterModal()
-			})
-		})
-	}
-
-	session := &downloadSession{
-		cancel: func() {
-			cancelOnce.Do(func() {
-				userCancelled.Store(true)
-				cancel()
-				closeDownloadPage()
-			})
-		},
-	}
-	t.setActiveDownload(session)
-
-	modal.SetDoneFunc(func(buttonIndex int, buttonLabel string) {
-		session.cancel()
-	})
-
-	t.pages.RemovePage(pageDownload)
-	t.pages.AddPage(pageDownload, modal, true, true)
-	t.app.SetFocus(modal)
-
 	dest := formatting.GetOutputFilename(asset.Href)
-
-	updateProgress := func(downloaded, total int64) {
-		if userCancelled.Load() {
+	if t.profile != nil && t.profile.DownloadDir != "" {
+		dir := t.profile.DownloadDirectory()
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.showError(fmt.Sprintf("Download failed: %v", err))
 			return
 		}
-		progressText := formatting.RenderDownloadProgress(downloaded, total)
-		modal.SetText(fmt.Sprintf("Downloading %s\n%s", asset.Href, progressText))
+		dest = filepath.Join(dir, dest)
 	}
+	req := client.AssetDownloadRequest(asset, client.AtomicFileDestination(dest))
 
-	go func() {
-		defer cancel()
-		defer t.clearActiveDownload(session)
+	transfer, err := t.currentDownloadQueue().Enqueue(req)
+	if err != nil {
+		t.showError(fmt.Sprintf("Download failed: %v", err))
+		return
+	}
 
-		err := t.client.DownloadAssetWithProgress(ctx, asset.Href, dest, func(downloaded, total int64) {
-			t.app.QueueUpdateDraw(func() { updateProgress(downloaded, total) })
-		})
+	t.downloadMu.Lock()
+	t.downloads = append(t.downloads, downloadEntry{transfer: transfer, dest: dest})
+	t.downloadMu.Unlock()
 
-		if userCancelled.Load() || errors.Is(err, context.Canceled) {
-			return
-		}
+	t.showInfo(fmt.Sprintf("Queued %s\nSaving to %s\n\nPress d to view the download queue.", asset.Href, dest))
+}
 
-		if err != nil {
-			closeDownloadPage()
-			t.showError(fmt.Sprintf("Download failed: %v", err))
-			return
-		}
+func (t *TUI) setupDownloadsPage() {
+	t.downloadsTable = tview.NewTable().
+		SetSelectable(true, false).
+		SetFixed(1, 0)
+	t.downloadsTable.SetBorder(true).SetTitle("Downloads")
+
+	help := formatting.MakeHelpText(downloadsHelpControls)
+	page := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(t.downloadsTable, 0, 1, true).
+		AddItem(help, 3, 0, false)
+
+	t.pages.AddPage(pageDownloads, page, true, false)
+}
+
+// showDownloads switches to the downloads page and refreshes it periodically
+// until the page is closed.
+func (t *TUI) showDownloads() {
+	currentPage, _ := t.pages.GetFrontPage()
+	if currentPage != pageDownloads {
+		t.downloadsReturnPage = currentPage
+	}
 
-		t.app.QueueUpdateDraw(func() {
-			if userCancelled.Load() {
+	t.refreshDownloads()
+	t.pages.SwitchToPage(pageDownloads)
+	t.app.SetFocus(t.downloadsTable)
+
+	if t.downloadsRefreshCancel != nil {
+		t.downloadsRefreshCancel()
+	}
+	ctx, cancel := context.WithCancel(t.baseCtx)
+	t.downloadsRefreshCancel = cancel
+
+	go func() {
+		ticker := time.NewTicker(downloadsRefreshEvery)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
 				return
+			case <-ticker.C:
+				t.app.QueueUpdateDraw(t.refreshDownloads)
 			}
-			modal.SetText(fmt.Sprintf("Asset downloaded to %s", dest))
-			modal.ClearButtons()
-			modal.AddButtons([]string{"Close"})
-			modal.SetDoneFunc(func(buttonIndex int, buttonLabel string) {
-				closeDownloadPage()
-			})
-			t.app.SetFocus(modal)
-		})
+		}
 	}()
 }
+
+func (t *TUI) closeDownloads() {
+	if t.downloadsRefreshCancel != nil {
+		t.downloadsRefreshCancel()
+		t.downloadsRefreshCancel = nil
+	}
+
+	returnPage := t.downloadsReturnPage
+	if returnPage == "" {
+		returnPage = pageInput
+	}
+	t.pages.SwitchToPage(returnPage)
+	t.restoreFocusAfterModal()
+}
+
+func (t *TUI) refreshDownloads() {
+	t.downloadMu.Lock()
+	entries := slices.Clone(t.downloads)
+	t.downloadMu.Unlock()
+
+	table := t.downloadsTable
+	selected, _ := table.GetSelection()
+	table.Clear()
+
+	for col, title := range []string{"State", "Progress", "Asset", "Destination"} {
+		table.SetCell(0, col, tview.NewTableCell(title).
+			SetTextColor(tcell.ColorYellow).
+			SetSelectable(false))
+	}
+
+	if len(entries) == 0 {
+		table.SetCell(1, 0, tview.NewTableCell("No downloads queued. Press Enter on an asset to add one.").
+			SetSelectable(false))
+		return
+	}
+
+	for i, entry := range entries {
+		row := i + 1
+		state := entry.transfer.State()
+		downloaded, total := entry.transfer.Progress()
+
+		progress := formatting.RenderDownloadProgress(downloaded, total)
+		if err := entry.transfer.Err(); state == client.TransferFailed && err != nil {
+			progress = fmt.Sprintf("[red]%v[white]", err)
+		}
+
+		table.SetCell(row, 0, tview.NewTableCell(state.String()).SetTextColor(downloadStateColor(state)))
+		table.SetCell(row, 1, tview.NewTableCell(progress))
+		table.SetCell(row, 2, tview.NewTableCell(entry.transfer.Href()).SetExpansion(1).SetMaxWidth(60))
+		table.SetCell(row, 3, tview.NewTableCell(entry.dest))
+	}
+
+	selected = min(max(selected, 1), len(entries))
+	table.Select(selected, 0)
+}
+
+func downloadStateColor(state client.TransferState) tcell.Color {
+	switch state {
+	case client.TransferActive:
+		return tcell.ColorGreen
+	case client.TransferPaused:
+		return tcell.ColorYellow
+	case client.TransferFailed:
+		return tcell.ColorRed
+	case client.TransferDone:
+		return tcell.ColorDarkCyan
+	default:
+		return tcell.ColorWhite
+	}
+}
+
+// selectedDownload returns the transfer under the cursor on the downloads page.
+func (t *TUI) selectedDownload() *client.Transfer {
+	row, _ := t.downloadsTable.GetSelection()
+
+	t.downloadMu.Lock()
+	defer t.downloadMu.Unlock()
+	if row < 1 || row > len(t.downloads) {
+		return nil
+	}
+	return t.downloads[row-1].transfer
+}
+
+func (t *TUI) toggleSelectedDownload() {
+	transfer := t.selectedDownload()
+	if transfer == nil {
+		return
+	}
+	if transfer.State() == client.TransferPaused {
+		transfer.Resume()
+	} else {
+		transfer.Pause()
+	}
+	t.refreshDownloads()
+}
+
+func (t *TUI) cancelSelectedDownload() {
+	if transfer := t.selectedDownload(); transfer != nil {
+		transfer.Cancel()
+	}
+	t.refreshDownloads()
+}
+
+func (t *TUI) clearFinishedDownloads() {
+	t.downloadMu.Lock()
+	t.downloads = slices.DeleteFunc(t.downloads, func(entry downloadEntry) bool {
+		return entry.transfer.State().Finished()
+	})
+	queues := slices.Clone(t.downloadQueues)
+	t.downloadMu.Unlock()
+
+	for _, q := range queues {
+		q.ClearFinished()
+	}
+	t.refreshDownloads()
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/rivo/tview"
+	"github.com/robert-malhotra/go-stac-client/cmd/tui/formatting"
+	"github.com/robert-malhotra/go-stac-client/pkg/output"
+)
+
+const pageExport = "export"
+
+// exportFormatLabels are the dialog buttons for output.Formats, in order.
+var exportFormatLabels = []string{"NDJSON", "GeoJSON", "CSV", "Table"}
+
+// exportItems offers to save the loaded items to a file.
+func (t *TUI) exportItems() {
+	docs := make([]any, len(t.items))
+	for i, item := range t.items {
+		docs[i] = item
+	}
+	t.showExportDialog(fmt.Sprintf("Results %s", t.activeResultLabel), docs)
+}
+
+// exportCollections offers to save the listed collections to a file.
+func (t *TUI) exportCollections() {
+	docs := make([]any, len(t.cols))
+	for i, col := range t.cols {
+		docs[i] = col
+	}
+	t.showExportDialog("Collections", docs)
+}
+
+// showExportDialog asks for an output format and writes docs to a file in
+// the working directory named after title.
+func (t *TUI) showExportDialog(title string, docs []any) {
+	if len(docs) == 0 {
+		return
+	}
+	modal := tview.NewModal().
+		SetText(fmt.Sprintf("Export %d documents as", len(docs))).
+		AddButtons(append(exportFormatLabels, "Cancel")).
+		SetDoneFunc(func(index int, _ string) {
+			t.pages.HidePage(pageExport)
+			t.restoreFocusAfterModal()
+			if index >= 0 && index < len(output.Formats) {
+				go t.export(title, docs, output.Formats[index])
+			}
+		})
+	t.pages.RemovePage(pageExport)
+	t.pages.AddPage(pageExport, modal, false, true)
+	t.pages.ShowPage(pageExport)
+}
+
+func (t *TUI) export(title string, docs []any, format output.Format) {
+	filename := formatting.GenerateFilename(title, format.Ext())
+	if err := writeExport(filename, docs, format); err != nil {
+		t.showError(fmt.Sprintf("Failed to export: %v", err))
+		return
+	}
+	t.showInfo(fmt.Sprintf("Exported %d documents to %s", len(docs), filename))
+}
+
+func writeExport(filename string, docs []any, format output.Format) error {
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	w, err := output.NewWriter(f, format)
+	if err != nil {
+		f.Close()
+		return err
+	}
+	for _, doc := range docs {
+		if err := w.Write(doc); err != nil {
+			f.Close()
+			return err
+		}
+	}
+	if err := w.Close(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -4,7 +4,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/robert-malhotra/go-stac-client/pkg/stac"
 )
@@ -70,25 +72,22 @@ func FormatCollectionDetails(col *stac.Collection) string {
 
 	if col.Extent != nil {
 		builder.WriteString("[yellow]Extent:[white]\n")
-		if col.Extent.Spatial != nil && len(col.Extent.Spatial.Bbox) > 0 {
-			total := len(col.Extent.Spatial.Bbox)
-			for i, bbox := range col.Extent.Spatial.Bbox {
-				label := "  Spatial bbox"
-				if total > 1 {
-					label = fmt.Sprintf("%s %d", label, i+1)
-				}
-				builder.WriteString(fmt.Sprintf("%s: %s\n", label, formatFloatSlice(bbox)))
+		if bbox := col.Extent.Spatial.Overall(); bbox != nil {
+			builder.WriteString(fmt.Sprintf("  Spatial bbox: %s\n", formatFloatSlice(bbox)))
+			for i, sub := range col.Extent.Spatial.SubBboxes() {
+				builder.WriteString(fmt.Sprintf("  Sub-bbox %d: %s\n", i+1, formatFloatSlice(sub)))
 			}
 		}
-		if col.Extent.Temporal != nil && len(col.Extent.Temporal.Interval) > 0 {
-			total := len(col.Extent.Temporal.Interval)
-			for i, interval := range col.Extent.Temporal.Interval {
-				label := "  Temporal interval"
-				if total > 1 {
-					label = fmt.Sprintf("%s %d", label, i+1)
-				}
-				builder.WriteString(fmt.Sprintf("%s: %s\n", label, formatTemporalInterval(interval)))
+		intervals, err := col.Extent.Temporal.Intervals()
+		if err != nil {
+			builder.WriteString(fmt.Sprintf("  Temporal interval: invalid (%v)\n", err))
+		}
+		for i, interval := range intervals {
+			label := "  Temporal interval"
+			if len(intervals) > 1 {
+				label = fmt.Sprintf("%s %d", label, i+1)
 			}
+			builder.WriteString(fmt.Sprintf("%s: %s\n", label, formatTemporalInterval(interval)))
 		}
 	}
 
@@ -100,6 +99,20 @@ func FormatCollectionDetails(col *stac.Collection) string {
 		}
 		sort.Strings(keys)
 		for _, key := range keys {
+			summary, ok := col.Summary(key)
+			if ok && summary.Kind == stac.SummaryRange {
+				builder.WriteString(fmt.Sprintf("  %s: %s – %s\n", key, formatSummaryValue(summary.Range.Minimum), formatSummaryValue(summary.Range.Maximum)))
+				continue
+			}
+			if ok && summary.Kind == stac.SummaryValues && allScalars(summary.Values) {
+				values := make([]string, len(summary.Values))
+				for i, v := range summary.Values {
+					values[i] = formatSummaryValue(v)
+				}
+				builder.WriteString(fmt.Sprintf("  %s: %s\n", key, strings.Join(values, ", ")))
+				continue
+			}
+
 			value := col.Summaries[key]
 			jsonBytes, err := json.MarshalIndent(value, "", "  ")
 			if err != nil {
@@ -181,13 +194,33 @@ func FormatCollectionDetails(col *stac.Collection) string {
 	return strings.TrimRight(builder.String(), "\n")
 }
 
-func formatTemporalInterval(interval []any) string {
-	if len(interval) == 0 {
-		return "[]"
+func formatTemporalInterval(interval stac.TimeInterval) string {
+	bound := func(t time.Time) string {
+		if t.IsZero() {
+			return "open"
+		}
+		return t.UTC().Format(time.RFC3339)
+	}
+	return fmt.Sprintf("%s to %s", bound(interval.Start), bound(interval.End))
+}
+
+func formatSummaryValue(v any) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
 	}
-	jsonBytes, err := json.Marshal(interval)
-	if err != nil {
-		return fmt.Sprintf("%v", interval)
+	return fmt.Sprintf("%v", v)
+}
+
+func allScalars(values []any) bool {
+	for _, v := range values {
+		switch v.(type) {
+		case string, float64, bool:
+		default:
+			return false
+		}
 	}
-	return string(jsonBytes)
+	return true
 }
@@ -114,9 +114,15 @@ func GetOutputFilename(assetUrl string) string {
 }
 
 func GenerateJSONFilename(title string) string {
+	return GenerateFilename(title, ".json")
+}
+
+// GenerateFilename returns a timestamped file name for title with extension
+// ext, including the dot.
+func GenerateFilename(title, ext string) string {
 	slug := Slugify(title)
 	if slug == "" {
 		slug = "stac_object"
 	}
-	return fmt.Sprintf("%s_%s.json", slug, time.Now().Format("20060102_150405"))
+	return fmt.Sprintf("%s_%s%s", slug, time.Now().Format("20060102_150405"), ext)
 }
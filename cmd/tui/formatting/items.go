@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/robert-malhotra/go-stac-client/pkg/stac"
 )
@@ -12,15 +13,22 @@ import (
 func FormatItemSummary(item *stac.Item) string {
 	var builder strings.Builder
 	builder.WriteString(fmt.Sprintf("[yellow]ID: [white]%s\n", item.Id))
-	if dt, ok := item.Properties["datetime"].(string); ok {
-		builder.WriteString(fmt.Sprintf("[yellow]Datetime: [white]%s\n", dt))
+	if text := formatItemTime(item); text != "" {
+		builder.WriteString(text)
 	}
-	if p, ok := item.Properties["platform"].(string); ok {
+	if p := item.Platform(); p != "" {
 		builder.WriteString(fmt.Sprintf("[yellow]Platform: [white]%s\n", p))
 	}
-	if c, ok := item.Properties["constellation"].(string); ok {
+	if c := item.Constellation(); c != "" {
 		builder.WriteString(fmt.Sprintf("[yellow]Constellation: [white]%s\n", c))
 	}
+	if instruments := item.Instruments(); len(instruments) > 0 {
+		builder.WriteString(fmt.Sprintf("[yellow]Instruments: [white]%s\n", strings.Join(instruments, ", ")))
+	}
+	if gsd, ok := item.GSD(); ok {
+		builder.WriteString(fmt.Sprintf("[yellow]GSD: [white]%g m\n", gsd))
+	}
+	builder.WriteString(formatFootprint(item))
 	if geomText := FormatGeometry(item.Geometry); geomText != "" {
 		builder.WriteString("[yellow]Geometry:[white]\n")
 		builder.WriteString(geomText)
@@ -31,6 +39,63 @@ func FormatItemSummary(item *stac.Item) string {
 	return builder.String()
 }
 
+// formatItemTime renders the item's datetime, or its start/end range when
+// datetime is null.
+func formatItemTime(item *stac.Item) string {
+	if dt, err := item.Datetime(); err != nil {
+		return fmt.Sprintf("[yellow]Datetime: [red]%v[white]\n", err)
+	} else if !dt.IsZero() {
+		return fmt.Sprintf("[yellow]Datetime: [white]%s\n", dt.Format(time.RFC3339))
+	}
+
+	tr, err := item.TimeRange()
+	if err != nil {
+		return fmt.Sprintf("[yellow]Time range: [red]%v[white]\n", err)
+	}
+	if tr.Start.IsZero() && tr.End.IsZero() {
+		return ""
+	}
+	return fmt.Sprintf("[yellow]Time range: [white]%s to %s\n", formatOptionalTime(tr.Start), formatOptionalTime(tr.End))
+}
+
+// formatFootprint renders the centroid, area and bbox of the item's
+// geometry.
+func formatFootprint(item *stac.Item) string {
+	g, err := item.OrbGeometry()
+	if err != nil {
+		return fmt.Sprintf("[yellow]Footprint: [red]%v[white]\n", err)
+	}
+	if g == nil {
+		return ""
+	}
+
+	var builder strings.Builder
+	if c, err := item.Centroid(); err == nil {
+		builder.WriteString(fmt.Sprintf("[yellow]Centroid: [white]%.5f, %.5f\n", c.Lon(), c.Lat()))
+	}
+	if area, err := item.Area(); err == nil && area > 0 {
+		builder.WriteString(fmt.Sprintf("[yellow]Area: [white]%.2f km²\n", area/1e6))
+	}
+	if bbox := stac.GeometryBbox(g); bbox != nil {
+		note := ""
+		if stac.BboxCrossesAntimeridian(bbox) {
+			note = " (crosses antimeridian)"
+		}
+		builder.WriteString(fmt.Sprintf("[yellow]Bbox: [white]%.5f, %.5f, %.5f, %.5f%s\n", bbox[0], bbox[1], bbox[2], bbox[3], note))
+	}
+	if err := item.CheckBbox(1e-6); err != nil && item.Bbox != nil {
+		builder.WriteString(fmt.Sprintf("[yellow]Bbox check: [red]%v[white]\n", err))
+	}
+	return builder.String()
+}
+
+func formatOptionalTime(t time.Time) string {
+	if t.IsZero() {
+		return ".."
+	}
+	return t.Format(time.RFC3339)
+}
+
 func FormatProperties(properties map[string]interface{}, indent int) string {
 	var builder strings.Builder
 	keys := make([]string, 0, len(properties))
@@ -2,10 +2,12 @@ package main
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
+	"github.com/robert-malhotra/go-stac-client/pkg/stac"
 )
 
 func (t *TUI) onInputDone(key tcell.Key) {
@@ -27,6 +29,11 @@ func (t *TUI) onInputCapture(event *tcell.EventKey) *tcell.EventKey {
 
 	currentPage, _ := t.pages.GetFrontPage()
 
+	// The collections filter box takes its own keys, including Esc.
+	if t.collectionFilter != nil && t.collectionFilter.HasFocus() {
+		return event
+	}
+
 	if currentPage == pageInput {
 		switch event.Key() {
 		case tcell.KeyTab:
@@ -65,12 +72,53 @@ func (t *TUI) onInputCapture(event *tcell.EventKey) *tcell.EventKey {
 				}
 				return nil
 			}
+		case r == 'r' || r == 'R':
+			if currentPage == pageItems && len(t.items) > 0 {
+				results := stac.NewItemCollection(slices.Clone(t.items))
+				t.showJSON(fmt.Sprintf("Results %s (%d items)", t.activeResultLabel, len(t.items)), results)
+				return nil
+			}
+		case r == 'e' || r == 'E':
+			switch currentPage {
+			case pageCollections:
+				t.exportCollections()
+				return nil
+			case pageItems:
+				t.exportItems()
+				return nil
+			}
+		case r == '/':
+			if currentPage == pageCollections {
+				t.app.SetFocus(t.collectionFilter)
+				return nil
+			}
 		case r == 's' || r == 'S':
 			switch currentPage {
 			case pageCollections, pageItems:
 				t.openBasicSearchForm()
 				return nil
 			}
+		case r == 'd' || r == 'D':
+			switch currentPage {
+			case pageCollections, pageItems, pageItemDetail:
+				t.showDownloads()
+				return nil
+			}
+		}
+	}
+
+	// Download queue controls
+	if currentPage == pageDownloads && event.Key() == tcell.KeyRune {
+		switch event.Rune() {
+		case 'p', 'P':
+			t.toggleSelectedDownload()
+			return nil
+		case 'x', 'X':
+			t.cancelSelectedDownload()
+			return nil
+		case 'c', 'C':
+			t.clearFinishedDownloads()
+			return nil
 		}
 	}
 
@@ -135,11 +183,10 @@ func (t *TUI) onInputCapture(event *tcell.EventKey) *tcell.EventKey {
 		}
 
 		switch currentPage {
-		case pageDownload:
-			t.cancelActiveDownload()
-			t.restoreFocusAfterModal()
+		case pageDownloads:
+			t.closeDownloads()
 			return nil
-		case pageError, pageInfo:
+		case pageError, pageInfo, pageExport:
 			t.pages.HidePage(currentPage)
 			t.restoreFocusAfterModal()
 			return nil
@@ -212,6 +259,9 @@ func (t *TUI) currentAuthConfig() authConfig {
 
 func (t *TUI) inputPageFocusOrder() []tview.Primitive {
 	var fields []tview.Primitive
+	if t.profileDropDown != nil {
+		fields = append(fields, t.profileDropDown)
+	}
 	if t.input != nil {
 		fields = append(fields, t.input)
 	}
@@ -1,14 +1,19 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 	"github.com/robert-malhotra/go-stac-client/cmd/tui/formatting"
+	"github.com/robert-malhotra/go-stac-client/pkg/stac/validate"
 )
 
 const jsonPageID = "jsonView"
@@ -67,7 +72,7 @@ func (v *jsonViewer) Show(title string, value any) {
 			textView.SetText(text)
 			textView.SetInputCapture(v.handleInput)
 
-			instructions := formatting.MakeHelpText("[yellow]Esc[white] close  |  [yellow]s[white] save JSON  |  [yellow]Ctrl+C[white] quit")
+			instructions := formatting.MakeHelpText("[yellow]Esc[white] close  |  [yellow]s[white] save JSON  |  [yellow]v[white] validate  |  [yellow]Ctrl+C[white] quit")
 			layout := tview.NewFlex().
 				SetDirection(tview.FlexRow).
 				AddItem(textView, 0, 1, true).
@@ -125,6 +130,58 @@ func (v *jsonViewer) Save() {
 	v.tui.showInfo(fmt.Sprintf("JSON saved to %s", filename))
 }
 
+// maxValidationErrors caps how many violations the validation dialog lists.
+const maxValidationErrors = 10
+
+// schemaFetchTimeout bounds downloading the extension schemas of a
+// document into the schema cache before validating it.
+const schemaFetchTimeout = 15 * time.Second
+
+// Validate checks the displayed document against the STAC JSON Schemas and
+// reports the result in a dialog. Extension schemas not yet in the schema
+// cache are downloaded first.
+func (v *jsonViewer) Validate() {
+	v.mu.Lock()
+	data := append([]byte(nil), v.snapshotData...)
+	v.mu.Unlock()
+
+	if len(data) == 0 {
+		return
+	}
+
+	dir := validate.DefaultSchemaDir()
+	if dir != "" {
+		// Best effort: without network access the validator reports the
+		// schemas that are still missing.
+		ctx, cancel := context.WithTimeout(v.tui.baseCtx, schemaFetchTimeout)
+		validate.FetchSchemas(ctx, nil, dir, validate.Extensions(data)...)
+		cancel()
+	}
+	validator := validate.New(validate.WithSchemaDir(dir))
+	err := validator.Validate(data)
+	if err == nil {
+		v.tui.showInfo("Valid STAC document")
+		return
+	}
+
+	var verr *validate.ValidationError
+	if !errors.As(err, &verr) {
+		v.tui.showError(fmt.Sprintf("Validation failed: %v", err))
+		return
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Invalid STAC document (%d errors):\n", len(verr.Errors))
+	for i, e := range verr.Errors {
+		if i == maxValidationErrors {
+			fmt.Fprintf(&b, "\n... and %d more", len(verr.Errors)-i)
+			break
+		}
+		fmt.Fprintf(&b, "\n%s", tview.Escape(e.String()))
+	}
+	v.tui.showError(b.String())
+}
+
 func (v *jsonViewer) handleInput(event *tcell.EventKey) *tcell.EventKey {
 	switch event.Key() {
 	case tcell.KeyCtrlC:
@@ -135,9 +192,13 @@ func (v *jsonViewer) handleInput(event *tcell.EventKey) *tcell.EventKey {
 		return nil
 	case tcell.KeyRune:
 		r := event.Rune()
-		if r == 's' || r == 'S' {
+		switch r {
+		case 's', 'S':
 			go v.Save()
 			return nil
+		case 'v', 'V':
+			go v.Validate()
+			return nil
 		}
 	}
 
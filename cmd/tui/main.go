@@ -2,17 +2,30 @@ package main
 
 import (
 	"context"
+	"errors"
+	"flag"
 	"fmt"
+	"io/fs"
 	"os"
 	"os/signal"
 	"syscall"
+
+	"github.com/robert-malhotra/go-stac-client/pkg/config"
 )
 
 func main() {
+	configPath := flag.String("config", os.Getenv("STAC_CONFIG"), "config file with connection profiles (default: $XDG_CONFIG_HOME/go-stac-client/config.yaml)")
+	profile := flag.String("profile", os.Getenv("STAC_PROFILE"), "connection profile to select (default: the file's default profile)")
+	flag.Parse()
+
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer stop()
 
 	tui := NewTUI(ctx)
+	if err := loadProfiles(tui, *configPath, *profile); err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(2)
+	}
 	go func() {
 		<-ctx.Done()
 		tui.Stop()
@@ -23,3 +36,22 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+// loadProfiles offers the profiles of the config file at path, or at the
+// default location, in tui. A missing file is only an error when a profile
+// is asked for.
+func loadProfiles(tui *TUI, path, profile string) error {
+	if path == "" {
+		var err error
+		if path, err = config.DefaultPath(); err != nil {
+			return err
+		}
+	}
+	f, err := config.Load(path)
+	if errors.Is(err, fs.ErrNotExist) && profile == "" {
+		f = &config.File{}
+	} else if err != nil {
+		return err
+	}
+	return tui.SetProfiles(f, path, profile)
+}
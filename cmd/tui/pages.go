@@ -22,14 +22,14 @@ const (
 	pageItems       = "items"
 	pageItemDetail  = "itemDetail"
 	pageSearch      = "search"
-	pageDownload    = "download"
+	pageDownloads   = "downloads"
 	pageError       = "error"
 	pageInfo        = "info"
 )
 
 const (
 	searchHelpControls = "[yellow]↑/↓[white] navigate  [yellow]Enter/Space[white] toggle selection  [yellow]Tab[white] switch focus  [yellow]Esc[white] cancel  [yellow]Ctrl+C[white] quit"
-	itemsHelpControls  = "[yellow]↑/↓[white] select  [yellow]Enter[white] view detail  [yellow]s[white] search (↑/↓ move, Space toggle)  [yellow]j[white] raw JSON  [yellow]Esc[white] back  [yellow]Ctrl+C[white] quit"
+	itemsHelpControls  = "[yellow]↑/↓[white] select  [yellow]Enter[white] view detail  [yellow]s[white] search (↑/↓ move, Space toggle)  [yellow]j[white] raw JSON  [yellow]r[white] result set JSON  [yellow]e[white] export  [yellow]d[white] downloads  [yellow]Esc[white] back  [yellow]Ctrl+C[white] quit"
 )
 
 func (t *TUI) setupPages() {
@@ -38,6 +38,18 @@ func (t *TUI) setupPages() {
 	t.setupSearchFormPage()
 	t.setupItemsPage()
 	t.setupItemDetailPage()
+	t.setupDownloadsPage()
+}
+
+// authOptions are the entries of the authentication drop-down.
+var authOptions = []struct {
+	label string
+	mode  authMode
+}{
+	{"None", authModeNone},
+	{"Bearer token", authModeBearer},
+	{"Basic auth", authModeBasic},
+	{"Custom header", authModeHeader},
 }
 
 func (t *TUI) setupInputPage() {
@@ -47,15 +59,10 @@ func (t *TUI) setupInputPage() {
 		SetText("https://earth-search.aws.element84.com/v1")
 	t.input.SetDoneFunc(t.onInputDone)
 
-	authOptions := []struct {
-		label string
-		mode  authMode
-	}{
-		{"None", authModeNone},
-		{"Bearer token", authModeBearer},
-		{"Basic auth", authModeBasic},
-		{"Custom header", authModeHeader},
-	}
+	t.profileDropDown = tview.NewDropDown().
+		SetLabel("Profile: ").
+		SetFieldWidth(30)
+	t.setProfileOptions()
 
 	t.authTypeDropDown = tview.NewDropDown().
 		SetLabel("Authentication: ").
@@ -106,6 +113,7 @@ func (t *TUI) setupInputPage() {
 
 	inputForm := tview.NewFlex().SetDirection(tview.FlexRow)
 	inputForm.SetBorder(true).SetTitle("STAC API Connection")
+	inputForm.AddItem(t.profileDropDown, 0, 1, false)
 	inputForm.AddItem(t.input, 0, 1, true)
 	inputForm.AddItem(t.authTypeDropDown, 0, 1, false)
 	inputForm.AddItem(t.authFieldsContainer, 0, 1, false)
@@ -181,14 +189,29 @@ func (t *TUI) setupCollectionsPage() {
 	t.collectionsList.SetBorder(true).SetTitle("Collections")
 	t.collectionsList.ShowSecondaryText(false)
 
+	t.collectionFilter = tview.NewInputField().
+		SetLabel("Filter: ").
+		SetPlaceholder("free text, Enter to apply")
+	t.collectionFilter.SetDoneFunc(func(key tcell.Key) {
+		if key == tcell.KeyEnter {
+			t.filterCollections(t.collectionFilter.GetText())
+		}
+		t.app.SetFocus(t.collectionsList)
+	})
+
+	collectionsColumn := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(t.collectionFilter, 1, 0, false).
+		AddItem(t.collectionsList, 0, 1, true)
+
 	t.colDetail = tview.NewTextView().SetDynamicColors(true).SetWordWrap(true).SetScrollable(true)
 	t.colDetail.SetBorder(true).SetTitle("Collection Details")
 
 	collectionsContent := tview.NewFlex().
-		AddItem(t.collectionsList, 0, 1, true).
+		AddItem(collectionsColumn, 0, 1, true).
 		AddItem(t.colDetail, 0, 2, false)
 
-	collectionsHelp := formatting.MakeHelpText("[yellow]↑/↓[white] select  [yellow]Enter[white] load items  [yellow]s[white] search (↑/↓ move, Space toggle)  [yellow]j[white] raw JSON  [yellow]Tab[white] toggle focus  [yellow]Esc[white] back  [yellow]Ctrl+C[white] quit")
+	collectionsHelp := formatting.MakeHelpText("[yellow]↑/↓[white] select  [yellow]Enter[white] load items  [yellow]/[white] filter  [yellow]s[white] search (↑/↓ move, Space toggle)  [yellow]j[white] raw JSON  [yellow]e[white] export  [yellow]d[white] downloads  [yellow]Tab[white] toggle focus  [yellow]Esc[white] back  [yellow]Ctrl+C[white] quit")
 	collectionsPage := tview.NewFlex().
 		SetDirection(tview.FlexRow).
 		AddItem(collectionsContent, 0, 1, true).
@@ -871,6 +894,9 @@ func (t *TUI) populateSearchFormFields() {
 	setField(t.searchDatetime, "datetime")
 	setField(t.searchBbox, "bbox")
 	setField(t.searchLimit, "limit")
+	if t.searchLimit != nil && t.searchLimit.GetText() == "" && t.profile != nil && t.profile.PageSize > 0 {
+		t.searchLimit.SetText(strconv.Itoa(t.profile.PageSize))
+	}
 }
 
 func (t *TUI) selectedSearchCollectionIDs() []string {
@@ -1041,7 +1067,7 @@ func (t *TUI) setupItemDetailPage() {
 
 	t.itemDetailPanes = []tview.Primitive{t.itemProperties, t.itemAssets, t.itemAssetDetail}
 
-	itemDetailHelp := formatting.MakeHelpText("[yellow]Tab[white] next pane  [yellow]Shift+Tab[white] previous pane  [yellow]Enter[white] download asset  [yellow]j[white] raw JSON  [yellow]Esc[white] back  [yellow]Ctrl+C[white] quit")
+	itemDetailHelp := formatting.MakeHelpText("[yellow]Tab[white] next pane  [yellow]Shift+Tab[white] previous pane  [yellow]Enter[white] queue download  [yellow]d[white] downloads  [yellow]j[white] raw JSON  [yellow]Esc[white] back  [yellow]Ctrl+C[white] quit")
 	itemDetailPage := tview.NewFlex().
 		SetDirection(tview.FlexRow).
 		AddItem(t.itemDetail, 0, 1, true).
@@ -1051,7 +1077,8 @@ func (t *TUI) setupItemDetailPage() {
 }
 
 func (t *TUI) ensureClient(url string, auth authConfig) (*client.Client, error) {
-	if t.client != nil && t.baseURL == url && t.currentAuth.equal(auth) {
+	profile := t.profile
+	if t.client != nil && t.baseURL == url && t.currentAuth.equal(auth) && t.clientProfile == profile {
 		return t.client, nil
 	}
 
@@ -1060,7 +1087,7 @@ func (t *TUI) ensureClient(url string, auth authConfig) (*client.Client, error)
 		return nil, err
 	}
 
-	var opts []client.ClientOption
+	opts := t.profileClientOptions()
 	if mw != nil {
 		opts = append(opts, client.WithMiddleware(mw))
 	}
@@ -1073,6 +1100,7 @@ func (t *TUI) ensureClient(url string, auth authConfig) (*client.Client, error)
 	t.client = cli
 	t.baseURL = url
 	t.currentAuth = auth
+	t.clientProfile = profile
 	return cli, nil
 }
 
@@ -1091,51 +1119,136 @@ func (t *TUI) fetchCollections(url string, auth authConfig) {
 			return
 		}
 
-		collectionsChan := make(chan []*stac.Collection, 1)
-		errorChan := make(chan error, 1)
+		collectionSearch := supportsCollectionSearch(t.baseCtx, cli)
+		t.app.QueueUpdateDraw(func() {
+			t.collectionSearch = collectionSearch
+			t.collectionFilter.SetText("")
+		})
+		t.loadCollections(cli.GetCollections, true)
+	}()
+}
 
-		go func() {
-			var collections []*stac.Collection
-			ctx, cancel := context.WithTimeout(t.baseCtx, 30*time.Second)
-			defer cancel()
+// supportsCollectionSearch reports whether the server advertises the
+// Collection Search extension with free-text queries.
+func supportsCollectionSearch(ctx context.Context, cli *client.Client) bool {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
 
-			var fetchErr error
-			cli.GetCollections(ctx)(func(col *stac.Collection, err error) bool {
-				if err != nil {
-					fetchErr = err
-					return false
+	classes, err := cli.GetConformance(ctx)
+	if err != nil {
+		return false
+	}
+	return client.ConformsTo(classes, client.ConformanceFreeText)
+}
+
+// filterCollections applies the collections filter box. The query is sent
+// to the server when it supports Collection Search; otherwise the
+// collections already loaded are matched by ID, title, description and
+// keywords.
+func (t *TUI) filterCollections(text string) {
+	cli := t.client
+	if cli == nil {
+		return
+	}
+	text = strings.TrimSpace(text)
+
+	t.collectionsList.Clear()
+	t.collectionsList.AddItem("Loading collections...", "", 0, nil)
+
+	switch {
+	case text == "":
+		go t.loadCollections(cli.GetCollections, true)
+	case t.collectionSearch:
+		go t.loadCollections(func(ctx context.Context) iter.Seq2[*stac.Collection, error] {
+			return cli.SearchCollections(ctx, client.CollectionSearchParams{Q: text})
+		}, false)
+	default:
+		all := t.allCols
+		go t.loadCollections(func(context.Context) iter.Seq2[*stac.Collection, error] {
+			return func(yield func(*stac.Collection, error) bool) {
+				for _, col := range all {
+					if collectionMatches(col, text) && !yield(col, nil) {
+						return
+					}
 				}
-				collections = append(collections, col)
+			}
+		}, false)
+	}
+}
+
+// collectionMatches reports whether any comma-separated term of query
+// occurs in the collection's ID, title, description or keywords.
+func collectionMatches(col *stac.Collection, query string) bool {
+	fields := append([]string{col.Id, col.Title, col.Description}, col.Keywords...)
+	for _, term := range strings.Split(query, ",") {
+		term = strings.ToLower(strings.TrimSpace(term))
+		if term == "" {
+			continue
+		}
+		for _, f := range fields {
+			if strings.Contains(strings.ToLower(f), term) {
 				return true
-			})
+			}
+		}
+	}
+	return false
+}
 
-			if fetchErr != nil {
-				errorChan <- fetchErr
-			} else {
-				collectionsChan <- collections
+// loadCollections fills the collections list from list. When all is set the
+// result is the unfiltered set, kept for local filtering.
+func (t *TUI) loadCollections(list func(context.Context) iter.Seq2[*stac.Collection, error], all bool) {
+	collectionsChan := make(chan []*stac.Collection, 1)
+	errorChan := make(chan error, 1)
+
+	go func() {
+		var collections []*stac.Collection
+		ctx, cancel := context.WithTimeout(t.baseCtx, 30*time.Second)
+		defer cancel()
+
+		var fetchErr error
+		list(ctx)(func(col *stac.Collection, err error) bool {
+			if err != nil {
+				fetchErr = err
+				return false
 			}
-		}()
+			collections = append(collections, col)
+			return true
+		})
 
-		select {
-		case <-t.baseCtx.Done():
-			return
-		case collections := <-collectionsChan:
-			t.cols = collections
-			t.app.QueueUpdateDraw(func() {
-				t.collectionsList.Clear()
-				for _, col := range t.cols {
-					collection := col
-					t.collectionsList.AddItem(col.Title, "", 0, func() {
-						go t.fetchItems(collection.Id)
-					})
-				}
-			})
-		case err := <-errorChan:
-			t.showError(err.Error())
-		case <-time.After(31 * time.Second):
-			t.showError("Timeout fetching collections")
+		if fetchErr != nil {
+			errorChan <- fetchErr
+		} else {
+			collectionsChan <- collections
 		}
 	}()
+
+	select {
+	case <-t.baseCtx.Done():
+		return
+	case collections := <-collectionsChan:
+		t.app.QueueUpdateDraw(func() {
+			t.cols = collections
+			if all {
+				t.allCols = collections
+			}
+			t.collectionsList.Clear()
+			t.colDetail.Clear()
+			if len(t.cols) == 0 {
+				t.collectionsList.AddItem("No matching collections", "", 0, nil)
+				return
+			}
+			for _, col := range t.cols {
+				collection := col
+				t.collectionsList.AddItem(col.Title, "", 0, func() {
+					go t.fetchItems(collection.Id)
+				})
+			}
+		})
+	case err := <-errorChan:
+		t.showError(err.Error())
+	case <-time.After(31 * time.Second):
+		t.showError("Timeout fetching collections")
+	}
 }
 
 func (t *TUI) fetchItems(collectionID string) {
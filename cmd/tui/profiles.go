@@ -0,0 +1,132 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/robert-malhotra/go-stac-client/pkg/client"
+	"github.com/robert-malhotra/go-stac-client/pkg/config"
+)
+
+// secretTimeout bounds how long a profile's secret command may run.
+const secretTimeout = 30 * time.Second
+
+// SetProfiles offers the profiles of f, read from path, on the input page
+// and selects the named one, or the default profile when name is empty.
+func (t *TUI) SetProfiles(f *config.File, path, name string) error {
+	t.profiles = f
+	t.profilesPath = path
+	t.setProfileOptions()
+
+	if name == "" {
+		name = f.Default
+	}
+	if name == "" {
+		return nil
+	}
+	if _, err := f.Profile(name); err != nil {
+		return err
+	}
+	for i, n := range t.profileNames {
+		if n == name {
+			t.profileDropDown.SetCurrentOption(i + 1)
+		}
+	}
+	return nil
+}
+
+// setProfileOptions fills the profile drop-down. The first option keeps
+// the fields as they are.
+func (t *TUI) setProfileOptions() {
+	if t.profileDropDown == nil {
+		return
+	}
+	none := "None"
+	t.profileNames = nil
+	if t.profiles != nil {
+		t.profileNames = t.profiles.Names()
+	}
+	if len(t.profileNames) == 0 && t.profilesPath != "" {
+		none = "None (add profiles to " + t.profilesPath + ")"
+	}
+
+	options := append([]string{none}, t.profileNames...)
+	t.profileDropDown.SetOptions(options, func(_ string, index int) {
+		if index <= 0 || index > len(t.profileNames) {
+			t.profile = nil
+			return
+		}
+		t.applyProfile(t.profileNames[index-1])
+	})
+	t.profileDropDown.SetCurrentOption(0)
+}
+
+// applyProfile fills the connection fields from the named profile and
+// selects its default collections for search. Secrets are resolved in the
+// background, as their commands may take a while.
+func (t *TUI) applyProfile(name string) {
+	p := t.profiles.Profiles[name]
+	if p == nil || p == t.profile {
+		return
+	}
+	t.profile = p
+
+	if p.URL != "" {
+		t.input.SetText(p.URL)
+	}
+	mode := authMode(p.Auth.EffectiveMode())
+	for i, opt := range authOptions {
+		if opt.mode == mode {
+			t.authTypeDropDown.SetCurrentOption(i)
+		}
+	}
+	t.authTokenField.SetText("")
+	t.authUsernameField.SetText(p.Auth.Username)
+	t.authPasswordField.SetText("")
+	t.authHeaderNameField.SetText(p.Auth.Header)
+	t.authHeaderValueField.SetText("")
+
+	t.searchSelectedCollections = make(map[string]bool)
+	t.searchSelectedOrder = nil
+	for _, id := range p.Collections {
+		if !t.searchSelectedCollections[id] {
+			t.searchSelectedCollections[id] = true
+			t.searchSelectedOrder = append(t.searchSelectedOrder, id)
+		}
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(t.baseCtx, secretTimeout)
+		defer cancel()
+		creds, err := p.Auth.Resolve(ctx)
+		if err != nil {
+			t.showError(fmt.Sprintf("Profile %s: %v", name, err))
+			return
+		}
+		t.app.QueueUpdateDraw(func() {
+			if t.profile != p {
+				return
+			}
+			t.authTokenField.SetText(creds.Token)
+			t.authPasswordField.SetText(creds.Password)
+			t.authHeaderValueField.SetText(creds.HeaderValue)
+		})
+	}()
+}
+
+// profileClientOptions returns the client options of the selected profile.
+func (t *TUI) profileClientOptions() []client.ClientOption {
+	p := t.profile
+	if p == nil {
+		return nil
+	}
+	var opts []client.ClientOption
+	if p.Timeout > 0 {
+		opts = append(opts, client.WithTimeout(p.Timeout))
+	}
+	if p.S3 != nil {
+		opts = append(opts, client.WithS3Options(p.S3.Options()))
+	}
+	return opts
+}
@@ -7,12 +7,14 @@ import (
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 	"github.com/robert-malhotra/go-stac-client/pkg/client"
+	"github.com/robert-malhotra/go-stac-client/pkg/config"
 	"github.com/robert-malhotra/go-stac-client/pkg/stac"
 )
 
 type TUI struct {
 	app                   *tview.Application
 	pages                 *tview.Pages
+	profileDropDown       *tview.DropDown
 	input                 *tview.InputField
 	authTypeDropDown      *tview.DropDown
 	authTokenField        *tview.InputField
@@ -28,6 +30,7 @@ type TUI struct {
 	searchLimit           *tview.InputField
 	searchCollectionsList *tview.List
 	collectionsList       *tview.List
+	collectionFilter      *tview.InputField
 	colDetail             *tview.TextView
 	itemsList             *tview.List
 	itemSummary           *tview.TextView
@@ -44,8 +47,14 @@ type TUI struct {
 	client  *client.Client
 	baseURL string
 	cols    []*stac.Collection
+	allCols []*stac.Collection
 	items   []*stac.Item
 
+	// collectionSearch reports whether the server implements the
+	// Collection Search extension, so the filter box can query it. It is
+	// only accessed on the UI goroutine.
+	collectionSearch bool
+
 	activeResultLabel         string
 	lastSearchMetadata        map[string]string
 	searchReturnPage          string
@@ -73,12 +82,27 @@ type TUI struct {
 
 	authMode authMode
 
-	downloadMu     sync.Mutex
-	activeDownload *downloadSession
+	downloadMu          sync.Mutex
+	downloadQueue       *client.DownloadQueue
+	downloadQueueClient *client.Client
+	downloadQueues      []*client.DownloadQueue
+	downloads           []downloadEntry
+
+	downloadsTable         *tview.Table
+	downloadsReturnPage    string
+	downloadsRefreshCancel context.CancelFunc
 
 	jsonViewer *jsonViewer
 
 	currentAuth authConfig
+
+	// Connection profiles from the config file. profile is the selected
+	// one, or nil; clientProfile is the one the client was built with.
+	profiles      *config.File
+	profilesPath  string
+	profileNames  []string
+	profile       *config.Profile
+	clientProfile *config.Profile
 }
 
 // configureStyles sets the tview global styles for the TUI.
@@ -136,7 +160,7 @@ func (t *TUI) Stop() {
 		if t.baseCancel != nil {
 			t.baseCancel()
 		}
-		t.cancelActiveDownload()
+		t.closeDownloadQueues()
 		t.cancelItemIteration()
 		t.app.Stop()
 	})
@@ -0,0 +1,497 @@
+package client
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"math"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/paulmach/orb"
+	"github.com/paulmach/orb/maptile"
+	"github.com/robert-malhotra/go-stac-client/pkg/stac"
+)
+
+// Aggregation data types, as reported in Aggregation.DataType,
+// AggregationResult.DataType and Bucket.DataType.
+const (
+	AggregationInteger               = "integer"
+	AggregationNumeric               = "numeric"
+	AggregationString                = "string"
+	AggregationDatetime              = "datetime"
+	AggregationFrequencyDistribution = "frequency_distribution"
+)
+
+// Aggregation describes an aggregation offered by the server.
+type Aggregation struct {
+	Name     string `json:"name"`
+	DataType string `json:"data_type"`
+}
+
+// AggregationCollection is the response of the aggregate endpoint.
+type AggregationCollection struct {
+	Type         string              `json:"type"`
+	Aggregations []AggregationResult `json:"aggregations"`
+	Links        []*stac.Link        `json:"links,omitempty"`
+
+	// Local is set when the aggregations were computed by the client from
+	// search results because the server lacks the Aggregation extension.
+	Local bool `json:"-"`
+}
+
+// Get returns the result of the named aggregation.
+func (ac *AggregationCollection) Get(name string) (*AggregationResult, bool) {
+	for i := range ac.Aggregations {
+		if ac.Aggregations[i].Name == name {
+			return &ac.Aggregations[i], true
+		}
+	}
+	return nil, false
+}
+
+// AggregationResult is one computed aggregation: either a single Value
+// (e.g. total_count, datetime_max) or, for frequency distributions, Buckets.
+type AggregationResult struct {
+	Name     string   `json:"name"`
+	DataType string   `json:"data_type"`
+	Value    any      `json:"value,omitempty"`
+	Buckets  []Bucket `json:"buckets,omitempty"`
+	// Overflow counts the items that fell outside the returned buckets.
+	Overflow int `json:"overflow,omitempty"`
+}
+
+// Int returns Value as an integer, e.g. for total_count.
+func (r *AggregationResult) Int() (int, bool) {
+	f, ok := r.Value.(float64)
+	if !ok || f != math.Trunc(f) {
+		return 0, false
+	}
+	return int(f), true
+}
+
+// Time returns Value as a timestamp, e.g. for datetime_min.
+func (r *AggregationResult) Time() (time.Time, error) {
+	s, ok := r.Value.(string)
+	if !ok {
+		return time.Time{}, fmt.Errorf("aggregation %s has no datetime value", r.Name)
+	}
+	return time.Parse(time.RFC3339, s)
+}
+
+// Bucket is one entry of a frequency distribution. Key is the term, the
+// start of a datetime interval, a "from-to" numeric range, a geohash or a
+// "z/x/y" geotile; From and To bound range buckets.
+type Bucket struct {
+	Key       any    `json:"key"`
+	DataType  string `json:"data_type"`
+	Frequency int    `json:"frequency"`
+	From      any    `json:"from,omitempty"`
+	To        any    `json:"to,omitempty"`
+}
+
+// KeyString returns Key formatted as a string.
+func (b Bucket) KeyString() string {
+	if s, ok := b.Key.(string); ok {
+		return s
+	}
+	return fmt.Sprint(b.Key)
+}
+
+// Time parses the key of a datetime bucket.
+func (b Bucket) Time() (time.Time, error) {
+	return time.Parse(time.RFC3339, b.KeyString())
+}
+
+// Range returns the bounds of a numeric range bucket, using From and To or,
+// when absent, a "from-to" key. An open bound is returned as ±Inf.
+func (b Bucket) Range() (from, to float64, ok bool) {
+	if b.From != nil || b.To != nil {
+		from, to = math.Inf(-1), math.Inf(1)
+		if f, isNum := b.From.(float64); isNum {
+			from = f
+		}
+		if t, isNum := b.To.(float64); isNum {
+			to = t
+		}
+		return from, to, true
+	}
+
+	// The separator is the first '-' after a possible leading minus sign.
+	key := b.KeyString()
+	if len(key) < 3 {
+		return 0, 0, false
+	}
+	sep := strings.IndexByte(key[1:], '-') + 1
+	if sep == 0 {
+		return 0, 0, false
+	}
+	lo, hi := key[:sep], key[sep+1:]
+	from, to = math.Inf(-1), math.Inf(1)
+	var err error
+	if lo != "*" {
+		if from, err = strconv.ParseFloat(lo, 64); err != nil {
+			return 0, 0, false
+		}
+	}
+	if hi != "*" {
+		if to, err = strconv.ParseFloat(hi, 64); err != nil {
+			return 0, 0, false
+		}
+	}
+	return from, to, true
+}
+
+// Cell returns the area of a geohash or "z/x/y" geotile grid bucket.
+func (b Bucket) Cell() (orb.Bound, error) {
+	key := b.KeyString()
+	if parts := strings.Split(key, "/"); len(parts) == 3 {
+		var zxy [3]uint64
+		for i, p := range parts {
+			n, err := strconv.ParseUint(p, 10, 32)
+			if err != nil {
+				return orb.Bound{}, fmt.Errorf("invalid geotile key %q", key)
+			}
+			zxy[i] = n
+		}
+		return maptile.New(uint32(zxy[1]), uint32(zxy[2]), maptile.Zoom(zxy[0])).Bound(), nil
+	}
+	return decodeGeohash(key)
+}
+
+// GetAggregations lists the aggregations the server offers
+// (GET /aggregations).
+func (c *Client) GetAggregations(ctx context.Context) ([]Aggregation, error) {
+	u := c.baseURL.JoinPath("aggregations")
+
+	resp, err := c.doRequest(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp)
+	}
+
+	var doc struct {
+		Aggregations []Aggregation `json:"aggregations"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("error decoding response from %s: %w", u, err)
+	}
+	return doc.Aggregations, nil
+}
+
+// Aggregate computes the named aggregations over the items matching params
+// using the Aggregation extension. With no names the server's default set is
+// returned. The request is POSTed to /aggregate and retried as a GET when
+// the server only allows GET.
+//
+// When the server lacks the extension (/aggregate is not found and the
+// server does not conform to ConformanceAggregation), the aggregations are
+// computed with AggregateItems from a search over params instead, which
+// pages through every matching item; the result then has Local set. Other
+// not found errors, such as an unknown collection, are returned as is.
+func (c *Client) Aggregate(ctx context.Context, params SearchParams, names ...string) (*AggregationCollection, error) {
+	result, err := c.aggregatePOST(ctx, params, names)
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusMethodNotAllowed {
+		result, err = c.aggregateGET(ctx, params, names)
+	}
+	if errors.Is(err, ErrNotFound) {
+		supported, confErr := c.supportsAggregation(ctx)
+		if confErr != nil {
+			return nil, errors.Join(err, confErr)
+		}
+		if !supported {
+			return AggregateItems(c.SearchCQL2(ctx, params), names...)
+		}
+	}
+	if err == nil && result == nil {
+		return nil, fmt.Errorf("empty aggregate response")
+	}
+	return result, err
+}
+
+// supportsAggregation reports whether the server implements the Aggregation
+// extension, by its conformance classes or, when those cannot be read,
+// by whether /aggregations exists.
+func (c *Client) supportsAggregation(ctx context.Context) (bool, error) {
+	if classes, err := c.GetConformance(ctx); err == nil {
+		return ConformsTo(classes, ConformanceAggregation), nil
+	}
+	_, err := c.GetAggregations(ctx)
+	if errors.Is(err, ErrNotFound) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (c *Client) aggregatePOST(ctx context.Context, params SearchParams, names []string) (*AggregationCollection, error) {
+	body := struct {
+		SearchParams
+		Aggregations []string `json:"aggregations,omitempty"`
+	}{params, names}
+
+	u := c.baseURL.JoinPath("aggregate")
+	return writeDocument[AggregationCollection](ctx, c, http.MethodPost, u, "application/json", body, nil, nil)
+}
+
+func (c *Client) aggregateGET(ctx context.Context, params SearchParams, names []string) (*AggregationCollection, error) {
+	q, err := searchValues(params)
+	if err != nil {
+		return nil, err
+	}
+	if len(names) > 0 {
+		q.Set("aggregations", strings.Join(names, ","))
+	}
+
+	u := c.baseURL.ResolveReference(&url.URL{Path: "aggregate", RawQuery: q.Encode()})
+	return writeDocument[AggregationCollection](ctx, c, http.MethodGet, u, "", nil, nil, nil)
+}
+
+// Aggregations AggregateItems can compute.
+var localAggregations = []Aggregation{
+	{Name: "total_count", DataType: AggregationInteger},
+	{Name: "datetime_min", DataType: AggregationDatetime},
+	{Name: "datetime_max", DataType: AggregationDatetime},
+	{Name: "datetime_frequency", DataType: AggregationFrequencyDistribution},
+	{Name: "collection_frequency", DataType: AggregationFrequencyDistribution},
+	{Name: "platform_frequency", DataType: AggregationFrequencyDistribution},
+	{Name: "grid_code_frequency", DataType: AggregationFrequencyDistribution},
+	{Name: "cloud_cover_frequency", DataType: AggregationFrequencyDistribution},
+	{Name: "sun_elevation_frequency", DataType: AggregationFrequencyDistribution},
+	{Name: "sun_azimuth_frequency", DataType: AggregationFrequencyDistribution},
+	{Name: "off_nadir_frequency", DataType: AggregationFrequencyDistribution},
+	{Name: "centroid_geohash_grid_frequency", DataType: AggregationFrequencyDistribution},
+	{Name: "centroid_geotile_grid_frequency", DataType: AggregationFrequencyDistribution},
+}
+
+// Item properties and histogram widths of the numeric frequency
+// aggregations.
+var (
+	termProperties = map[string]string{
+		"platform_frequency":  "platform",
+		"grid_code_frequency": "grid:code",
+	}
+	rangeProperties = map[string]struct {
+		property string
+		width    float64
+	}{
+		"cloud_cover_frequency":   {"eo:cloud_cover", 10},
+		"sun_elevation_frequency": {"view:sun_elevation", 10},
+		"sun_azimuth_frequency":   {"view:sun_azimuth", 30},
+		"off_nadir_frequency":     {"view:off_nadir", 5},
+	}
+)
+
+// Grid precisions used by AggregateItems: geohash cells of about
+// 156 x 156 km and zoom 6 geotiles.
+const (
+	localGeohashPrecision = 3
+	localGeotileZoom      = 6
+)
+
+// AggregateItems computes aggregations client-side from an item iterator,
+// such as the result of SearchCQL2, for servers without the Aggregation
+// extension. With no names every supported aggregation is computed:
+// total_count, datetime_min/max, datetime_frequency (monthly),
+// collection/platform/grid_code_frequency, histograms of cloud cover, sun
+// elevation/azimuth and off-nadir angle, and centroid geohash (precision 3)
+// and geotile (zoom 6) grids.
+func AggregateItems(items iter.Seq2[*stac.Item, error], names ...string) (*AggregationCollection, error) {
+	if len(names) == 0 {
+		for _, a := range localAggregations {
+			names = append(names, a.Name)
+		}
+	}
+	for _, name := range names {
+		if !slices.ContainsFunc(localAggregations, func(a Aggregation) bool { return a.Name == name }) {
+			return nil, fmt.Errorf("aggregation %q cannot be computed locally", name)
+		}
+	}
+
+	var (
+		count      int
+		minT, maxT time.Time
+		freqs      = make(map[string]map[string]int, len(names))
+	)
+	for _, name := range names {
+		freqs[name] = make(map[string]int)
+	}
+	add := func(name, key string) {
+		if f, ok := freqs[name]; ok {
+			f[key]++
+		}
+	}
+
+	for item, err := range items {
+		if err != nil {
+			return nil, err
+		}
+		count++
+
+		if iv, err := item.TimeRange(); err == nil && !iv.Start.IsZero() {
+			start := iv.Start.UTC()
+			if minT.IsZero() || start.Before(minT) {
+				minT = start
+			}
+			if start.After(maxT) {
+				maxT = start
+			}
+			month := time.Date(start.Year(), start.Month(), 1, 0, 0, 0, 0, time.UTC)
+			add("datetime_frequency", month.Format(time.RFC3339))
+		}
+		if item.Collection != "" {
+			add("collection_frequency", item.Collection)
+		}
+		for name, prop := range termProperties {
+			if s, ok := item.Properties[prop].(string); ok && s != "" {
+				add(name, s)
+			}
+		}
+		for name, r := range rangeProperties {
+			if v, ok := item.Properties[r.property].(float64); ok {
+				from := math.Floor(v/r.width) * r.width
+				add(name, rangeKey(from, from+r.width))
+			}
+		}
+		if c, err := item.Centroid(); err == nil {
+			add("centroid_geohash_grid_frequency", encodeGeohash(c, localGeohashPrecision))
+			t := maptile.At(c, localGeotileZoom)
+			add("centroid_geotile_grid_frequency", fmt.Sprintf("%d/%d/%d", t.Z, t.X, t.Y))
+		}
+	}
+
+	result := &AggregationCollection{Type: "AggregationCollection", Local: true}
+	for _, name := range names {
+		agg := AggregationResult{Name: name, DataType: AggregationFrequencyDistribution}
+		switch name {
+		case "total_count":
+			agg.DataType, agg.Value = AggregationInteger, float64(count)
+		case "datetime_min", "datetime_max":
+			agg.DataType = AggregationDatetime
+			if t := minT; !t.IsZero() {
+				if name == "datetime_max" {
+					t = maxT
+				}
+				agg.Value = t.Format(time.RFC3339)
+			}
+		default:
+			agg.Buckets = frequencyBuckets(name, freqs[name])
+		}
+		result.Aggregations = append(result.Aggregations, agg)
+	}
+	return result, nil
+}
+
+// frequencyBuckets orders datetime and range buckets by key and term and
+// grid buckets by descending frequency.
+func frequencyBuckets(name string, freq map[string]int) []Bucket {
+	dataType := AggregationString
+	_, isRange := rangeProperties[name]
+	switch {
+	case name == "datetime_frequency":
+		dataType = AggregationDatetime
+	case isRange:
+		dataType = AggregationNumeric
+	}
+
+	buckets := make([]Bucket, 0, len(freq))
+	for key, n := range freq {
+		b := Bucket{Key: key, DataType: dataType, Frequency: n}
+		if isRange {
+			from, to, _ := b.Range()
+			b.From, b.To = from, to
+		}
+		buckets = append(buckets, b)
+	}
+
+	slices.SortFunc(buckets, func(a, b Bucket) int {
+		switch dataType {
+		case AggregationDatetime:
+			return cmp.Compare(a.KeyString(), b.KeyString())
+		case AggregationNumeric:
+			return cmp.Compare(a.From.(float64), b.From.(float64))
+		}
+		return cmp.Or(cmp.Compare(b.Frequency, a.Frequency), cmp.Compare(a.KeyString(), b.KeyString()))
+	})
+	return buckets
+}
+
+func rangeKey(from, to float64) string {
+	return strconv.FormatFloat(from, 'f', -1, 64) + "-" + strconv.FormatFloat(to, 'f', -1, 64)
+}
+
+const geohashAlphabet = "0123456789bcdefghjkmnpqrstuvwxyz"
+
+// encodeGeohash returns the geohash of p with the given number of
+// characters.
+func encodeGeohash(p orb.Point, precision int) string {
+	lon, lat := [2]float64{-180, 180}, [2]float64{-90, 90}
+	var (
+		hash []byte
+		bits int
+		ch   int
+		even = true
+	)
+	for len(hash) < precision {
+		rng, v := &lat, p.Lat()
+		if even {
+			rng, v = &lon, p.Lon()
+		}
+		mid := (rng[0] + rng[1]) / 2
+		ch <<= 1
+		if v >= mid {
+			ch |= 1
+			rng[0] = mid
+		} else {
+			rng[1] = mid
+		}
+		even = !even
+
+		if bits++; bits == 5 {
+			hash = append(hash, geohashAlphabet[ch])
+			bits, ch = 0, 0
+		}
+	}
+	return string(hash)
+}
+
+// decodeGeohash returns the cell of a geohash.
+func decodeGeohash(hash string) (orb.Bound, error) {
+	if hash == "" {
+		return orb.Bound{}, fmt.Errorf("empty geohash")
+	}
+	lon, lat := [2]float64{-180, 180}, [2]float64{-90, 90}
+	even := true
+	for _, r := range strings.ToLower(hash) {
+		idx := strings.IndexRune(geohashAlphabet, r)
+		if idx < 0 {
+			return orb.Bound{}, fmt.Errorf("invalid geohash %q", hash)
+		}
+		for bit := 4; bit >= 0; bit-- {
+			rng := &lat
+			if even {
+				rng = &lon
+			}
+			mid := (rng[0] + rng[1]) / 2
+			if idx&(1<<bit) != 0 {
+				rng[0] = mid
+			} else {
+				rng[1] = mid
+			}
+			even = !even
+		}
+	}
+	return orb.Bound{Min: orb.Point{lon[0], lat[0]}, Max: orb.Point{lon[1], lat[1]}}, nil
+}
@@ -0,0 +1,227 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/paulmach/orb"
+	"github.com/robert-malhotra/go-stac-client/pkg/stac"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const aggregateResponse = `{
+  "type": "AggregationCollection",
+  "aggregations": [
+    {"name": "total_count", "data_type": "integer", "value": 42},
+    {"name": "datetime_max", "data_type": "datetime", "value": "2024-03-31T10:00:00Z"},
+    {"name": "datetime_frequency", "data_type": "frequency_distribution", "buckets": [
+      {"key": "2024-01-01T00:00:00Z", "data_type": "datetime", "frequency": 30},
+      {"key": "2024-02-01T00:00:00Z", "data_type": "datetime", "frequency": 12}
+    ]},
+    {"name": "cloud_cover_frequency", "data_type": "frequency_distribution", "buckets": [
+      {"key": "0-5", "data_type": "numeric", "frequency": 20},
+      {"key": "40-*", "data_type": "numeric", "frequency": 2, "from": 40}
+    ]},
+    {"name": "centroid_geohash_grid_frequency", "data_type": "frequency_distribution", "buckets": [
+      {"key": "gcp", "data_type": "string", "frequency": 42}
+    ]}
+  ],
+  "links": []
+}`
+
+func TestClient_Aggregate(t *testing.T) {
+	ctx := context.Background()
+	params := SearchParams{Collections: []string{"sentinel-2-l2a"}, Datetime: "2024-01-01T00:00:00Z/.."}
+
+	t.Run("POST", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch {
+			case r.URL.Path == "/aggregations" && r.Method == http.MethodGet:
+				w.Write([]byte(`{"aggregations":[{"name":"total_count","data_type":"integer"},{"name":"datetime_frequency","data_type":"frequency_distribution"}]}`))
+			case r.URL.Path == "/aggregate" && r.Method == http.MethodPost:
+				var body map[string]any
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+				assert.Equal(t, []any{"sentinel-2-l2a"}, body["collections"])
+				assert.Equal(t, []any{"total_count", "datetime_frequency"}, body["aggregations"])
+				w.Write([]byte(aggregateResponse))
+			default:
+				http.NotFound(w, r)
+			}
+		}))
+		defer srv.Close()
+
+		c, err := NewClient(srv.URL)
+		require.NoError(t, err)
+
+		available, err := c.GetAggregations(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []Aggregation{
+			{Name: "total_count", DataType: AggregationInteger},
+			{Name: "datetime_frequency", DataType: AggregationFrequencyDistribution},
+		}, available)
+
+		result, err := c.Aggregate(ctx, params, "total_count", "datetime_frequency")
+		require.NoError(t, err)
+		assert.False(t, result.Local)
+
+		total, ok := result.Get("total_count")
+		require.True(t, ok)
+		n, ok := total.Int()
+		require.True(t, ok)
+		assert.Equal(t, 42, n)
+
+		latest, ok := result.Get("datetime_max")
+		require.True(t, ok)
+		ts, err := latest.Time()
+		require.NoError(t, err)
+		assert.Equal(t, time.Date(2024, 3, 31, 10, 0, 0, 0, time.UTC), ts)
+
+		monthly, _ := result.Get("datetime_frequency")
+		require.Len(t, monthly.Buckets, 2)
+		month, err := monthly.Buckets[1].Time()
+		require.NoError(t, err)
+		assert.Equal(t, time.February, month.Month())
+
+		cloud, _ := result.Get("cloud_cover_frequency")
+		from, to, ok := cloud.Buckets[0].Range()
+		require.True(t, ok)
+		assert.Equal(t, [2]float64{0, 5}, [2]float64{from, to})
+		from, to, ok = cloud.Buckets[1].Range()
+		require.True(t, ok)
+		assert.Equal(t, 40.0, from)
+		assert.True(t, math.IsInf(to, 1))
+
+		grid, _ := result.Get("centroid_geohash_grid_frequency")
+		cell, err := grid.Buckets[0].Cell()
+		require.NoError(t, err)
+		assert.True(t, cell.Contains(orb.Point{-0.1278, 51.5074}))
+
+		_, ok = result.Get("missing")
+		assert.False(t, ok)
+	})
+
+	t.Run("GET when POST is not allowed", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/aggregate" {
+				http.NotFound(w, r)
+				return
+			}
+			if r.Method != http.MethodGet {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				return
+			}
+			assert.Equal(t, "sentinel-2-l2a", r.URL.Query().Get("collections"))
+			assert.Equal(t, "total_count,datetime_max", r.URL.Query().Get("aggregations"))
+			w.Write([]byte(aggregateResponse))
+		}))
+		defer srv.Close()
+
+		c, err := NewClient(srv.URL)
+		require.NoError(t, err)
+		result, err := c.Aggregate(ctx, params, "total_count", "datetime_max")
+		require.NoError(t, err)
+		assert.Len(t, result.Aggregations, 5)
+	})
+
+	t.Run("computed locally without the extension", func(t *testing.T) {
+		items := []*stac.Item{testItem("a"), testItem("b"), testItem("c")}
+		items[0].Collection = "sentinel-2-l2a"
+		items[1].Properties["datetime"] = "2024-02-15T00:00:00Z"
+		items[1].Properties["eo:cloud_cover"] = 35.0
+		items[2].Properties["datetime"] = "2023-12-31T23:00:00-02:00"
+		items[2].Geometry = map[string]any{"type": "Point", "coordinates": []float64{-0.1278, 51.5074}}
+
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/search" {
+				http.NotFound(w, r)
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]any{"type": "FeatureCollection", "features": items, "links": []any{}})
+		}))
+		defer srv.Close()
+
+		c, err := NewClient(srv.URL)
+		require.NoError(t, err)
+		result, err := c.Aggregate(ctx, params, "total_count", "datetime_min", "datetime_frequency",
+			"collection_frequency", "cloud_cover_frequency", "centroid_geohash_grid_frequency", "centroid_geotile_grid_frequency")
+		require.NoError(t, err)
+		assert.True(t, result.Local)
+
+		total, _ := result.Get("total_count")
+		n, _ := total.Int()
+		assert.Equal(t, 3, n)
+
+		earliest, _ := result.Get("datetime_min")
+		assert.Equal(t, "2024-01-01T00:00:00Z", earliest.Value)
+
+		monthly, _ := result.Get("datetime_frequency")
+		assert.Equal(t, []Bucket{
+			{Key: "2024-01-01T00:00:00Z", DataType: AggregationDatetime, Frequency: 2},
+			{Key: "2024-02-01T00:00:00Z", DataType: AggregationDatetime, Frequency: 1},
+		}, monthly.Buckets)
+
+		collections, _ := result.Get("collection_frequency")
+		assert.Equal(t, []Bucket{{Key: "sentinel-2-l2a", DataType: AggregationString, Frequency: 1}}, collections.Buckets)
+
+		cloud, _ := result.Get("cloud_cover_frequency")
+		assert.Equal(t, []Bucket{
+			{Key: "10-20", DataType: AggregationNumeric, Frequency: 2, From: 10.0, To: 20.0},
+			{Key: "30-40", DataType: AggregationNumeric, Frequency: 1, From: 30.0, To: 40.0},
+		}, cloud.Buckets)
+
+		geohash, _ := result.Get("centroid_geohash_grid_frequency")
+		assert.Equal(t, "u0z", geohash.Buckets[0].Key)
+		assert.Equal(t, 2, geohash.Buckets[0].Frequency)
+		assert.Equal(t, "gcp", geohash.Buckets[1].Key)
+
+		geotile, _ := result.Get("centroid_geotile_grid_frequency")
+		cell, err := geotile.Buckets[0].Cell()
+		require.NoError(t, err)
+		assert.True(t, cell.Contains(orb.Point{10, 50}))
+
+		_, err = c.Aggregate(ctx, params, "geometry_geohash_grid_frequency")
+		assert.ErrorContains(t, err, "cannot be computed locally")
+	})
+
+	t.Run("not found with the extension", func(t *testing.T) {
+		var searched bool
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/conformance":
+				w.Write([]byte(`{"conformsTo":["https://api.stacspec.org/v0.3.0/aggregation"]}`))
+			case "/search":
+				searched = true
+				w.Write([]byte(`{"type":"FeatureCollection","features":[],"links":[]}`))
+			default:
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(`{"code":"NotFoundError","description":"Collection sentinel-2-l2a does not exist"}`))
+			}
+		}))
+		defer srv.Close()
+
+		c, err := NewClient(srv.URL)
+		require.NoError(t, err)
+		_, err = c.Aggregate(ctx, params, "total_count")
+		assert.ErrorIs(t, err, ErrNotFound)
+		assert.False(t, searched, "an unknown collection is not aggregated locally")
+	})
+}
+
+func TestGeohash(t *testing.T) {
+	london := orb.Point{-0.1278, 51.5074}
+	assert.Equal(t, "gcpvj", encodeGeohash(london, 5))
+
+	cell, err := decodeGeohash("gcpvj")
+	require.NoError(t, err)
+	assert.True(t, cell.Contains(london))
+	assert.InDelta(t, 0.0439, cell.Max.Lon()-cell.Min.Lon(), 1e-4)
+
+	_, err = decodeGeohash("gcpva")
+	assert.Error(t, err)
+}
@@ -0,0 +1,350 @@
+package client
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"sync"
+)
+
+const (
+	defaultAssetBlockSize   = 1 << 20 // 1 MiB
+	defaultAssetCacheBlocks = 32
+)
+
+// AssetReader provides random access to a remote asset using ranged reads.
+//
+// Data is fetched in fixed-size blocks that are kept in an LRU cache.
+// Concurrent reads of the same block share a single request, and a read that
+// spans several uncached neighbouring blocks fetches them with one request.
+// AssetReader is safe for concurrent use; ReadAt does not affect the offset
+// used by Read and Seek.
+type AssetReader struct {
+	ctx       context.Context
+	url       *url.URL
+	fetcher   RangeFetcher
+	size      int64
+	blockSize int64
+	maxBlocks int
+
+	mu       sync.Mutex
+	offset   int64
+	closed   bool
+	blocks   map[int64]*list.Element
+	lru      *list.List
+	inflight map[int64]*blockCall
+}
+
+var (
+	_ io.ReaderAt       = (*AssetReader)(nil)
+	_ io.ReadSeekCloser = (*AssetReader)(nil)
+)
+
+type cachedBlock struct {
+	index int64
+	data  []byte
+}
+
+// blockCall tracks a block that is being fetched so concurrent readers can
+// wait for it instead of issuing their own request.
+type blockCall struct {
+	done chan struct{}
+	data []byte
+	err  error
+}
+
+// AssetReaderOption configures an AssetReader.
+type AssetReaderOption func(*AssetReader)
+
+// WithBlockSize sets the size of each ranged request (default 1 MiB).
+func WithBlockSize(n int64) AssetReaderOption {
+	return func(r *AssetReader) {
+		if n > 0 {
+			r.blockSize = n
+		}
+	}
+}
+
+// WithBlockCache sets how many blocks are kept in memory (default 32).
+// Zero disables caching.
+func WithBlockCache(blocks int) AssetReaderOption {
+	return func(r *AssetReader) {
+		if blocks >= 0 {
+			r.maxBlocks = blocks
+		}
+	}
+}
+
+// OpenAsset opens an asset for random access without downloading it. HTTP(S)
+// assets are read with Range requests through the client's middleware;
+// s3://, gs:// and Azure hrefs use ranged object reads with the configured
+// storage options. Hrefs are signed with the client's Signer, if any.
+//
+// ctx governs every request made by the returned reader. The first block is
+// fetched immediately to learn the asset size.
+func (c *Client) OpenAsset(ctx context.Context, href string, opts ...AssetReaderOption) (*AssetReader, error) {
+	if c == nil {
+		return nil, fmt.Errorf("client is nil")
+	}
+
+	u, err := c.resolveAssetURL(href)
+	if err != nil {
+		return nil, err
+	}
+	if u, err = c.signAssetURL(ctx, u); err != nil {
+		return nil, err
+	}
+	rf, err := c.rangeFetcher(u)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &AssetReader{
+		ctx:       ctx,
+		url:       u,
+		fetcher:   rf,
+		blockSize: defaultAssetBlockSize,
+		maxBlocks: defaultAssetCacheBlocks,
+		blocks:    make(map[int64]*list.Element),
+		lru:       list.New(),
+		inflight:  make(map[int64]*blockCall),
+	}
+	for _, o := range opts {
+		o(r)
+	}
+
+	body, size, err := rf.FetchRange(ctx, u, 0, r.blockSize)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	first, err := io.ReadAll(io.LimitReader(body, r.blockSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read asset range: %w", err)
+	}
+	if want := min(r.blockSize, size); int64(len(first)) != want {
+		return nil, fmt.Errorf("failed to read asset range: got %d bytes, want %d", len(first), want)
+	}
+	r.size = size
+	if size > 0 {
+		r.storeLocked(0, first)
+	}
+	return r, nil
+}
+
+// Size returns the total size of the asset in bytes.
+func (r *AssetReader) Size() int64 {
+	return r.size
+}
+
+// ReadAt implements io.ReaderAt.
+func (r *AssetReader) ReadAt(p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, errors.New("client.AssetReader.ReadAt: negative offset")
+	}
+	if off >= r.size {
+		return 0, io.EOF
+	}
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	end := off + int64(len(p))
+	if end > r.size {
+		end = r.size
+	}
+
+	first := off / r.blockSize
+	last := (end - 1) / r.blockSize
+	blocks, err := r.loadBlocks(first, last)
+	if err != nil {
+		return 0, err
+	}
+
+	n := 0
+	for i, data := range blocks {
+		blockStart := (first + int64(i)) * r.blockSize
+		lo := int64(0)
+		if off > blockStart {
+			lo = off - blockStart
+		}
+		hi := int64(len(data))
+		if end < blockStart+hi {
+			hi = end - blockStart
+		}
+		n += copy(p[n:], data[lo:hi])
+	}
+
+	if n < len(p) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+// Read implements io.Reader.
+func (r *AssetReader) Read(p []byte) (int, error) {
+	r.mu.Lock()
+	off := r.offset
+	r.mu.Unlock()
+
+	n, err := r.ReadAt(p, off)
+
+	r.mu.Lock()
+	r.offset = off + int64(n)
+	r.mu.Unlock()
+
+	if err == io.EOF && n > 0 {
+		err = nil
+	}
+	return n, err
+}
+
+// Seek implements io.Seeker.
+func (r *AssetReader) Seek(offset int64, whence int) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var abs int64
+	switch whence {
+	case io.SeekStart:
+		abs = offset
+	case io.SeekCurrent:
+		abs = r.offset + offset
+	case io.SeekEnd:
+		abs = r.size + offset
+	default:
+		return 0, errors.New("client.AssetReader.Seek: invalid whence")
+	}
+	if abs < 0 {
+		return 0, errors.New("client.AssetReader.Seek: negative position")
+	}
+	r.offset = abs
+	return abs, nil
+}
+
+// Close releases the block cache. Subsequent reads fail.
+func (r *AssetReader) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.closed = true
+	r.blocks = make(map[int64]*list.Element)
+	r.lru.Init()
+	return nil
+}
+
+// loadBlocks returns the contents of blocks first..last, fetching the ones
+// that are neither cached nor already being fetched.
+func (r *AssetReader) loadBlocks(first, last int64) ([][]byte, error) {
+	out := make([][]byte, last-first+1)
+	waits := make(map[int64]*blockCall)
+	var missing []int64
+
+	r.mu.Lock()
+	if r.closed {
+		r.mu.Unlock()
+		return nil, errors.New("client.AssetReader: read after close")
+	}
+	for i := first; i <= last; i++ {
+		if el, ok := r.blocks[i]; ok {
+			r.lru.MoveToFront(el)
+			out[i-first] = el.Value.(*cachedBlock).data
+			continue
+		}
+		call, ok := r.inflight[i]
+		if !ok {
+			call = &blockCall{done: make(chan struct{})}
+			r.inflight[i] = call
+			missing = append(missing, i)
+		}
+		waits[i] = call
+	}
+	r.mu.Unlock()
+
+	// Coalesce runs of adjacent missing blocks into single requests.
+	for start := 0; start < len(missing); {
+		end := start
+		for end+1 < len(missing) && missing[end+1] == missing[end]+1 {
+			end++
+		}
+		r.fetchBlocks(missing[start], missing[end])
+		start = end + 1
+	}
+
+	for i, call := range waits {
+		select {
+		case <-call.done:
+		case <-r.ctx.Done():
+			return nil, r.ctx.Err()
+		}
+		if call.err != nil {
+			return nil, call.err
+		}
+		out[i-first] = call.data
+	}
+	return out, nil
+}
+
+// fetchBlocks retrieves blocks first..last with one ranged request and
+// completes their pending calls.
+func (r *AssetReader) fetchBlocks(first, last int64) {
+	off := first * r.blockSize
+	length := (last - first + 1) * r.blockSize
+	if off+length > r.size {
+		length = r.size - off
+	}
+
+	buf := make([]byte, length)
+	body, _, err := r.fetcher.FetchRange(r.ctx, r.url, off, length)
+	if err == nil {
+		_, err = io.ReadFull(body, buf)
+		body.Close()
+		if err != nil {
+			err = fmt.Errorf("failed to read asset range: %w", err)
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := first; i <= last; i++ {
+		call := r.inflight[i]
+		delete(r.inflight, i)
+		if err != nil {
+			call.err = err
+		} else {
+			lo := (i - first) * r.blockSize
+			hi := lo + r.blockSize
+			if hi > length {
+				hi = length
+			}
+			call.data = buf[lo:hi:hi]
+			if !r.closed {
+				r.storeLocked(i, call.data)
+			}
+		}
+		close(call.done)
+	}
+}
+
+// storeLocked adds a block to the LRU cache, evicting the oldest entries.
+// The caller must hold r.mu (or have exclusive access during construction).
+func (r *AssetReader) storeLocked(index int64, data []byte) {
+	if r.maxBlocks == 0 {
+		return
+	}
+	if el, ok := r.blocks[index]; ok {
+		r.lru.MoveToFront(el)
+		return
+	}
+	r.blocks[index] = r.lru.PushFront(&cachedBlock{index: index, data: data})
+	for r.lru.Len() > r.maxBlocks {
+		oldest := r.lru.Back()
+		r.lru.Remove(oldest)
+		delete(r.blocks, oldest.Value.(*cachedBlock).index)
+	}
+}
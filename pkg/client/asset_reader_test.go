@@ -0,0 +1,220 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rangeServer serves content with Range support and counts requests.
+type rangeServer struct {
+	*httptest.Server
+	hits   atomic.Int32
+	ranges []string
+	mu     sync.Mutex
+	delay  time.Duration
+}
+
+func newRangeServer(t *testing.T, content []byte) *rangeServer {
+	t.Helper()
+	rs := &rangeServer{}
+	rs.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rs.hits.Add(1)
+		rs.mu.Lock()
+		rs.ranges = append(rs.ranges, r.Header.Get("Range"))
+		delay := rs.delay
+		rs.mu.Unlock()
+		time.Sleep(delay)
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		http.ServeContent(w, r, "asset.bin", time.Time{}, bytes.NewReader(content))
+	}))
+	t.Cleanup(rs.Close)
+	return rs
+}
+
+// lastRange returns the Range header of the most recent request.
+func (rs *rangeServer) lastRange() string {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	return rs.ranges[len(rs.ranges)-1]
+}
+
+func authMiddleware(_ context.Context, r *http.Request) error {
+	r.Header.Set("Authorization", "Bearer secret")
+	return nil
+}
+
+func testContent(n int) []byte {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = byte(i % 251)
+	}
+	return b
+}
+
+func TestClient_OpenAsset_HTTP(t *testing.T) {
+	content := testContent(10_000)
+	srv := newRangeServer(t, content)
+
+	cli, err := NewClient(srv.URL, WithMiddleware(authMiddleware))
+	require.NoError(t, err)
+
+	r, err := cli.OpenAsset(context.Background(), "asset.bin", WithBlockSize(1024))
+	require.NoError(t, err)
+	defer r.Close()
+
+	assert.Equal(t, int64(len(content)), r.Size())
+	assert.Equal(t, int32(1), srv.hits.Load())
+	assert.Equal(t, "bytes=0-1023", srv.lastRange())
+
+	t.Run("read within first block is cached", func(t *testing.T) {
+		buf := make([]byte, 16)
+		n, err := r.ReadAt(buf, 100)
+		require.NoError(t, err)
+		assert.Equal(t, 16, n)
+		assert.Equal(t, content[100:116], buf)
+		assert.Equal(t, int32(1), srv.hits.Load())
+	})
+
+	t.Run("spanning read is coalesced", func(t *testing.T) {
+		before := srv.hits.Load()
+		buf := make([]byte, 3000)
+		n, err := r.ReadAt(buf, 1500)
+		require.NoError(t, err)
+		assert.Equal(t, 3000, n)
+		assert.Equal(t, content[1500:4500], buf)
+		assert.Equal(t, before+1, srv.hits.Load())
+		assert.Equal(t, "bytes=1024-5119", srv.lastRange())
+	})
+
+	t.Run("read past end", func(t *testing.T) {
+		buf := make([]byte, 100)
+		n, err := r.ReadAt(buf, int64(len(content)-10))
+		assert.ErrorIs(t, err, io.EOF)
+		assert.Equal(t, 10, n)
+		assert.Equal(t, content[len(content)-10:], buf[:n])
+
+		_, err = r.ReadAt(buf, int64(len(content)))
+		assert.ErrorIs(t, err, io.EOF)
+	})
+
+	t.Run("seek and read all", func(t *testing.T) {
+		pos, err := r.Seek(-2000, io.SeekEnd)
+		require.NoError(t, err)
+		assert.Equal(t, int64(8000), pos)
+
+		rest, err := io.ReadAll(r)
+		require.NoError(t, err)
+		assert.Equal(t, content[8000:], rest)
+
+		_, err = r.Seek(0, io.SeekStart)
+		require.NoError(t, err)
+		all, err := io.ReadAll(r)
+		require.NoError(t, err)
+		assert.Equal(t, content, all)
+	})
+
+	t.Run("read after close", func(t *testing.T) {
+		other, err := cli.OpenAsset(context.Background(), "asset.bin", WithBlockSize(1024))
+		require.NoError(t, err)
+		require.NoError(t, other.Close())
+		_, err = other.ReadAt(make([]byte, 1), 5000)
+		require.Error(t, err)
+	})
+}
+
+func TestClient_OpenAsset_ConcurrentReadsShareRequest(t *testing.T) {
+	content := testContent(8192)
+	srv := newRangeServer(t, content)
+
+	cli, err := NewClient(srv.URL, WithMiddleware(authMiddleware))
+	require.NoError(t, err)
+
+	r, err := cli.OpenAsset(context.Background(), "asset.bin", WithBlockSize(1024))
+	require.NoError(t, err)
+
+	srv.mu.Lock()
+	srv.delay = 50 * time.Millisecond
+	srv.mu.Unlock()
+	before := srv.hits.Load()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			buf := make([]byte, 10)
+			_, err := r.ReadAt(buf, 5000)
+			assert.NoError(t, err)
+			assert.Equal(t, content[5000:5010], buf)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, before+1, srv.hits.Load())
+}
+
+func TestClient_OpenAsset_Errors(t *testing.T) {
+	noRange := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", "4096")
+		w.Write(testContent(4096))
+	}))
+	defer noRange.Close()
+
+	cli, err := NewClient(noRange.URL)
+	require.NoError(t, err)
+
+	_, err = cli.OpenAsset(context.Background(), "big.bin", WithBlockSize(1024))
+	assert.ErrorIs(t, err, ErrRangeNotSupported)
+
+	r, err := cli.OpenAsset(context.Background(), "small.bin", WithBlockSize(1<<20))
+	require.NoError(t, err, "a full response that fits in one block is accepted")
+	assert.Equal(t, int64(4096), r.Size())
+
+	cli.RegisterAssetFetcher("plain", AssetFetcherFunc(func(context.Context, *url.URL) (io.ReadCloser, int64, error) {
+		return nil, 0, nil
+	}))
+	_, err = cli.OpenAsset(context.Background(), "plain://x/y")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "does not support range requests")
+}
+
+func TestClient_OpenAsset_S3(t *testing.T) {
+	content := string(testContent(5000))
+	srv, seen := newFakeS3Server(t, map[string]map[string]string{
+		"bucket": {"cog.tif": content},
+	})
+
+	cli, err := NewClient("https://stac.example.com", WithS3Options(S3Options{
+		Endpoint:     srv.URL,
+		UsePathStyle: true,
+		Region:       "us-east-1",
+		Anonymous:    true,
+	}))
+	require.NoError(t, err)
+
+	r, err := cli.OpenAsset(context.Background(), "s3://bucket/cog.tif", WithBlockSize(1000))
+	require.NoError(t, err)
+	assert.Equal(t, int64(5000), r.Size())
+
+	buf := make([]byte, 200)
+	_, err = r.ReadAt(buf, 4100)
+	require.NoError(t, err)
+	assert.Equal(t, []byte(content[4100:4300]), buf)
+
+	last := (*seen)[len(*seen)-1]
+	assert.Equal(t, "bytes=4000-4999", last.Get("Range"))
+}
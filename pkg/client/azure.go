@@ -0,0 +1,148 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	azureBlobHostSuffix = ".blob.core.windows.net"
+	azureStorageVersion = "2021-08-06"
+)
+
+// AzureBlobOptions configures how Azure Blob Storage asset hrefs are fetched.
+//
+// Both az://container/blob hrefs and https://<account>.blob.core.windows.net
+// URLs are handled once the options are set. Without them, blob URLs are
+// fetched like any other https href.
+type AzureBlobOptions struct {
+	// Account is the storage account used to resolve az://container/blob hrefs.
+	Account string
+
+	// Endpoint overrides the blob service URL, e.g.
+	// "http://127.0.0.1:10000/devstoreaccount1" for Azurite.
+	Endpoint string
+
+	// SASToken is appended to requests whose URL does not already carry a
+	// signature. A leading "?" is optional.
+	SASToken string
+
+	// Token supplies a Microsoft Entra ID bearer token. It is ignored when a
+	// SAS token is present.
+	Token TokenFunc
+}
+
+// WithAzureBlobOptions configures access to Azure Blob Storage asset hrefs.
+func WithAzureBlobOptions(opts AzureBlobOptions) ClientOption {
+	return func(c *Client) { c.azureOptions = &opts }
+}
+
+func isAzureBlobHost(host string) bool {
+	return strings.HasSuffix(strings.ToLower(host), azureBlobHostSuffix)
+}
+
+// azureBlobURL maps an az:// or blob https URL onto the configured endpoint
+// and attaches the SAS token when needed.
+func (c *Client) azureBlobURL(u *url.URL) (*url.URL, error) {
+	opts := AzureBlobOptions{}
+	if c.azureOptions != nil {
+		opts = *c.azureOptions
+	}
+
+	var (
+		endpoint string
+		blobPath string
+	)
+	switch strings.ToLower(u.Scheme) {
+	case "az":
+		container := u.Host
+		blob := strings.TrimPrefix(u.Path, "/")
+		if container == "" || blob == "" {
+			return nil, fmt.Errorf("invalid Azure URL %q: expected az://container/blob", u)
+		}
+		blobPath = container + "/" + blob
+		switch {
+		case opts.Endpoint != "":
+			endpoint = opts.Endpoint
+		case opts.Account != "":
+			endpoint = "https://" + opts.Account + azureBlobHostSuffix
+		default:
+			return nil, fmt.Errorf("azure account or endpoint must be configured for %s", u)
+		}
+	default:
+		blobPath = strings.TrimPrefix(u.Path, "/")
+		endpoint = u.Scheme + "://" + u.Host
+		if opts.Endpoint != "" {
+			endpoint = opts.Endpoint
+		}
+	}
+
+	base, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Azure endpoint %q: %w", endpoint, err)
+	}
+	out := base.JoinPath(blobPath)
+	out.RawQuery = u.RawQuery
+
+	if !out.Query().Has("sig") {
+		appendRawQuery(out, opts.SASToken)
+	}
+	return out, nil
+}
+
+// appendRawQuery appends an already-encoded query string such as a SAS token
+// to u. A leading "?" is ignored.
+func appendRawQuery(u *url.URL, query string) {
+	query = strings.TrimPrefix(query, "?")
+	if query == "" {
+		return
+	}
+	if u.RawQuery != "" {
+		u.RawQuery += "&" + query
+	} else {
+		u.RawQuery = query
+	}
+}
+
+// azureGet issues a GET for a blob with the configured credentials.
+func (c *Client) azureGet(ctx context.Context, u *url.URL, header http.Header) (*http.Response, error) {
+	blobURL, err := c.azureBlobURL(u)
+	if err != nil {
+		return nil, err
+	}
+
+	var token TokenFunc
+	if c.azureOptions != nil && !blobURL.Query().Has("sig") {
+		token = c.azureOptions.Token
+	}
+	if header == nil {
+		header = make(http.Header)
+	}
+	header.Set("X-Ms-Version", azureStorageVersion)
+
+	return c.getWithToken(ctx, blobURL.String(), token, header)
+}
+
+func (c *Client) fetchAzureBlob(ctx context.Context, u *url.URL) (io.ReadCloser, int64, error) {
+	resp, err := c.azureGet(ctx, u, nil)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to download from Azure: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, 0, fmt.Errorf("failed to download from Azure: %w", newStatusError(resp))
+	}
+	return resp.Body, resp.ContentLength, nil
+}
+
+func (c *Client) fetchAzureBlobRange(ctx context.Context, u *url.URL, off, length int64) (io.ReadCloser, int64, error) {
+	resp, err := c.azureGet(ctx, u, http.Header{"Range": []string{rangeHeader(off, length)}})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read Azure range: %w", err)
+	}
+	return rangeResponse(resp, off, length)
+}
@@ -9,8 +9,10 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/robert-malhotra/go-stac-client/pkg/stac"
 )
 
@@ -43,6 +45,22 @@ type Client struct {
 	httpClient  *http.Client
 	nextHandler NextHandler
 	middleware  []Middleware
+
+	s3Options    S3Options
+	s3Mu         sync.Mutex
+	s3Client     *s3.Client
+	gcsOptions   *GCSOptions
+	azureOptions *AzureBlobOptions
+
+	fetchersMu sync.RWMutex
+	fetchers   map[string]AssetFetcher
+
+	signer    Signer
+	signItems bool
+
+	bandwidth *rateLimiter
+
+	migrate bool
 }
 
 // -----------------------------------------------------------------------------
@@ -54,7 +72,8 @@ func WithHTTPClient(client *http.Client) ClientOption {
 	return func(c *Client) { c.httpClient = client }
 }
 
-// WithTimeout sets the HTTP timeout.
+// WithTimeout sets the HTTP timeout. For asset downloads it bounds the wait
+// for the response headers rather than the whole transfer.
 func WithTimeout(d time.Duration) ClientOption {
 	return func(c *Client) { c.httpClient.Timeout = d }
 }
@@ -259,14 +278,18 @@ func iteratePagesWithDecoder[T any](
 			}
 			if resp.StatusCode != http.StatusOK {
 				resp.Body.Close()
-				if !yield(nil, fmt.Errorf("unexpected status %d on %s", resp.StatusCode, current)) {
+				if !yield(nil, newStatusError(resp)) {
 					return
 				}
 				return
 			}
 
 			// --------------------------- Decode body ----------------------
-			page, err := decoder(resp.Body)
+			body, err := cli.migrateBody(resp.Body)
+			var page *PageResponse[T]
+			if err == nil {
+				page, err = decoder(body)
+			}
 			resp.Body.Close()
 			if err != nil {
 				if !yield(nil, fmt.Errorf("error decoding response from %s: %w", current, err)) {
@@ -276,6 +299,10 @@ func iteratePagesWithDecoder[T any](
 			}
 
 			for _, v := range page.Items {
+				if err := cli.prepareValue(ctx, v); err != nil {
+					yield(nil, err)
+					return
+				}
 				if !yield(v, nil) {
 					return // consumer stopped
 				}
@@ -316,13 +343,24 @@ func (c *Client) doRequest(ctx context.Context, method, rawURL string, body io.R
 	if err != nil {
 		return nil, fmt.Errorf("error creating request for %s: %w", rawURL, err)
 	}
+	return c.do(ctx, req)
+}
 
-	// Apply all registered middleware in order.
+// do runs the middleware chain on a prepared request and executes it. Use it
+// instead of doRequest when the request needs extra headers.
+func (c *Client) do(ctx context.Context, req *http.Request) (*http.Response, error) {
+	if err := c.applyMiddleware(ctx, req); err != nil {
+		return nil, err
+	}
+	return c.httpClient.Do(req)
+}
+
+// applyMiddleware runs all registered middleware on req in order.
+func (c *Client) applyMiddleware(ctx context.Context, req *http.Request) error {
 	for _, mw := range c.middleware {
 		if err := mw(ctx, req); err != nil {
-			return nil, fmt.Errorf("error applying middleware for %s: %w", rawURL, err)
+			return fmt.Errorf("error applying middleware for %s: %w", req.URL, err)
 		}
 	}
-
-	return c.httpClient.Do(req)
+	return nil
 }
@@ -0,0 +1,94 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/robert-malhotra/go-stac-client/pkg/stac"
+)
+
+// CollectionSearchParams filters collections with the Collection Search
+// extension. Zero fields are left out of the request.
+type CollectionSearchParams struct {
+	Bbox     []float64
+	Datetime string
+	// Q is a free-text query matched against titles, descriptions and
+	// keywords; terms separated by commas match any of them.
+	Q string
+	// Filter is a CQL2 expression, sent as CQL2-JSON.
+	Filter *Filter
+	SortBy []SortField
+	Limit  int
+}
+
+// values encodes the parameters as a GET query string.
+func (p CollectionSearchParams) values() (url.Values, error) {
+	q := url.Values{}
+	if len(p.Bbox) > 0 {
+		if len(p.Bbox) != 4 && len(p.Bbox) != 6 {
+			return nil, fmt.Errorf("bbox must have 4 or 6 values, got %d", len(p.Bbox))
+		}
+		coords := make([]string, len(p.Bbox))
+		for i, v := range p.Bbox {
+			coords[i] = strconv.FormatFloat(v, 'f', -1, 64)
+		}
+		q.Set("bbox", strings.Join(coords, ","))
+	}
+	if p.Datetime != "" {
+		q.Set("datetime", p.Datetime)
+	}
+	if p.Q != "" {
+		q.Set("q", p.Q)
+	}
+	if p.Filter != nil {
+		data, err := json.Marshal(p.Filter)
+		if err != nil {
+			return nil, fmt.Errorf("error encoding filter: %w", err)
+		}
+		q.Set("filter", string(data))
+		q.Set("filter-lang", "cql2-json")
+	}
+	if len(p.SortBy) > 0 {
+		parts := make([]string, len(p.SortBy))
+		for i, s := range p.SortBy {
+			if strings.EqualFold(s.Direction, "desc") {
+				parts[i] = "-" + s.Field
+			} else {
+				parts[i] = "+" + s.Field
+			}
+		}
+		q.Set("sortby", strings.Join(parts, ","))
+	}
+	if p.Limit > 0 {
+		q.Set("limit", strconv.Itoa(p.Limit))
+	}
+	return q, nil
+}
+
+// SearchCollections lists the collections matching params using the
+// Collection Search extension (GET /collections with query parameters),
+// following pagination like GetCollections. Servers without the extension
+// typically ignore the parameters and list every collection; check for
+// ConformanceCollectionSearch with GetConformance first.
+func (c *Client) SearchCollections(ctx context.Context, params CollectionSearchParams) iter.Seq2[*stac.Collection, error] {
+	return c.SearchCollectionsWithDecoder(ctx, params, DefaultCollectionDecoder())
+}
+
+// SearchCollectionsWithDecoder searches collections using a custom page
+// decoder.
+func (c *Client) SearchCollectionsWithDecoder(ctx context.Context, params CollectionSearchParams, decoder PageDecoder[stac.Collection]) iter.Seq2[*stac.Collection, error] {
+	q, err := params.values()
+	if err != nil {
+		return func(yield func(*stac.Collection, error) bool) {
+			yield(nil, fmt.Errorf("invalid collection search parameters: %w", err))
+		}
+	}
+
+	startURL := &url.URL{Path: "collections", RawQuery: q.Encode()}
+	return iteratePagesWithDecoder[stac.Collection](ctx, c, startURL.String(), decoder)
+}
@@ -0,0 +1,140 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_SearchCollections(t *testing.T) {
+	var queries []url.Values
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/collections" {
+			http.NotFound(w, r)
+			return
+		}
+		queries = append(queries, r.URL.Query())
+
+		page := map[string]any{
+			"collections": []map[string]any{{"type": "Collection", "id": "sentinel-2-l2a", "title": "Sentinel-2 Level-2A"}},
+			"links": []map[string]any{{
+				"rel":  "next",
+				"href": srv.URL + "/collections?q=sentinel&token=page2",
+			}},
+		}
+		if r.URL.Query().Get("token") == "page2" {
+			page = map[string]any{
+				"collections": []map[string]any{{"type": "Collection", "id": "sentinel-1-grd", "title": "Sentinel-1 GRD"}},
+				"links":       []map[string]any{},
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(page)
+	}))
+	defer srv.Close()
+
+	c, err := NewClient(srv.URL)
+	require.NoError(t, err)
+
+	t.Run("encodes parameters and follows pages", func(t *testing.T) {
+		queries = nil
+		params := CollectionSearchParams{
+			Bbox:     []float64{-10, 40.5, 5, 50},
+			Datetime: "2024-01-01T00:00:00Z/..",
+			Q:        "sentinel,landsat",
+			Filter:   NewFilterBuilder().Where(Eq(Property("platform"), String("sentinel-2a"))).Build(),
+			SortBy:   []SortField{{Field: "title", Direction: "asc"}, {Field: "id", Direction: "desc"}},
+			Limit:    1,
+		}
+
+		var ids []string
+		for col, err := range c.SearchCollections(context.Background(), params) {
+			require.NoError(t, err)
+			ids = append(ids, col.Id)
+		}
+		assert.Equal(t, []string{"sentinel-2-l2a", "sentinel-1-grd"}, ids)
+
+		require.Len(t, queries, 2)
+		first := queries[0]
+		assert.Equal(t, "-10,40.5,5,50", first.Get("bbox"))
+		assert.Equal(t, "2024-01-01T00:00:00Z/..", first.Get("datetime"))
+		assert.Equal(t, "sentinel,landsat", first.Get("q"))
+		assert.Equal(t, "+title,-id", first.Get("sortby"))
+		assert.Equal(t, "1", first.Get("limit"))
+		assert.Equal(t, "cql2-json", first.Get("filter-lang"))
+		var filter map[string]any
+		require.NoError(t, json.Unmarshal([]byte(first.Get("filter")), &filter))
+		assert.Equal(t, "=", filter["op"])
+		assert.Equal(t, "page2", queries[1].Get("token"))
+	})
+
+	t.Run("invalid bbox", func(t *testing.T) {
+		queries = nil
+		var gotErr error
+		for _, err := range c.SearchCollections(context.Background(), CollectionSearchParams{Bbox: []float64{1, 2, 3}}) {
+			gotErr = err
+		}
+		assert.ErrorContains(t, gotErr, "bbox")
+		assert.Empty(t, queries)
+	})
+}
+
+func TestClient_GetConformance(t *testing.T) {
+	classes := []string{
+		"https://api.stacspec.org/v1.0.0/core",
+		"https://api.stacspec.org/v1.0.0-rc.1/collection-search",
+		"https://api.stacspec.org/v1.0.0-rc.1/collection-search#free-text",
+	}
+
+	t.Run("conformance endpoint", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/conformance" {
+				http.NotFound(w, r)
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]any{"conformsTo": classes})
+		}))
+		defer srv.Close()
+
+		c, err := NewClient(srv.URL)
+		require.NoError(t, err)
+		got, err := c.GetConformance(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, classes, got)
+
+		assert.True(t, ConformsTo(got, ConformanceCollectionSearch))
+		assert.True(t, ConformsTo(got, ConformanceFreeText))
+		assert.True(t, ConformsTo(got, "https://api.stacspec.org/v1.0.0/core"))
+		assert.False(t, ConformsTo(got, ConformanceItemSearch))
+	})
+
+	t.Run("landing page fallback", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/" {
+				http.NotFound(w, r)
+				return
+			}
+			fmt.Fprintf(w, `{"type":"Catalog","id":"api","description":"API","links":[],"conformsTo":["%s"]}`, classes[0])
+		}))
+		defer srv.Close()
+
+		c, err := NewClient(srv.URL)
+		require.NoError(t, err)
+		landing, err := c.GetLandingPage(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "api", landing.Id)
+
+		got, err := c.GetConformance(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, classes[:1], got)
+		assert.True(t, ConformsTo(got, ConformanceCore))
+	})
+}
@@ -12,25 +12,38 @@ import (
 
 // GetCollection fetches a single collection document by ID.
 func (c *Client) GetCollection(ctx context.Context, collectionID string) (*stac.Collection, error) {
+	col, _, err := c.GetCollectionWithETag(ctx, collectionID)
+	return col, err
+}
+
+// GetCollectionWithETag fetches a collection together with its ETag, for use
+// with IfMatch. The ETag is empty when the server does not send one.
+func (c *Client) GetCollectionWithETag(ctx context.Context, collectionID string) (*stac.Collection, string, error) {
 	if collectionID == "" {
-		return nil, fmt.Errorf("collection ID cannot be empty")
+		return nil, "", fmt.Errorf("collection ID cannot be empty")
 	}
 
 	u := c.baseURL.JoinPath("collections", collectionID)
 
 	resp, err := c.doRequest(ctx, http.MethodGet, u.String(), nil)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code %d for %s", resp.StatusCode, u)
+		return nil, "", newStatusError(resp)
 	}
 
+	body, err := c.migrateBody(resp.Body)
+	if err != nil {
+		return nil, "", fmt.Errorf("error decoding response from %s: %w", u, err)
+	}
 	var col stac.Collection
-	err = json.NewDecoder(resp.Body).Decode(&col)
-	return &col, err
+	if err := json.NewDecoder(body).Decode(&col); err != nil {
+		return nil, "", fmt.Errorf("error decoding response from %s: %w", u, err)
+	}
+	return &col, resp.Header.Get("ETag"), nil
 }
 
 // GetCollections iterates over every collection exposed by the STAC API
@@ -64,11 +77,11 @@ func (c *Client) GetQueryables(ctx context.Context, collectionID string) (*stac.
 	defer resp.Body.Close()
 
 	if resp.StatusCode == http.StatusNotFound {
-		return nil, fmt.Errorf("queryables not available for collection %s", collectionID)
+		return nil, fmt.Errorf("queryables not available for collection %s: %w", collectionID, newStatusError(resp))
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code %d for %s", resp.StatusCode, u)
+		return nil, newStatusError(resp)
 	}
 
 	var q stac.Queryables
@@ -88,11 +101,11 @@ func (c *Client) GetGlobalQueryables(ctx context.Context) (*stac.Queryables, err
 	defer resp.Body.Close()
 
 	if resp.StatusCode == http.StatusNotFound {
-		return nil, fmt.Errorf("queryables endpoint not available")
+		return nil, fmt.Errorf("queryables endpoint not available: %w", newStatusError(resp))
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code %d for %s", resp.StatusCode, u)
+		return nil, newStatusError(resp)
 	}
 
 	var q stac.Queryables
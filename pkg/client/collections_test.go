@@ -98,12 +98,17 @@ func TestClient_GetCollection(t *testing.T) {
 		_, err := cli.GetCollection(context.Background(), "missing")
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "unexpected status code 404")
+		assert.ErrorIs(t, err, ErrNotFound)
+		var statusErr *StatusError
+		require.ErrorAs(t, err, &statusErr)
+		assert.Equal(t, http.StatusNotFound, statusErr.StatusCode)
 	})
 
 	t.Run("server error", func(t *testing.T) {
 		_, err := cli.GetCollection(context.Background(), "error-collection")
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "unexpected status code 500")
+		assert.NotErrorIs(t, err, ErrNotFound)
 	})
 
 }
@@ -0,0 +1,95 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/robert-malhotra/go-stac-client/pkg/stac"
+)
+
+// Conformance classes checked by the client, given as the part of the class
+// URI after the version so that every release of the spec matches. Use them
+// with ConformsTo.
+const (
+	ConformanceCore             = "core"
+	ConformanceItemSearch       = "item-search"
+	ConformanceCollectionSearch = "collection-search"
+	ConformanceFreeText         = "collection-search#free-text"
+	ConformanceTransaction      = "ogcapi-features/extensions/transaction"
+	ConformanceAggregation      = "aggregation"
+)
+
+// GetLandingPage fetches the API landing page, the root catalog whose
+// "conformsTo" member lists the conformance classes.
+func (c *Client) GetLandingPage(ctx context.Context) (*stac.Catalog, error) {
+	u := c.baseURL.String()
+
+	resp, err := c.doRequest(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newStatusError(resp)
+	}
+
+	var cat stac.Catalog
+	if err := json.NewDecoder(resp.Body).Decode(&cat); err != nil {
+		return nil, fmt.Errorf("error decoding response from %s: %w", u, err)
+	}
+	return &cat, nil
+}
+
+// GetConformance returns the conformance classes the API implements, from
+// /conformance or, when that endpoint is missing, the landing page.
+func (c *Client) GetConformance(ctx context.Context) ([]string, error) {
+	u := c.baseURL.JoinPath("conformance")
+
+	resp, err := c.doRequest(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusOK {
+		var doc struct {
+			ConformsTo []string `json:"conformsTo"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&doc); err != nil {
+			return nil, fmt.Errorf("error decoding response from %s: %w", u, err)
+		}
+		return doc.ConformsTo, nil
+	}
+	if resp.StatusCode != http.StatusNotFound {
+		return nil, newStatusError(resp)
+	}
+
+	landing, err := c.GetLandingPage(ctx)
+	if err != nil {
+		return nil, err
+	}
+	raw, _ := landing.AdditionalFields["conformsTo"].([]any)
+	classes := make([]string, 0, len(raw))
+	for _, v := range raw {
+		if s, ok := v.(string); ok {
+			classes = append(classes, s)
+		}
+	}
+	return classes, nil
+}
+
+// ConformsTo reports whether classes includes class in any version, where
+// class is a full URI or the part after the version, such as
+// ConformanceCollectionSearch.
+func ConformsTo(classes []string, class string) bool {
+	for _, c := range classes {
+		if c == class || strings.HasSuffix(c, "/"+class) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,123 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// Destination receives the body of a downloaded asset.
+//
+// Open is called once the asset has been opened successfully, so nothing is
+// created for hrefs that fail to resolve or fetch. After Open succeeds,
+// Commit is called once the whole asset has been written, and Abort is
+// called if the transfer or Commit fails. A Destination is used for a single
+// download.
+type Destination interface {
+	// Open prepares the destination and returns the writer for the asset
+	// bytes. total is the expected size, or negative when unknown.
+	Open(ctx context.Context, total int64) (io.Writer, error)
+
+	// Commit finalizes a complete download.
+	Commit() error
+
+	// Abort discards a partial download.
+	Abort() error
+}
+
+// WriterDestination streams an asset into w, e.g. a bytes.Buffer, a hash or
+// an upload to another object store. w is not closed.
+func WriterDestination(w io.Writer) Destination {
+	return writerDestination{w: w}
+}
+
+type writerDestination struct {
+	w io.Writer
+}
+
+func (d writerDestination) Open(context.Context, int64) (io.Writer, error) { return d.w, nil }
+func (d writerDestination) Commit() error                                  { return nil }
+func (d writerDestination) Abort() error                                   { return nil }
+
+// FileDestination writes an asset to path, truncating any existing file. The
+// file is removed if the download fails.
+func FileDestination(path string) Destination {
+	return &fileDestination{path: path}
+}
+
+type fileDestination struct {
+	path string
+	f    *os.File
+}
+
+func (d *fileDestination) Open(context.Context, int64) (io.Writer, error) {
+	f, err := os.Create(d.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create destination file: %w", err)
+	}
+	d.f = f
+	return f, nil
+}
+
+func (d *fileDestination) Commit() error {
+	return d.f.Close()
+}
+
+func (d *fileDestination) Abort() error {
+	d.f.Close()
+	return os.Remove(d.path)
+}
+
+// AtomicFileDestination writes an asset to a temporary file next to path and
+// renames it into place once the download completes, so path never holds a
+// partial asset and an existing file survives a failed download.
+func AtomicFileDestination(path string) Destination {
+	return &atomicFileDestination{path: path}
+}
+
+type atomicFileDestination struct {
+	path string
+	f    *os.File
+}
+
+func (d *atomicFileDestination) Open(context.Context, int64) (io.Writer, error) {
+	f, err := createTemp(filepath.Dir(d.path), "."+filepath.Base(d.path)+".", ".part")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	d.f = f
+	return f, nil
+}
+
+// createTemp creates a new file named dir/prefix<random>suffix. Unlike
+// os.CreateTemp, whose files are owner-only, it uses the mode os.Create
+// gives, 0666 less the umask, as the file is renamed into place.
+func createTemp(dir, prefix, suffix string) (*os.File, error) {
+	for range 10000 {
+		name := filepath.Join(dir, prefix+strconv.FormatUint(uint64(rand.Uint32()), 10)+suffix)
+		f, err := os.OpenFile(name, os.O_RDWR|os.O_CREATE|os.O_EXCL, 0o666)
+		if errors.Is(err, fs.ErrExist) {
+			continue
+		}
+		return f, err
+	}
+	return nil, fmt.Errorf("no unused temporary file name in %s", dir)
+}
+
+func (d *atomicFileDestination) Commit() error {
+	if err := d.f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(d.f.Name(), d.path)
+}
+
+func (d *atomicFileDestination) Abort() error {
+	d.f.Close()
+	return os.Remove(d.f.Name())
+}
@@ -5,13 +5,6 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"net/http"
-	"net/url"
-	"os"
-	"strings"
-
-	"github.com/aws/aws-sdk-go-v2/config"
-	"github.com/aws/aws-sdk-go-v2/service/s3"
 )
 
 // ProgressFunc reports cumulative bytes downloaded and the expected total.
@@ -23,115 +16,97 @@ func (c *Client) DownloadAsset(ctx context.Context, assetURL, destPath string) e
 }
 
 // DownloadAssetWithProgress downloads an asset while reporting progress.
+//
+// The URL scheme selects the fetcher: http and https go through the client's
+// middleware, s3, gs and az use the configured cloud storage options, and any
+// scheme registered with RegisterAssetFetcher is supported as well.
 func (c *Client) DownloadAssetWithProgress(
 	ctx context.Context,
 	assetURL string,
 	destPath string,
 	progress ProgressFunc,
 ) error {
-	if c == nil {
-		return fmt.Errorf("client is nil")
-	}
-
-	u, err := url.Parse(assetURL)
-	if err != nil {
-		return fmt.Errorf("failed to parse asset URL: %w", err)
-	}
+	return c.DownloadAssetToDestination(ctx, assetURL, FileDestination(destPath), progress)
+}
 
-	if u.Scheme == "" {
-		u = c.baseURL.ResolveReference(u)
-	}
+// DownloadOption configures a single download.
+type DownloadOption func(*downloadConfig)
 
-	switch u.Scheme {
-	case "http", "https":
-		return c.downloadHTTP(ctx, u.String(), destPath, progress)
-	case "s3":
-		return downloadS3(ctx, u, destPath, progress)
-	default:
-		return fmt.Errorf("unsupported URL scheme: %s", u.Scheme)
-	}
+type downloadConfig struct {
+	bandwidthLimit int64
+	gate           func(context.Context) error
 }
 
-func (c *Client) downloadHTTP(ctx context.Context, assetURL string, destPath string, progress ProgressFunc) (err error) {
-	resp, err := c.doRequest(ctx, http.MethodGet, assetURL, nil)
-	if err != nil {
-		return fmt.Errorf("failed to download asset: %w", err)
-	}
-	defer resp.Body.Close()
+// WithDownloadBandwidthLimit caps a single download at bytesPerSec, in
+// addition to any client-wide WithBandwidthLimit.
+func WithDownloadBandwidthLimit(bytesPerSec int64) DownloadOption {
+	return func(cfg *downloadConfig) { cfg.bandwidthLimit = bytesPerSec }
+}
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("failed to download asset: unexpected status code %d", resp.StatusCode)
-	}
+// withDownloadGate makes the transfer call gate before every read; gate
+// blocks while the transfer is paused.
+func withDownloadGate(gate func(context.Context) error) DownloadOption {
+	return func(cfg *downloadConfig) { cfg.gate = gate }
+}
 
-	out, err := os.Create(destPath)
-	if err != nil {
-		return fmt.Errorf("failed to create destination file: %w", err)
-	}
-	defer func() {
-		out.Close()
-		if err != nil {
-			_ = os.Remove(destPath)
-		}
-	}()
+// DownloadAssetTo streams an asset into w while reporting progress. w may
+// already hold data when an error is returned.
+func (c *Client) DownloadAssetTo(ctx context.Context, assetURL string, w io.Writer, progress ProgressFunc, opts ...DownloadOption) error {
+	return c.DownloadAssetToDestination(ctx, assetURL, WriterDestination(w), progress, opts...)
+}
 
-	total := resp.ContentLength
-	if progress != nil {
-		progress(0, total)
+// DownloadAssetToDestination downloads an asset into dest while reporting
+// progress. Hrefs are resolved and fetched exactly as by
+// DownloadAssetWithProgress; dest is committed on success and aborted if
+// the transfer fails. Throughput is capped by the client's WithBandwidthLimit
+// and by WithDownloadBandwidthLimit, if given.
+func (c *Client) DownloadAssetToDestination(
+	ctx context.Context,
+	assetURL string,
+	dest Destination,
+	progress ProgressFunc,
+	opts ...DownloadOption,
+) (err error) {
+	if c == nil {
+		return fmt.Errorf("client is nil")
 	}
-
-	_, err = copyWithProgress(ctx, out, resp.Body, total, progress)
-	if err != nil {
-		return fmt.Errorf("failed to write asset to file: %w", err)
+	if dest == nil {
+		return fmt.Errorf("destination is nil")
 	}
 
-	return nil
-}
-
-func downloadS3(ctx context.Context, u *url.URL, destPath string, progress ProgressFunc) (err error) {
-	cfg, err := config.LoadDefaultConfig(ctx)
-	if err != nil {
-		return fmt.Errorf("failed to load AWS config: %w", err)
+	var cfg downloadConfig
+	for _, o := range opts {
+		o(&cfg)
 	}
 
-	s3Client := s3.NewFromConfig(cfg)
-
-	bucket := u.Host
-	key := strings.TrimPrefix(u.Path, "/")
-
-	result, err := s3Client.GetObject(ctx, &s3.GetObjectInput{
-		Bucket: &bucket,
-		Key:    &key,
-	})
+	body, total, err := c.openAsset(ctx, assetURL)
 	if err != nil {
-		return fmt.Errorf("failed to download from S3: %w", err)
+		return err
 	}
-	defer result.Body.Close()
+	defer body.Close()
 
-	out, err := os.Create(destPath)
+	out, err := dest.Open(ctx, total)
 	if err != nil {
-		return fmt.Errorf("failed to create destination file: %w", err)
+		return err
 	}
 	defer func() {
-		out.Close()
 		if err != nil {
-			_ = os.Remove(destPath)
+			_ = dest.Abort()
 		}
 	}()
 
-	var total int64
-	if result.ContentLength != nil {
-		total = *result.ContentLength
-	}
-
 	if progress != nil {
 		progress(0, total)
 	}
 
-	_, err = copyWithProgress(ctx, out, result.Body, total, progress)
-	if err != nil {
-		return fmt.Errorf("failed to write asset to file: %w", err)
+	src := newThrottledReader(ctx, body, cfg.gate, c.bandwidth, newRateLimiter(cfg.bandwidthLimit))
+	if _, err = copyWithProgress(ctx, out, src, total, progress); err != nil {
+		return fmt.Errorf("failed to write asset: %w", err)
 	}
 
+	if err = dest.Commit(); err != nil {
+		return fmt.Errorf("failed to finalize asset: %w", err)
+	}
 	return nil
 }
 
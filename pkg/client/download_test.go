@@ -0,0 +1,384 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newFakeS3Server serves objects from a bucket→key→content map using
+// path-style addressing (with Range support), recording the headers of every request.
+func newFakeS3Server(t *testing.T, objects map[string]map[string]string) (*httptest.Server, *[]http.Header) {
+	t.Helper()
+	var seen []http.Header
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = append(seen, r.Header.Clone())
+
+		bucket, key, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+		body, ok := objects[bucket][key]
+		if !ok {
+			w.Header().Set("Content-Type", "application/xml")
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?><Error><Code>NoSuchKey</Code><Message>not found</Message></Error>`))
+			return
+		}
+		http.ServeContent(w, r, key, time.Time{}, strings.NewReader(body))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &seen
+}
+
+func TestClient_FetchS3_UnknownSize(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Flushing before the body sends it chunked, without Content-Length.
+		w.WriteHeader(http.StatusOK)
+		w.(http.Flusher).Flush()
+		w.Write([]byte("band-4"))
+	}))
+	defer srv.Close()
+
+	cli, err := NewClient("https://stac.example.com", WithS3Options(S3Options{
+		Endpoint: srv.URL, UsePathStyle: true, Region: "us-west-2", Anonymous: true,
+	}))
+	require.NoError(t, err)
+
+	u, err := url.Parse("s3://landsat/c2/scene/B4.TIF")
+	require.NoError(t, err)
+	rc, total, err := cli.fetchS3(context.Background(), u)
+	require.NoError(t, err)
+	defer rc.Close()
+	assert.Equal(t, int64(-1), total, "a missing Content-Length is an unknown size")
+}
+
+func TestClient_DownloadAsset_HTTP(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/data/scene.tif" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte("tiff-bytes"))
+	}))
+	defer srv.Close()
+
+	cli, err := NewClient(srv.URL)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+
+	t.Run("relative href", func(t *testing.T) {
+		dest := filepath.Join(dir, "scene.tif")
+		var last int64
+		err := cli.DownloadAssetWithProgress(context.Background(), "data/scene.tif", dest, func(downloaded, total int64) {
+			last = downloaded
+		})
+		require.NoError(t, err)
+
+		got, err := os.ReadFile(dest)
+		require.NoError(t, err)
+		assert.Equal(t, "tiff-bytes", string(got))
+		assert.Equal(t, int64(len("tiff-bytes")), last)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		dest := filepath.Join(dir, "missing.tif")
+		err := cli.DownloadAsset(context.Background(), srv.URL+"/missing.tif", dest)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unexpected status code 404")
+		assert.NoFileExists(t, dest)
+	})
+
+	t.Run("unsupported scheme", func(t *testing.T) {
+		err := cli.DownloadAsset(context.Background(), "ftp://example.com/a.tif", filepath.Join(dir, "a.tif"))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unsupported URL scheme")
+	})
+
+	t.Run("response header timeout", func(t *testing.T) {
+		slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(time.Second):
+			}
+		}))
+		defer slow.Close()
+
+		cli, err := NewClient(slow.URL, WithTimeout(50*time.Millisecond))
+		require.NoError(t, err)
+		err = cli.DownloadAssetTo(context.Background(), "scene.tif", io.Discard, nil)
+		assert.ErrorIs(t, err, errResponseTimeout)
+	})
+}
+
+func TestClient_DownloadAsset_S3(t *testing.T) {
+	srv, seen := newFakeS3Server(t, map[string]map[string]string{
+		"landsat": {"c2/scene/B4.TIF": "band-4"},
+	})
+
+	cli, err := NewClient("https://stac.example.com", WithS3Options(S3Options{
+		Endpoint:      srv.URL,
+		UsePathStyle:  true,
+		Region:        "us-west-2",
+		Anonymous:     true,
+		RequesterPays: true,
+	}))
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+
+	t.Run("requester pays", func(t *testing.T) {
+		dest := filepath.Join(dir, "B4.TIF")
+		require.NoError(t, cli.DownloadAsset(context.Background(), "s3://landsat/c2/scene/B4.TIF", dest))
+
+		got, err := os.ReadFile(dest)
+		require.NoError(t, err)
+		assert.Equal(t, "band-4", string(got))
+
+		require.NotEmpty(t, *seen)
+		last := (*seen)[len(*seen)-1]
+		assert.Equal(t, "requester", last.Get("X-Amz-Request-Payer"))
+		assert.Empty(t, last.Get("Authorization"), "anonymous requests must be unsigned")
+	})
+
+	t.Run("client is reused", func(t *testing.T) {
+		first, err := cli.s3API(context.Background())
+		require.NoError(t, err)
+		second, err := cli.s3API(context.Background())
+		require.NoError(t, err)
+		assert.Same(t, first, second)
+	})
+
+	t.Run("missing object", func(t *testing.T) {
+		dest := filepath.Join(dir, "missing.TIF")
+		err := cli.DownloadAsset(context.Background(), "s3://landsat/nope.TIF", dest)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to download from S3")
+		assert.NoFileExists(t, dest)
+	})
+
+	t.Run("invalid url", func(t *testing.T) {
+		err := cli.DownloadAsset(context.Background(), "s3://landsat", filepath.Join(dir, "x"))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "expected s3://bucket/key")
+	})
+}
+
+func TestClient_DownloadAsset_GCS(t *testing.T) {
+	var gotAuth, gotProject string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		gotProject = r.URL.Query().Get("userProject")
+		if r.URL.Path != "/gcp-public-data-sentinel-2/tiles/T10/B02.jp2" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte("jp2"))
+	}))
+	defer srv.Close()
+
+	cli, err := NewClient("https://stac.example.com", WithGCSOptions(GCSOptions{
+		Endpoint:    srv.URL,
+		Token:       func(context.Context) (string, error) { return "gcs-token", nil },
+		UserProject: "billing-project",
+	}))
+	require.NoError(t, err)
+
+	dest := filepath.Join(t.TempDir(), "B02.jp2")
+	require.NoError(t, cli.DownloadAsset(context.Background(), "gs://gcp-public-data-sentinel-2/tiles/T10/B02.jp2", dest))
+
+	got, err := os.ReadFile(dest)
+	require.NoError(t, err)
+	assert.Equal(t, "jp2", string(got))
+	assert.Equal(t, "Bearer gcs-token", gotAuth)
+	assert.Equal(t, "billing-project", gotProject)
+
+	err = cli.DownloadAsset(context.Background(), "gs://gcp-public-data-sentinel-2/missing", dest)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to download from GCS")
+}
+
+func TestClient_DownloadAsset_Azure(t *testing.T) {
+	var lastQuery url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lastQuery = r.URL.Query()
+		assert.NotEmpty(t, r.Header.Get("X-Ms-Version"))
+		if r.URL.Path != "/devstoreaccount1/sentinel2-l2/10/T/scene.tif" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte("blob"))
+	}))
+	defer srv.Close()
+
+	cli, err := NewClient("https://stac.example.com", WithAzureBlobOptions(AzureBlobOptions{
+		Endpoint: srv.URL + "/devstoreaccount1",
+		SASToken: "?sv=2021&sig=abc",
+	}))
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+
+	t.Run("az scheme", func(t *testing.T) {
+		dest := filepath.Join(dir, "az.tif")
+		require.NoError(t, cli.DownloadAsset(context.Background(), "az://sentinel2-l2/10/T/scene.tif", dest))
+		got, err := os.ReadFile(dest)
+		require.NoError(t, err)
+		assert.Equal(t, "blob", string(got))
+		assert.Equal(t, "abc", lastQuery.Get("sig"))
+	})
+
+	t.Run("blob https url keeps existing signature", func(t *testing.T) {
+		dest := filepath.Join(dir, "https.tif")
+		href := "https://sentinel2l2a01.blob.core.windows.net/sentinel2-l2/10/T/scene.tif?sig=signed"
+		require.NoError(t, cli.DownloadAsset(context.Background(), href, dest))
+		assert.Equal(t, "signed", lastQuery.Get("sig"))
+	})
+
+	t.Run("az scheme requires account", func(t *testing.T) {
+		bare, err := NewClient("https://stac.example.com")
+		require.NoError(t, err)
+		err = bare.DownloadAsset(context.Background(), "az://c/b.tif", filepath.Join(dir, "x"))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "account or endpoint must be configured")
+	})
+}
+
+func TestClient_RegisterAssetFetcher(t *testing.T) {
+	var fetched string
+	custom := AssetFetcherFunc(func(_ context.Context, u *url.URL) (io.ReadCloser, int64, error) {
+		fetched = u.String()
+		return io.NopCloser(strings.NewReader("custom")), 6, nil
+	})
+
+	cli, err := NewClient("https://stac.example.com", WithAssetFetcher("MyOrg", custom))
+	require.NoError(t, err)
+
+	dest := filepath.Join(t.TempDir(), "custom.bin")
+	require.NoError(t, cli.DownloadAsset(context.Background(), "myorg://vault/object", dest))
+	assert.Equal(t, "myorg://vault/object", fetched)
+
+	got, err := os.ReadFile(dest)
+	require.NoError(t, err)
+	assert.Equal(t, "custom", string(got))
+
+	cli.RegisterAssetFetcher("myorg", nil)
+	err = cli.DownloadAsset(context.Background(), "myorg://vault/object", dest)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported URL scheme")
+}
+
+func TestClient_DownloadAssetTo(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/scene.tif":
+			w.Write([]byte("tiff-bytes"))
+		case "/truncated.tif":
+			// Promise more bytes than are sent so the transfer fails midway.
+			w.Header().Set("Content-Length", "100")
+			w.Write([]byte("partial"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	cli, err := NewClient(srv.URL)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	t.Run("writer", func(t *testing.T) {
+		var buf bytes.Buffer
+		var total int64
+		err := cli.DownloadAssetTo(ctx, "scene.tif", &buf, func(_, t int64) { total = t })
+		require.NoError(t, err)
+		assert.Equal(t, "tiff-bytes", buf.String())
+		assert.Equal(t, int64(len("tiff-bytes")), total)
+	})
+
+	t.Run("writer error", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := cli.DownloadAssetTo(ctx, "truncated.tif", &buf, nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to write asset")
+	})
+
+	t.Run("atomic file", func(t *testing.T) {
+		dir := t.TempDir()
+		dest := filepath.Join(dir, "scene.tif")
+		require.NoError(t, os.WriteFile(dest, []byte("old"), 0o644))
+
+		err := cli.DownloadAssetToDestination(ctx, "truncated.tif", AtomicFileDestination(dest), nil)
+		require.Error(t, err)
+		got, err := os.ReadFile(dest)
+		require.NoError(t, err)
+		assert.Equal(t, "old", string(got), "a failed download keeps the existing file")
+
+		require.NoError(t, cli.DownloadAssetToDestination(ctx, "scene.tif", AtomicFileDestination(dest), nil))
+		got, err = os.ReadFile(dest)
+		require.NoError(t, err)
+		assert.Equal(t, "tiff-bytes", string(got))
+
+		// The file gets the mode os.Create gives, not os.CreateTemp's 0600.
+		ref, err := os.Create(filepath.Join(t.TempDir(), "ref"))
+		require.NoError(t, err)
+		ref.Close()
+		refInfo, err := os.Stat(ref.Name())
+		require.NoError(t, err)
+		info, err := os.Stat(dest)
+		require.NoError(t, err)
+		assert.Equal(t, refInfo.Mode().Perm(), info.Mode().Perm())
+
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		assert.Len(t, entries, 1, "temporary files are cleaned up")
+	})
+
+	t.Run("file removed on failure", func(t *testing.T) {
+		dest := filepath.Join(t.TempDir(), "truncated.tif")
+		err := cli.DownloadAssetToDestination(ctx, "truncated.tif", FileDestination(dest), nil)
+		require.Error(t, err)
+		assert.NoFileExists(t, dest)
+	})
+
+	t.Run("custom destination", func(t *testing.T) {
+		dest := &recordingDestination{}
+		require.NoError(t, cli.DownloadAssetToDestination(ctx, "scene.tif", dest, nil))
+		assert.True(t, dest.committed)
+		assert.False(t, dest.aborted)
+		assert.Equal(t, "tiff-bytes", dest.String())
+
+		dest = &recordingDestination{}
+		require.Error(t, cli.DownloadAssetToDestination(ctx, "truncated.tif", dest, nil))
+		assert.True(t, dest.aborted)
+		assert.False(t, dest.committed)
+
+		dest = &recordingDestination{}
+		require.Error(t, cli.DownloadAssetToDestination(ctx, "missing.tif", dest, nil))
+		assert.False(t, dest.opened, "nothing is opened when the fetch fails")
+	})
+}
+
+// recordingDestination records which Destination methods were called.
+type recordingDestination struct {
+	bytes.Buffer
+	opened, committed, aborted bool
+}
+
+func (d *recordingDestination) Open(context.Context, int64) (io.Writer, error) {
+	d.opened = true
+	return &d.Buffer, nil
+}
+
+func (d *recordingDestination) Commit() error { d.committed = true; return nil }
+func (d *recordingDestination) Abort() error  { d.aborted = true; return nil }
@@ -0,0 +1,138 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Sentinel errors matched by *APIError and *StatusError, for use with
+// errors.Is.
+var (
+	// ErrNotFound reports a 404 response.
+	ErrNotFound = errors.New("not found")
+	// ErrUnauthorized reports a 401 or 403 response: credentials are
+	// missing, invalid or insufficient.
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrConflict reports a 409 response, e.g. creating an item whose ID
+	// is already taken.
+	ErrConflict = errors.New("conflict")
+	// ErrPreconditionFailed reports a 412 response: the If-Match ETag no
+	// longer matches the stored document.
+	ErrPreconditionFailed = errors.New("precondition failed")
+	// ErrValidation reports a 400 or 422 response: the server rejected the
+	// document or patch as invalid.
+	ErrValidation = errors.New("validation failed")
+)
+
+// APIError is returned when a transaction or aggregation request fails with
+// an error status. It matches ErrNotFound, ErrUnauthorized, ErrConflict,
+// ErrPreconditionFailed or ErrValidation according to StatusCode.
+type APIError struct {
+	Method     string
+	URL        string
+	StatusCode int
+	// Code is the error code reported by the server, if any (e.g.
+	// "ConflictError" from stac-fastapi).
+	Code string
+	// Description is the server's explanation, or the raw response body
+	// when it is not a JSON error document.
+	Description string
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("%s %s: status %d", e.Method, e.URL, e.StatusCode)
+	if e.Code != "" {
+		msg += " " + e.Code
+	}
+	if e.Description != "" {
+		msg += ": " + e.Description
+	}
+	return msg
+}
+
+// Unwrap returns the sentinel error for the status code, if any.
+func (e *APIError) Unwrap() error {
+	return statusSentinel(e.StatusCode)
+}
+
+// StatusError reports a response with an unexpected HTTP status to a read
+// request. Like APIError it matches the sentinel error for StatusCode.
+type StatusError struct {
+	StatusCode int
+	URL        string
+}
+
+func (e *StatusError) Error() string {
+	if e.URL == "" {
+		return fmt.Sprintf("unexpected status code %d", e.StatusCode)
+	}
+	return fmt.Sprintf("unexpected status code %d for %s", e.StatusCode, e.URL)
+}
+
+// Unwrap returns the sentinel error for the status code, if any.
+func (e *StatusError) Unwrap() error {
+	return statusSentinel(e.StatusCode)
+}
+
+// newStatusError builds a StatusError for resp.
+func newStatusError(resp *http.Response) *StatusError {
+	e := &StatusError{StatusCode: resp.StatusCode}
+	if resp.Request != nil && resp.Request.URL != nil {
+		e.URL = resp.Request.URL.String()
+	}
+	return e
+}
+
+func statusSentinel(code int) error {
+	switch code {
+	case http.StatusNotFound:
+		return ErrNotFound
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return ErrUnauthorized
+	case http.StatusConflict:
+		return ErrConflict
+	case http.StatusPreconditionFailed:
+		return ErrPreconditionFailed
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return ErrValidation
+	}
+	return nil
+}
+
+// maxErrorBody caps how much of a non-JSON error body APIError keeps.
+const maxErrorBody = 512
+
+// newAPIError builds an APIError from a failed response.
+func newAPIError(resp *http.Response) *APIError {
+	apiErr := &APIError{
+		Method:     resp.Request.Method,
+		URL:        resp.Request.URL.String(),
+		StatusCode: resp.StatusCode,
+	}
+
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	var doc struct {
+		Code        any    `json:"code"`
+		Description string `json:"description"`
+		Title       string `json:"title"`
+		Detail      string `json:"detail"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		apiErr.Description = strings.TrimSpace(string(data[:min(len(data), maxErrorBody)]))
+		return apiErr
+	}
+	if code, ok := doc.Code.(string); ok {
+		apiErr.Code = code
+	}
+	for _, d := range []string{doc.Description, doc.Detail, doc.Title} {
+		if d != "" {
+			apiErr.Description = d
+			break
+		}
+	}
+	return apiErr
+}
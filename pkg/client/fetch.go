@@ -0,0 +1,327 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// AssetFetcher opens asset hrefs for a particular URL scheme.
+//
+// Fetch returns the asset body and its size in bytes, or a negative size when
+// the size is unknown. The caller closes the body.
+type AssetFetcher interface {
+	Fetch(ctx context.Context, u *url.URL) (io.ReadCloser, int64, error)
+}
+
+// AssetFetcherFunc adapts an ordinary function to the AssetFetcher interface.
+type AssetFetcherFunc func(ctx context.Context, u *url.URL) (io.ReadCloser, int64, error)
+
+// Fetch calls f(ctx, u).
+func (f AssetFetcherFunc) Fetch(ctx context.Context, u *url.URL) (io.ReadCloser, int64, error) {
+	return f(ctx, u)
+}
+
+// RangeFetcher is implemented by fetchers that can read a byte range of an
+// asset. It backs OpenAsset; custom fetchers registered with
+// RegisterAssetFetcher may implement it to support random access.
+//
+// FetchRange returns a body holding at most length bytes starting at off,
+// together with the total size of the asset.
+type RangeFetcher interface {
+	FetchRange(ctx context.Context, u *url.URL, off, length int64) (io.ReadCloser, int64, error)
+}
+
+// RangeFetcherFunc adapts an ordinary function to the RangeFetcher interface.
+type RangeFetcherFunc func(ctx context.Context, u *url.URL, off, length int64) (io.ReadCloser, int64, error)
+
+// FetchRange calls f(ctx, u, off, length).
+func (f RangeFetcherFunc) FetchRange(ctx context.Context, u *url.URL, off, length int64) (io.ReadCloser, int64, error) {
+	return f(ctx, u, off, length)
+}
+
+// ErrRangeNotSupported is returned when the server holding an asset ignores
+// HTTP Range requests.
+var ErrRangeNotSupported = errors.New("server does not support range requests")
+
+// TokenFunc supplies a bearer token for cloud storage requests. It is called
+// once per request so implementations can refresh expiring tokens.
+type TokenFunc func(ctx context.Context) (string, error)
+
+// WithAssetFetcher registers a fetcher for a URL scheme. See RegisterAssetFetcher.
+func WithAssetFetcher(scheme string, f AssetFetcher) ClientOption {
+	return func(c *Client) { c.RegisterAssetFetcher(scheme, f) }
+}
+
+// RegisterAssetFetcher makes the client use f for asset hrefs with the given
+// URL scheme (e.g. "gs", "ftp", "myorg"). Registered fetchers take precedence
+// over the built-in http, https, s3, gs and az implementations. Passing a nil
+// fetcher removes a previous registration. It is safe to call concurrently
+// with downloads.
+func (c *Client) RegisterAssetFetcher(scheme string, f AssetFetcher) {
+	scheme = strings.ToLower(scheme)
+
+	c.fetchersMu.Lock()
+	defer c.fetchersMu.Unlock()
+
+	if f == nil {
+		delete(c.fetchers, scheme)
+		return
+	}
+	if c.fetchers == nil {
+		c.fetchers = make(map[string]AssetFetcher)
+	}
+	c.fetchers[scheme] = f
+}
+
+// resolveAssetURL parses an asset href, resolving relative hrefs against the
+// client's base URL.
+func (c *Client) resolveAssetURL(href string) (*url.URL, error) {
+	u, err := url.Parse(href)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse asset URL: %w", err)
+	}
+	if u.Scheme == "" {
+		u = c.baseURL.ResolveReference(u)
+	}
+	return u, nil
+}
+
+// assetFetcher returns the fetcher responsible for u.
+func (c *Client) assetFetcher(u *url.URL) (AssetFetcher, error) {
+	scheme := strings.ToLower(u.Scheme)
+
+	c.fetchersMu.RLock()
+	f, ok := c.fetchers[scheme]
+	c.fetchersMu.RUnlock()
+	if ok {
+		return f, nil
+	}
+
+	switch scheme {
+	case "http", "https":
+		if c.azureOptions != nil && isAzureBlobHost(u.Host) {
+			return AssetFetcherFunc(c.fetchAzureBlob), nil
+		}
+		return AssetFetcherFunc(c.fetchHTTP), nil
+	case "s3":
+		return AssetFetcherFunc(c.fetchS3), nil
+	case "gs":
+		return AssetFetcherFunc(c.fetchGCS), nil
+	case "az":
+		return AssetFetcherFunc(c.fetchAzureBlob), nil
+	default:
+		return nil, fmt.Errorf("unsupported URL scheme: %s", u.Scheme)
+	}
+}
+
+// rangeFetcher returns the range-capable fetcher responsible for u.
+func (c *Client) rangeFetcher(u *url.URL) (RangeFetcher, error) {
+	scheme := strings.ToLower(u.Scheme)
+
+	c.fetchersMu.RLock()
+	f, ok := c.fetchers[scheme]
+	c.fetchersMu.RUnlock()
+	if ok {
+		rf, ok := f.(RangeFetcher)
+		if !ok {
+			return nil, fmt.Errorf("fetcher for scheme %s does not support range requests", u.Scheme)
+		}
+		return rf, nil
+	}
+
+	switch scheme {
+	case "http", "https":
+		if c.azureOptions != nil && isAzureBlobHost(u.Host) {
+			return RangeFetcherFunc(c.fetchAzureBlobRange), nil
+		}
+		return RangeFetcherFunc(c.fetchHTTPRange), nil
+	case "s3":
+		return RangeFetcherFunc(c.fetchS3Range), nil
+	case "gs":
+		return RangeFetcherFunc(c.fetchGCSRange), nil
+	case "az":
+		return RangeFetcherFunc(c.fetchAzureBlobRange), nil
+	default:
+		return nil, fmt.Errorf("unsupported URL scheme: %s", u.Scheme)
+	}
+}
+
+// openAsset resolves and signs href, then opens it with the matching fetcher.
+func (c *Client) openAsset(ctx context.Context, href string) (io.ReadCloser, int64, error) {
+	u, err := c.resolveAssetURL(href)
+	if err != nil {
+		return nil, 0, err
+	}
+	if u, err = c.signAssetURL(ctx, u); err != nil {
+		return nil, 0, err
+	}
+	f, err := c.assetFetcher(u)
+	if err != nil {
+		return nil, 0, err
+	}
+	return f.Fetch(ctx, u)
+}
+
+// fetchHTTP retrieves an http(s) asset through the client's middleware
+// (e.g. authentication).
+func (c *Client) fetchHTTP(ctx context.Context, u *url.URL) (io.ReadCloser, int64, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error creating request for %s: %w", u, err)
+	}
+	if err := c.applyMiddleware(ctx, req); err != nil {
+		return nil, 0, err
+	}
+	resp, err := c.doAsset(req)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to download asset: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, 0, fmt.Errorf("failed to download asset: %w", newStatusError(resp))
+	}
+	return resp.Body, resp.ContentLength, nil
+}
+
+// fetchHTTPRange reads a byte range of an http(s) asset through the
+// client's middleware.
+func (c *Client) fetchHTTPRange(ctx context.Context, u *url.URL, off, length int64) (io.ReadCloser, int64, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error creating request for %s: %w", u, err)
+	}
+	req.Header.Set("Range", rangeHeader(off, length))
+	if err := c.applyMiddleware(ctx, req); err != nil {
+		return nil, 0, err
+	}
+
+	resp, err := c.doAsset(req)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read asset range: %w", err)
+	}
+	return rangeResponse(resp, off, length)
+}
+
+// rangeHeader formats an HTTP Range header value for length bytes at off.
+func rangeHeader(off, length int64) string {
+	return fmt.Sprintf("bytes=%d-%d", off, off+length-1)
+}
+
+// rangeResponse validates the response to a ranged GET and returns its body
+// and the total size of the resource. The response body is closed on error.
+func rangeResponse(resp *http.Response, off, length int64) (io.ReadCloser, int64, error) {
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+		size, ok := parseContentRange(resp.Header.Get("Content-Range"))
+		if !ok {
+			resp.Body.Close()
+			return nil, 0, fmt.Errorf("failed to read asset range: invalid Content-Range %q", resp.Header.Get("Content-Range"))
+		}
+		return resp.Body, size, nil
+	case http.StatusOK:
+		// Servers may answer with the full body when it fits in the range.
+		if off == 0 && resp.ContentLength >= 0 && resp.ContentLength <= length {
+			return resp.Body, resp.ContentLength, nil
+		}
+		resp.Body.Close()
+		return nil, 0, ErrRangeNotSupported
+	case http.StatusRequestedRangeNotSatisfiable:
+		resp.Body.Close()
+		if size, ok := parseContentRange(resp.Header.Get("Content-Range")); ok && size == 0 {
+			return io.NopCloser(strings.NewReader("")), 0, nil
+		}
+		return nil, 0, fmt.Errorf("failed to read asset range: range %d-%d not satisfiable", off, off+length-1)
+	default:
+		resp.Body.Close()
+		return nil, 0, fmt.Errorf("failed to read asset range: %w", newStatusError(resp))
+	}
+}
+
+// parseContentRange extracts the complete length from a Content-Range header
+// such as "bytes 0-1023/146515" or "bytes */0".
+func parseContentRange(v string) (int64, bool) {
+	_, total, ok := strings.Cut(v, "/")
+	if !ok || total == "*" {
+		return 0, false
+	}
+	size, err := strconv.ParseInt(strings.TrimSpace(total), 10, 64)
+	if err != nil || size < 0 {
+		return 0, false
+	}
+	return size, true
+}
+
+// getWithToken performs an unauthenticated-by-middleware GET against a cloud
+// storage endpoint. Middleware is deliberately skipped: it carries STAC API
+// credentials that must not leak to third-party storage services.
+func (c *Client) getWithToken(ctx context.Context, rawURL string, token TokenFunc, header http.Header) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request for %s: %w", rawURL, err)
+	}
+	for k, vs := range header {
+		for _, v := range vs {
+			req.Header.Add(k, v)
+		}
+	}
+	if token != nil {
+		tok, err := token(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to obtain access token: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+tok)
+	}
+	return c.doAsset(req)
+}
+
+// errResponseTimeout reports that an asset request hit the client's timeout
+// before the response headers arrived.
+var errResponseTimeout = errors.New("timeout awaiting response headers")
+
+// doAsset executes an asset request. The client's Timeout bounds the wait
+// for the response headers only, not the reading of the body, so that long,
+// throttled or paused transfers are not cut off; ctx still cancels them.
+func (c *Client) doAsset(req *http.Request) (*http.Response, error) {
+	timeout := c.httpClient.Timeout
+	if timeout <= 0 {
+		return c.httpClient.Do(req)
+	}
+	hc := *c.httpClient
+	hc.Timeout = 0
+
+	ctx, cancel := context.WithCancelCause(req.Context())
+	timer := time.AfterFunc(timeout, func() { cancel(errResponseTimeout) })
+	resp, err := hc.Do(req.WithContext(ctx))
+	if !timer.Stop() && err == nil {
+		resp.Body.Close()
+		err = errResponseTimeout
+	}
+	if err != nil {
+		cancel(nil)
+		if errors.Is(context.Cause(ctx), errResponseTimeout) {
+			return nil, fmt.Errorf("%s %s: %w after %s", req.Method, req.URL, errResponseTimeout, timeout)
+		}
+		return nil, err
+	}
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: func() { cancel(nil) }}
+	return resp, nil
+}
+
+// cancelOnClose releases a request's context when its body is closed.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel func()
+}
+
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
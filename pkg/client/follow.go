@@ -0,0 +1,172 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/robert-malhotra/go-stac-client/pkg/stac"
+)
+
+// Follow fetches the document a link points to and decodes it as a
+// *stac.Item, *stac.ItemCollection, *stac.Collection or *stac.Catalog,
+// chosen by the document's "type" member and, for documents without one,
+// the link's relation type.
+//
+// Relative hrefs are resolved against the client's base URL; resolve links
+// taken from static catalogs with Link.ResolveHref first. The href may use
+// any scheme Walk accepts, and http(s) requests go through the client's
+// middleware. file URLs are only followed when the client's base URL is
+// itself a file URL, so an API cannot make the client read local files.
+func (c *Client) Follow(ctx context.Context, link *stac.Link) (any, error) {
+	if link == nil || link.Href == "" {
+		return nil, fmt.Errorf("link has no href")
+	}
+	if !isJSONMediaType(link.Type) {
+		return nil, fmt.Errorf("cannot follow %s link to %s document", link.Rel, link.Type)
+	}
+
+	ref, err := url.Parse(link.Href)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s link URL '%s': %w", link.Rel, link.Href, err)
+	}
+	u := c.baseURL.ResolveReference(ref)
+	if err := checkFileLink(c.baseURL, u); err != nil {
+		return nil, err
+	}
+
+	doc, err := c.loadDocument(ctx, u, link.Rel)
+	if err != nil {
+		return nil, fmt.Errorf("failed to follow %s link to %s: %w", link.Rel, u, err)
+	}
+	return doc, nil
+}
+
+// loadDocument fetches and decodes the STAC document at u. rel is the
+// relation type of the link that led there, if any.
+func (c *Client) loadDocument(ctx context.Context, u *url.URL, rel string) (any, error) {
+	rc, err := c.openDocument(ctx, u)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	r, err := c.migrateBody(rc)
+	if err != nil {
+		return nil, err
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return c.decodeDocument(ctx, data, rel)
+}
+
+// decodeDocument decodes data into the STAC type named by its "type"
+// member. Untyped documents (STAC before 1.0) are told apart by the link
+// relation and by their members.
+func (c *Client) decodeDocument(ctx context.Context, data []byte, rel string) (any, error) {
+	var head struct {
+		Type       string          `json:"type"`
+		Extent     json.RawMessage `json:"extent"`
+		Properties json.RawMessage `json:"properties"`
+		Features   json.RawMessage `json:"features"`
+	}
+	if err := json.Unmarshal(data, &head); err != nil {
+		return nil, err
+	}
+
+	kind := head.Type
+	if kind == "" {
+		switch {
+		case head.Features != nil:
+			kind = "FeatureCollection"
+		case rel == stac.RelItem || head.Properties != nil:
+			kind = "Feature"
+		case rel == stac.RelCollection || head.Extent != nil:
+			kind = "Collection"
+		default:
+			kind = "Catalog"
+		}
+	}
+
+	switch kind {
+	case "Feature":
+		var item stac.Item
+		if err := json.Unmarshal(data, &item); err != nil {
+			return nil, err
+		}
+		if err := c.prepareItem(ctx, &item); err != nil {
+			return nil, err
+		}
+		return &item, nil
+	case "FeatureCollection":
+		var ic stac.ItemCollection
+		if err := json.Unmarshal(data, &ic); err != nil {
+			return nil, err
+		}
+		for _, item := range ic.Features {
+			if err := c.prepareItem(ctx, item); err != nil {
+				return nil, err
+			}
+		}
+		return &ic, nil
+	case "Collection":
+		var col stac.Collection
+		if err := json.Unmarshal(data, &col); err != nil {
+			return nil, err
+		}
+		return &col, nil
+	case "Catalog":
+		var cat stac.Catalog
+		if err := json.Unmarshal(data, &cat); err != nil {
+			return nil, err
+		}
+		return &cat, nil
+	default:
+		return nil, fmt.Errorf("unexpected document type %q", head.Type)
+	}
+}
+
+// openDocument opens a STAC document, reading file URLs from disk and
+// everything else through the client's asset fetchers.
+func (c *Client) openDocument(ctx context.Context, u *url.URL) (io.ReadCloser, error) {
+	if u.Scheme == "file" {
+		return os.Open(filepath.FromSlash(u.Path))
+	}
+	f, err := c.assetFetcher(u)
+	if err != nil {
+		return nil, err
+	}
+	rc, _, err := f.Fetch(ctx, u)
+	return rc, err
+}
+
+// checkFileLink refuses a file URL u linked from a document at parent
+// that is not itself local, so remote documents cannot make the client
+// read local files.
+func checkFileLink(parent, u *url.URL) error {
+	if u.Scheme == "file" && parent.Scheme != "file" {
+		return fmt.Errorf("refusing to read local file %s linked from %s", u, parent)
+	}
+	return nil
+}
+
+// isJSONMediaType reports whether a link type may hold a STAC document.
+// Links without a type are assumed to.
+func isJSONMediaType(typ string) bool {
+	if typ == "" {
+		return true
+	}
+	mt, _, err := mime.ParseMediaType(typ)
+	if err != nil {
+		return false
+	}
+	return mt == "application/json" || strings.HasSuffix(mt, "+json")
+}
@@ -0,0 +1,83 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/robert-malhotra/go-stac-client/pkg/stac"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_Follow(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		doc, ok := staticCatalog[strings.TrimPrefix(r.URL.Path, "/static/")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(doc))
+	}))
+	defer server.Close()
+
+	c, err := NewClient(server.URL + "/static/")
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	t.Run("decodes by type", func(t *testing.T) {
+		doc, err := c.Follow(ctx, &stac.Link{Rel: "root", Href: "catalog.json"})
+		require.NoError(t, err)
+		cat, ok := doc.(*stac.Catalog)
+		require.True(t, ok, "got %T", doc)
+		assert.Equal(t, "root", cat.Id)
+
+		doc, err = c.Follow(ctx, &stac.Link{Rel: "child", Href: server.URL + "/static/sentinel-2/collection.json"})
+		require.NoError(t, err)
+		col, ok := doc.(*stac.Collection)
+		require.True(t, ok, "got %T", doc)
+		assert.Equal(t, "sentinel-2", col.Id)
+
+		// Links inside a static catalog are relative to the holding document.
+		href, err := col.LinksByRel(stac.RelItem)[0].ResolveHref(server.URL + "/static/sentinel-2/collection.json")
+		require.NoError(t, err)
+		doc, err = c.Follow(ctx, &stac.Link{Rel: "item", Href: href, Type: "application/geo+json"})
+		require.NoError(t, err)
+		item, ok := doc.(*stac.Item)
+		require.True(t, ok, "got %T", doc)
+		assert.Equal(t, "a", item.Id)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := c.Follow(ctx, &stac.Link{Rel: "alternate", Href: "a.html", Type: "text/html"})
+		assert.Error(t, err)
+
+		_, err = c.Follow(ctx, &stac.Link{Rel: "child", Href: "missing.json"})
+		assert.Error(t, err)
+
+		_, err = c.Follow(ctx, &stac.Link{Rel: "child"})
+		assert.Error(t, err)
+
+		_, err = c.Follow(ctx, &stac.Link{Rel: "child", Href: "file:///etc/catalog.json"})
+		assert.ErrorContains(t, err, "refusing to read local file")
+	})
+}
+
+func TestClient_FollowItemCollection(t *testing.T) {
+	page := fixture(t, "items_page2.json")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(page)
+	}))
+	defer server.Close()
+
+	c, err := NewClient(server.URL)
+	require.NoError(t, err)
+
+	doc, err := c.Follow(context.Background(), &stac.Link{Rel: "items", Href: "/collections/c/items", Type: "application/geo+json"})
+	require.NoError(t, err)
+	ic, ok := doc.(*stac.ItemCollection)
+	require.True(t, ok, "got %T", doc)
+	assert.NotEmpty(t, ic.Features)
+}
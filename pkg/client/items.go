@@ -13,32 +13,46 @@ import (
 
 // GetItem fetches an individual item from a collection.
 func (c *Client) GetItem(ctx context.Context, collectionID, itemID string) (*stac.Item, error) {
+	item, _, err := c.GetItemWithETag(ctx, collectionID, itemID)
+	return item, err
+}
+
+// GetItemWithETag fetches an item together with its ETag, for use with
+// IfMatch. The ETag is empty when the server does not send one.
+func (c *Client) GetItemWithETag(ctx context.Context, collectionID, itemID string) (*stac.Item, string, error) {
 	if collectionID == "" {
-		return nil, fmt.Errorf("collection ID cannot be empty")
+		return nil, "", fmt.Errorf("collection ID cannot be empty")
 	}
 	if itemID == "" {
-		return nil, fmt.Errorf("item ID cannot be empty")
+		return nil, "", fmt.Errorf("item ID cannot be empty")
 	}
 
 	u := c.baseURL.JoinPath("collections", collectionID, "items", itemID)
 
 	resp, err := c.doRequest(ctx, http.MethodGet, u.String(), nil)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 	defer resp.Body.Close()
 
 	switch resp.StatusCode {
 	case http.StatusOK:
+		body, err := c.migrateBody(resp.Body)
+		if err != nil {
+			return nil, "", fmt.Errorf("error decoding response from %s: %w", u, err)
+		}
 		var item stac.Item
-		if err := json.NewDecoder(resp.Body).Decode(&item); err != nil {
-			return nil, fmt.Errorf("error decoding response from %s: %w", u, err)
+		if err := json.NewDecoder(body).Decode(&item); err != nil {
+			return nil, "", fmt.Errorf("error decoding response from %s: %w", u, err)
+		}
+		if err := c.prepareItem(ctx, &item); err != nil {
+			return nil, "", err
 		}
-		return &item, nil
+		return &item, resp.Header.Get("ETag"), nil
 	case http.StatusNotFound:
-		return nil, fmt.Errorf("item not found: %s", itemID)
+		return nil, "", fmt.Errorf("item not found: %s: %w", itemID, newStatusError(resp))
 	default:
-		return nil, fmt.Errorf("unexpected status code %d for %s", resp.StatusCode, u)
+		return nil, "", newStatusError(resp)
 	}
 }
 
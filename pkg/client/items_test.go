@@ -156,6 +156,7 @@ func TestClient_GetItem(t *testing.T) {
 		_, err := cli.GetItem(context.Background(), "dummy", "missing")
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "item not found")
+		assert.ErrorIs(t, err, ErrNotFound)
 	})
 }
 
@@ -222,3 +223,59 @@ func TestClient_GetItems(t *testing.T) {
 	})
 
 }
+
+func TestClient_WithMigration(t *testing.T) {
+	const oldItem = `{
+		"stac_version": "0.9.0",
+		"stac_extensions": ["eo"],
+		"id": "old",
+		"geometry": null,
+		"properties": {"datetime": "2020-01-01T00:00:00Z", "eo:epsg": 32633},
+		"links": [],
+		"assets": {}
+	}`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/collections/c/items/old":
+			w.Write([]byte(oldItem))
+		case "/collections/c/items":
+			w.Write([]byte(`{"type": "FeatureCollection", "features": [` + oldItem + `], "links": []}`))
+		case "/collections/c":
+			w.Write([]byte(`{"stac_version": "0.8.0", "id": "c", "description": "d", "license": "MIT",
+				"extent": {"spatial": [0, 0, 1, 1], "temporal": ["2020-01-01T00:00:00Z", null]}, "links": []}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	cli, err := NewClient(srv.URL, WithMigration())
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	item, err := cli.GetItem(ctx, "c", "old")
+	require.NoError(t, err)
+	assert.Equal(t, stac.LatestVersion, item.Version)
+	assert.Equal(t, "Feature", item.Type)
+	assert.Equal(t, "EPSG:32633", item.Properties["proj:code"])
+
+	items, err := collect(cli.GetItems(ctx, "c"))
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, "EPSG:32633", items[0].Properties["proj:code"])
+
+	col, err := cli.GetCollection(ctx, "c")
+	require.NoError(t, err)
+	assert.Equal(t, stac.LatestVersion, col.Version)
+	assert.Equal(t, [][]float64{{0, 0, 1, 1}}, col.Extent.Spatial.Bbox)
+
+	t.Run("disabled by default", func(t *testing.T) {
+		plain, err := NewClient(srv.URL)
+		require.NoError(t, err)
+		item, err := plain.GetItem(ctx, "c", "old")
+		require.NoError(t, err)
+		assert.Equal(t, "0.9.0", item.Version)
+		assert.Contains(t, item.Properties, "eo:epsg")
+	})
+}
@@ -0,0 +1,70 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/robert-malhotra/go-stac-client/pkg/stac"
+)
+
+// WithMigration upgrades Items and Collections from older STAC versions to
+// stac.LatestVersion as responses are decoded, using stac.Migrate. It applies
+// to single documents and to the documents in any top-level array of a page,
+// so custom PageDecoders see migrated JSON too.
+func WithMigration() ClientOption {
+	return func(c *Client) { c.migrate = true }
+}
+
+// migrateBody returns r unchanged unless migration is enabled, in which case
+// it returns the migrated response body.
+func (c *Client) migrateBody(r io.Reader) (io.Reader, error) {
+	if !c.migrate {
+		return r, nil
+	}
+
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+	var doc map[string]any
+	if err := dec.Decode(&doc); err != nil {
+		return nil, err
+	}
+
+	if isSTACDocument(doc) {
+		if err := stac.Migrate(doc); err != nil {
+			return nil, fmt.Errorf("failed to migrate document: %w", err)
+		}
+	} else {
+		for key, v := range doc {
+			list, _ := v.([]any)
+			for i, e := range list {
+				obj, ok := e.(map[string]any)
+				if !ok || !isSTACDocument(obj) {
+					continue
+				}
+				if err := stac.Migrate(obj); err != nil {
+					return nil, fmt.Errorf("failed to migrate %s[%d]: %w", key, i, err)
+				}
+			}
+		}
+	}
+
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	return bytes.NewReader(data), nil
+}
+
+// isSTACDocument reports whether obj looks like an Item, Collection or
+// Catalog rather than a page wrapping them.
+func isSTACDocument(obj map[string]any) bool {
+	if _, ok := obj["stac_version"]; ok {
+		_, isPage := obj["features"]
+		_, hasCollections := obj["collections"]
+		return !isPage && !hasCollections
+	}
+	t, _ := obj["type"].(string)
+	return t == "Feature" || t == "Collection"
+}
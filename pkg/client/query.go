@@ -0,0 +1,331 @@
+package client
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/planetlabs/go-ogc/filter"
+)
+
+// Query extension operators.
+const (
+	QueryEq         = "eq"
+	QueryNeq        = "neq"
+	QueryLt         = "lt"
+	QueryLte        = "lte"
+	QueryGt         = "gt"
+	QueryGte        = "gte"
+	QueryStartsWith = "startsWith"
+	QueryEndsWith   = "endsWith"
+	QueryContains   = "contains"
+	QueryIn         = "in"
+)
+
+// QueryBuilder builds an expression for the legacy Query extension
+// (SearchParams.Query), mapping property names to operator/value pairs.
+// Conditions are ANDed; setting the same operator on a property twice keeps
+// the last value.
+//
+// Example:
+//
+//	query := client.NewQueryBuilder().
+//	    Lt("eo:cloud_cover", 10).
+//	    In("platform", "sentinel-2a", "sentinel-2b").
+//	    Build()
+type QueryBuilder struct {
+	query map[string]any
+}
+
+// NewQueryBuilder creates an empty QueryBuilder.
+func NewQueryBuilder() *QueryBuilder {
+	return &QueryBuilder{query: make(map[string]any)}
+}
+
+// Eq requires property to equal value.
+func (b *QueryBuilder) Eq(property string, value any) *QueryBuilder {
+	return b.op(property, QueryEq, value)
+}
+
+// Neq requires property to differ from value.
+func (b *QueryBuilder) Neq(property string, value any) *QueryBuilder {
+	return b.op(property, QueryNeq, value)
+}
+
+// Lt requires property to be less than value.
+func (b *QueryBuilder) Lt(property string, value any) *QueryBuilder {
+	return b.op(property, QueryLt, value)
+}
+
+// Lte requires property to be less than or equal to value.
+func (b *QueryBuilder) Lte(property string, value any) *QueryBuilder {
+	return b.op(property, QueryLte, value)
+}
+
+// Gt requires property to be greater than value.
+func (b *QueryBuilder) Gt(property string, value any) *QueryBuilder {
+	return b.op(property, QueryGt, value)
+}
+
+// Gte requires property to be greater than or equal to value.
+func (b *QueryBuilder) Gte(property string, value any) *QueryBuilder {
+	return b.op(property, QueryGte, value)
+}
+
+// StartsWith requires a string property to start with prefix.
+func (b *QueryBuilder) StartsWith(property, prefix string) *QueryBuilder {
+	return b.op(property, QueryStartsWith, prefix)
+}
+
+// EndsWith requires a string property to end with suffix.
+func (b *QueryBuilder) EndsWith(property, suffix string) *QueryBuilder {
+	return b.op(property, QueryEndsWith, suffix)
+}
+
+// Contains requires a string property to contain substr.
+func (b *QueryBuilder) Contains(property, substr string) *QueryBuilder {
+	return b.op(property, QueryContains, substr)
+}
+
+// In requires property to equal one of values.
+func (b *QueryBuilder) In(property string, values ...any) *QueryBuilder {
+	return b.op(property, QueryIn, values)
+}
+
+func (b *QueryBuilder) op(property, op string, value any) *QueryBuilder {
+	ops, _ := b.query[property].(map[string]any)
+	if ops == nil {
+		ops = make(map[string]any)
+		b.query[property] = ops
+	}
+	ops[op] = value
+	return b
+}
+
+// Build returns the query for SearchParams.Query, or nil when empty.
+func (b *QueryBuilder) Build() map[string]any {
+	if len(b.query) == 0 {
+		return nil
+	}
+	return b.query
+}
+
+// ToCQL2 converts the query to an equivalent CQL2 filter. See QueryToCQL2.
+func (b *QueryBuilder) ToCQL2() (*Filter, error) {
+	return QueryToCQL2(b.Build())
+}
+
+// QueryToCQL2 converts a Query extension expression into an equivalent CQL2
+// filter, for servers that implement the Filter extension but not the Query
+// extension. startsWith, endsWith and contains become LIKE patterns, and
+// string values that are RFC 3339 timestamps become CQL2 timestamps. It
+// returns nil for an empty query.
+func QueryToCQL2(query map[string]any) (*Filter, error) {
+	var exprs []filter.BooleanExpression
+	for _, property := range slices.Sorted(maps.Keys(query)) {
+		ops, ok := query[property].(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("query for %s must be an object of operators", property)
+		}
+		for _, op := range slices.Sorted(maps.Keys(ops)) {
+			expr, err := queryComparison(property, op, ops[op])
+			if err != nil {
+				return nil, fmt.Errorf("query for %s: %w", property, err)
+			}
+			exprs = append(exprs, expr)
+		}
+	}
+
+	switch len(exprs) {
+	case 0:
+		return nil, nil
+	case 1:
+		return &Filter{Expression: exprs[0]}, nil
+	}
+	return &Filter{Expression: And(exprs...)}, nil
+}
+
+// QueryAsFilter returns a copy of params with its Query converted to CQL2
+// and ANDed into Filter, for servers that only support the Filter extension.
+func (p SearchParams) QueryAsFilter() (SearchParams, error) {
+	converted, err := QueryToCQL2(p.Query)
+	if err != nil || converted == nil {
+		return p, err
+	}
+
+	p.Query = nil
+	if p.Filter == nil {
+		p.Filter = converted
+	} else {
+		p.Filter = &Filter{Expression: And(p.Filter.Expression, converted.Expression)}
+	}
+	return p, nil
+}
+
+func queryComparison(property, op string, value any) (filter.BooleanExpression, error) {
+	prop := Property(property)
+
+	switch op {
+	case QueryStartsWith, QueryEndsWith, QueryContains:
+		s, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("%s needs a string, got %T", op, value)
+		}
+		pattern := escapeLike(s)
+		switch op {
+		case QueryStartsWith:
+			pattern += "%"
+		case QueryEndsWith:
+			pattern = "%" + pattern
+		default:
+			pattern = "%" + pattern + "%"
+		}
+		return Like(prop, String(pattern)), nil
+
+	case QueryIn:
+		var values []any
+		switch v := value.(type) {
+		case []any:
+			values = v
+		case []string:
+			for _, s := range v {
+				values = append(values, s)
+			}
+		default:
+			return nil, fmt.Errorf("in needs an array, got %T", value)
+		}
+		list := make([]filter.ScalarExpression, len(values))
+		for i, v := range values {
+			lit, err := queryLiteral(v)
+			if err != nil {
+				return nil, err
+			}
+			list[i] = lit
+		}
+		return In(prop, list...), nil
+	}
+
+	compare := map[string]func(left, right filter.ScalarExpression) *filter.Comparison{
+		QueryEq: Eq, QueryNeq: Neq, QueryLt: Lt, QueryLte: Lte, QueryGt: Gt, QueryGte: Gte,
+	}[op]
+	if compare == nil {
+		return nil, fmt.Errorf("unsupported operator %q", op)
+	}
+	lit, err := queryLiteral(value)
+	if err != nil {
+		return nil, err
+	}
+	return compare(prop, lit), nil
+}
+
+// queryLiteral converts a Query extension value to a CQL2 literal.
+func queryLiteral(v any) (filter.ScalarExpression, error) {
+	switch v := v.(type) {
+	case string:
+		if _, err := time.Parse(time.RFC3339, v); err == nil {
+			return Timestamp(v), nil
+		}
+		return String(v), nil
+	case bool:
+		return Boolean(v), nil
+	case float64:
+		return Number(v), nil
+	case float32:
+		return Number(float64(v)), nil
+	case int:
+		return Number(float64(v)), nil
+	case int64:
+		return Number(float64(v)), nil
+	}
+	return nil, fmt.Errorf("unsupported value %v (%T)", v, v)
+}
+
+// escapeLike escapes the CQL2 LIKE wildcards in s.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
+
+// TextQuery is a free-text search expression for the q parameter of the
+// Free-text extension (SearchParams.Q and CollectionSearchParams.Q). Terms
+// are combined with AND and OR, grouped with parentheses, quoted as exact
+// phrases and prefixed with + (required) or - (excluded). Servers that only
+// implement basic free-text search treat the terms as a list matched with
+// OR; use Terms for those.
+//
+// Example:
+//
+//	q := client.TextAnd(client.Text("sentinel"), client.TextOr(client.Phrase("sea ice"), client.Text("glacier")))
+//	params.Q = q.String() // sentinel AND ("sea ice" OR glacier)
+type TextQuery struct {
+	expr     string
+	compound bool
+}
+
+// Text matches a single term. Terms containing spaces, quotes, commas or
+// parentheses are quoted as a phrase.
+func Text(term string) TextQuery {
+	if strings.ContainsAny(term, " \t\",()") || strings.EqualFold(term, "AND") || strings.EqualFold(term, "OR") {
+		return Phrase(term)
+	}
+	return TextQuery{expr: term}
+}
+
+// Phrase matches the exact phrase p.
+func Phrase(p string) TextQuery {
+	return TextQuery{expr: `"` + strings.ReplaceAll(p, `"`, `\"`) + `"`}
+}
+
+// TextAnd matches documents matching every query.
+func TextAnd(queries ...TextQuery) TextQuery {
+	return joinText(" AND ", queries)
+}
+
+// TextOr matches documents matching any query.
+func TextOr(queries ...TextQuery) TextQuery {
+	return joinText(" OR ", queries)
+}
+
+// Required marks q as required (+q).
+func Required(q TextQuery) TextQuery {
+	return TextQuery{expr: "+" + q.group()}
+}
+
+// Excluded excludes documents matching q (-q).
+func Excluded(q TextQuery) TextQuery {
+	return TextQuery{expr: "-" + q.group()}
+}
+
+// Terms is the basic free-text form: a comma-separated list of terms
+// matched with OR.
+func Terms(terms ...string) string {
+	return strings.Join(terms, ",")
+}
+
+// String returns the q parameter value.
+func (q TextQuery) String() string {
+	return q.expr
+}
+
+// group parenthesizes compound expressions when they are nested.
+func (q TextQuery) group() string {
+	if q.compound {
+		return "(" + q.expr + ")"
+	}
+	return q.expr
+}
+
+func joinText(sep string, queries []TextQuery) TextQuery {
+	switch len(queries) {
+	case 0:
+		return TextQuery{}
+	case 1:
+		return queries[0]
+	}
+	parts := make([]string, len(queries))
+	for i, q := range queries {
+		parts[i] = q.group()
+	}
+	return TextQuery{expr: strings.Join(parts, sep), compound: true}
+}
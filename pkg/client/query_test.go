@@ -0,0 +1,135 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryBuilder(t *testing.T) {
+	b := NewQueryBuilder().
+		Lt("eo:cloud_cover", 10).
+		Gte("datetime", "2024-01-01T00:00:00Z").
+		In("platform", "sentinel-2a", "sentinel-2b").
+		StartsWith("s2:mgrs_tile", "31U").
+		Contains("title", "50%_off")
+
+	query := b.Build()
+	assert.Equal(t, map[string]any{"lt": 10}, query["eo:cloud_cover"])
+	assert.Equal(t, map[string]any{"in": []any{"sentinel-2a", "sentinel-2b"}}, query["platform"])
+
+	f, err := b.ToCQL2()
+	require.NoError(t, err)
+	data, err := json.Marshal(f)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"op":"and","args":[
+		{"op":">=","args":[{"property":"datetime"},{"timestamp":"2024-01-01T00:00:00Z"}]},
+		{"op":"<","args":[{"property":"eo:cloud_cover"},10]},
+		{"op":"in","args":[{"property":"platform"},["sentinel-2a","sentinel-2b"]]},
+		{"op":"like","args":[{"property":"s2:mgrs_tile"},"31U%"]},
+		{"op":"like","args":[{"property":"title"},"%50\\%\\_off%"]}
+	]}`, string(data))
+
+	t.Run("single condition", func(t *testing.T) {
+		f, err := NewQueryBuilder().Eq("constellation", "sentinel-2").ToCQL2()
+		require.NoError(t, err)
+		data, err := json.Marshal(f)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"op":"=","args":[{"property":"constellation"},"sentinel-2"]}`, string(data))
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		assert.Nil(t, NewQueryBuilder().Build())
+		f, err := NewQueryBuilder().ToCQL2()
+		require.NoError(t, err)
+		assert.Nil(t, f)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := QueryToCQL2(map[string]any{"eo:cloud_cover": 10})
+		assert.Error(t, err)
+		_, err = QueryToCQL2(map[string]any{"eo:cloud_cover": map[string]any{"between": []any{1, 2}}})
+		assert.ErrorContains(t, err, "unsupported operator")
+		_, err = QueryToCQL2(map[string]any{"title": map[string]any{"startsWith": 1}})
+		assert.Error(t, err)
+	})
+}
+
+func TestSearchParams_QueryAsFilter(t *testing.T) {
+	params := SearchParams{
+		Collections: []string{"sentinel-2-l2a"},
+		Query:       NewQueryBuilder().Lte("eo:cloud_cover", 20).Build(),
+		Filter:      NewFilterBuilder().Where(Eq(Property("platform"), String("sentinel-2a"))).Build(),
+	}
+
+	converted, err := params.QueryAsFilter()
+	require.NoError(t, err)
+	assert.Nil(t, converted.Query)
+	assert.NotNil(t, params.Query, "original params are unchanged")
+
+	data, err := json.Marshal(converted)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"collections":["sentinel-2-l2a"],"filter":{"op":"and","args":[
+		{"op":"=","args":[{"property":"platform"},"sentinel-2a"]},
+		{"op":"<=","args":[{"property":"eo:cloud_cover"},20]}
+	]}}`, string(data))
+}
+
+func TestTextQuery(t *testing.T) {
+	tests := []struct {
+		name  string
+		query TextQuery
+		want  string
+	}{
+		{"term", Text("sentinel"), "sentinel"},
+		{"term with spaces becomes phrase", Text("sea ice"), `"sea ice"`},
+		{"phrase escapes quotes", Phrase(`the "big" one`), `"the \"big\" one"`},
+		{"operator words are quoted", Text("or"), `"or"`},
+		{"and", TextAnd(Text("sentinel"), Text("radar")), "sentinel AND radar"},
+		{"nested groups", TextAnd(Text("sentinel"), TextOr(Phrase("sea ice"), Text("glacier"))), `sentinel AND ("sea ice" OR glacier)`},
+		{"required and excluded", TextAnd(Required(Text("ocean")), Excluded(TextOr(Text("land"), Text("urban")))), "+ocean AND -(land OR urban)"},
+		{"single element", TextOr(Text("landsat")), "landsat"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.query.String())
+		})
+	}
+	assert.Equal(t, "sentinel,landsat", Terms("sentinel", "landsat"))
+}
+
+func TestClient_SearchFreeTextAndFilter(t *testing.T) {
+	params := SearchParams{
+		Q:      TextAnd(Text("sentinel"), Phrase("sea ice")).String(),
+		Filter: NewFilterBuilder().Where(Lt(Property("eo:cloud_cover"), Number(10))).Build(),
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			assert.Equal(t, `sentinel AND "sea ice"`, r.URL.Query().Get("q"))
+			assert.Equal(t, "cql2-json", r.URL.Query().Get("filter-lang"))
+			assert.JSONEq(t, `{"op":"<","args":[{"property":"eo:cloud_cover"},10]}`, r.URL.Query().Get("filter"))
+		case http.MethodPost:
+			var body map[string]any
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, `sentinel AND "sea ice"`, body["q"])
+			assert.Equal(t, "<", body["filter"].(map[string]any)["op"])
+		}
+		w.Write([]byte(`{"type":"FeatureCollection","features":[],"links":[]}`))
+	}))
+	defer srv.Close()
+
+	c, err := NewClient(srv.URL)
+	require.NoError(t, err)
+
+	_, err = collect(c.SearchSimple(context.Background(), params))
+	require.NoError(t, err)
+	_, err = collect(c.SearchCQL2(context.Background(), params))
+	require.NoError(t, err)
+}
@@ -0,0 +1,426 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/robert-malhotra/go-stac-client/pkg/stac"
+	"github.com/robert-malhotra/go-stac-client/pkg/stac/ext/file"
+)
+
+// ErrQueueClosed is returned when enqueueing into a closed DownloadQueue.
+var ErrQueueClosed = errors.New("download queue is closed")
+
+// TransferState describes where a Transfer is in its lifecycle.
+type TransferState int
+
+const (
+	TransferQueued TransferState = iota
+	TransferActive
+	TransferPaused
+	TransferDone
+	TransferFailed
+	TransferCanceled
+)
+
+// String returns a lower-case name for the state.
+func (s TransferState) String() string {
+	switch s {
+	case TransferQueued:
+		return "queued"
+	case TransferActive:
+		return "active"
+	case TransferPaused:
+		return "paused"
+	case TransferDone:
+		return "done"
+	case TransferFailed:
+		return "failed"
+	case TransferCanceled:
+		return "canceled"
+	default:
+		return fmt.Sprintf("TransferState(%d)", int(s))
+	}
+}
+
+// Finished reports whether the state is terminal.
+func (s TransferState) Finished() bool {
+	return s >= TransferDone
+}
+
+// DownloadRequest describes an asset download submitted to a DownloadQueue.
+type DownloadRequest struct {
+	Href string
+	Dest Destination
+
+	// Priority orders requests; higher values start first.
+	Priority int
+
+	// Size is the expected size in bytes. Among requests of equal priority,
+	// smaller known sizes start first and unknown sizes (zero or negative)
+	// start last.
+	Size int64
+
+	// BandwidthLimit caps this transfer in bytes per second.
+	BandwidthLimit int64
+
+	// Progress, if set, is called as the transfer advances.
+	Progress ProgressFunc
+}
+
+// AssetDownloadRequest builds a request for a STAC asset. The size is taken
+// from the asset's file:size field, and thumbnail, overview and metadata
+// assets are given a higher priority than data assets.
+func AssetDownloadRequest(asset *stac.Asset, dest Destination) DownloadRequest {
+	req := DownloadRequest{Href: asset.Href, Dest: dest}
+
+	if size, ok := file.FromAsset(asset).Size(); ok {
+		req.Size = size
+	}
+
+	for _, role := range asset.Roles {
+		switch role {
+		case "thumbnail", "overview", "metadata":
+			req.Priority = 1
+		}
+	}
+	return req
+}
+
+// DownloadQueue runs asset downloads with bounded concurrency, starting the
+// highest priority and smallest requests first. Individual transfers can be
+// paused, resumed and canceled.
+type DownloadQueue struct {
+	client      *Client
+	ctx         context.Context
+	cancel      context.CancelFunc
+	concurrency int
+
+	mu        sync.Mutex
+	seq       uint64
+	transfers []*Transfer
+	pending   []*Transfer
+	active    int
+	closed    bool
+	wg        sync.WaitGroup
+}
+
+// NewDownloadQueue creates a queue that downloads with the client, running
+// at most concurrency transfers at a time (minimum 1). ctx bounds the
+// lifetime of every transfer.
+func (c *Client) NewDownloadQueue(ctx context.Context, concurrency int) *DownloadQueue {
+	ctx, cancel := context.WithCancel(ctx)
+	return &DownloadQueue{
+		client:      c,
+		ctx:         ctx,
+		cancel:      cancel,
+		concurrency: max(concurrency, 1),
+	}
+}
+
+// Enqueue adds a download to the queue and returns its Transfer.
+func (q *DownloadQueue) Enqueue(req DownloadRequest) (*Transfer, error) {
+	if req.Dest == nil {
+		return nil, fmt.Errorf("destination is nil")
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		return nil, ErrQueueClosed
+	}
+
+	q.seq++
+	t := &Transfer{
+		queue: q,
+		req:   req,
+		seq:   q.seq,
+		state: TransferQueued,
+		total: -1,
+		done:  make(chan struct{}),
+	}
+	if req.Size > 0 {
+		t.total = req.Size
+	}
+	q.transfers = append(q.transfers, t)
+	q.pending = append(q.pending, t)
+	q.dispatchLocked()
+	return t, nil
+}
+
+// Transfers returns the queue's transfers in the order they were enqueued.
+func (q *DownloadQueue) Transfers() []*Transfer {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return slices.Clone(q.transfers)
+}
+
+// ClearFinished forgets transfers that are done, failed or canceled.
+func (q *DownloadQueue) ClearFinished() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.transfers = slices.DeleteFunc(q.transfers, func(t *Transfer) bool {
+		return t.state.Finished()
+	})
+}
+
+// Wait blocks until every enqueued transfer has finished.
+func (q *DownloadQueue) Wait() {
+	q.mu.Lock()
+	transfers := slices.Clone(q.transfers)
+	q.mu.Unlock()
+
+	for _, t := range transfers {
+		<-t.done
+	}
+}
+
+// Close cancels all pending and active transfers and waits for them to stop.
+// Enqueue fails afterwards.
+func (q *DownloadQueue) Close() {
+	q.mu.Lock()
+	q.closed = true
+	for _, t := range q.pending {
+		t.finishLocked(TransferCanceled, context.Canceled)
+	}
+	q.pending = nil
+	q.mu.Unlock()
+
+	q.cancel()
+	q.wg.Wait()
+}
+
+// dispatchLocked starts pending transfers while there are free slots.
+func (q *DownloadQueue) dispatchLocked() {
+	for q.active < q.concurrency {
+		i := q.nextPendingLocked()
+		if i < 0 {
+			return
+		}
+		t := q.pending[i]
+		q.pending = slices.Delete(q.pending, i, i+1)
+		q.startLocked(t)
+	}
+}
+
+// nextPendingLocked returns the index of the pending transfer to start next,
+// or -1 if every pending transfer is paused.
+func (q *DownloadQueue) nextPendingLocked() int {
+	best := -1
+	for i, t := range q.pending {
+		if t.paused {
+			continue
+		}
+		if best < 0 || t.runsBefore(q.pending[best]) {
+			best = i
+		}
+	}
+	return best
+}
+
+func (q *DownloadQueue) startLocked(t *Transfer) {
+	ctx, cancel := context.WithCancel(q.ctx)
+	t.cancel = cancel
+	t.started = true
+	t.state = TransferActive
+	q.active++
+	q.wg.Add(1)
+
+	go func() {
+		defer q.wg.Done()
+		defer cancel()
+
+		err := q.client.DownloadAssetToDestination(ctx, t.req.Href, t.req.Dest, t.progress,
+			WithDownloadBandwidthLimit(t.req.BandwidthLimit),
+			withDownloadGate(t.waitResumed),
+		)
+
+		q.mu.Lock()
+		defer q.mu.Unlock()
+		q.active--
+		switch {
+		case err == nil:
+			t.finishLocked(TransferDone, nil)
+		case t.canceled || errors.Is(err, context.Canceled):
+			t.finishLocked(TransferCanceled, err)
+		default:
+			t.finishLocked(TransferFailed, err)
+		}
+		q.dispatchLocked()
+	}()
+}
+
+// Transfer is a download tracked by a DownloadQueue. Its methods are safe for
+// concurrent use.
+type Transfer struct {
+	queue *DownloadQueue
+	req   DownloadRequest
+	seq   uint64
+
+	// Guarded by queue.mu.
+	state             TransferState
+	downloaded, total int64
+	err               error
+	started           bool
+	paused            bool
+	canceled          bool
+	resumed           chan struct{}
+	cancel            context.CancelFunc
+
+	done chan struct{}
+}
+
+// Href returns the asset href being downloaded.
+func (t *Transfer) Href() string {
+	return t.req.Href
+}
+
+// Request returns the request the transfer was created from.
+func (t *Transfer) Request() DownloadRequest {
+	return t.req
+}
+
+// State returns the current state of the transfer.
+func (t *Transfer) State() TransferState {
+	t.queue.mu.Lock()
+	defer t.queue.mu.Unlock()
+	return t.state
+}
+
+// Progress returns the bytes downloaded so far and the expected total, which
+// is negative when unknown.
+func (t *Transfer) Progress() (downloaded, total int64) {
+	t.queue.mu.Lock()
+	defer t.queue.mu.Unlock()
+	return t.downloaded, t.total
+}
+
+// Err returns the error a failed or canceled transfer finished with.
+func (t *Transfer) Err() error {
+	t.queue.mu.Lock()
+	defer t.queue.mu.Unlock()
+	return t.err
+}
+
+// Done returns a channel that is closed when the transfer finishes.
+func (t *Transfer) Done() <-chan struct{} {
+	return t.done
+}
+
+// Wait blocks until the transfer finishes and returns its error.
+func (t *Transfer) Wait() error {
+	<-t.done
+	return t.Err()
+}
+
+// Pause suspends the transfer. A queued transfer is not started until it is
+// resumed; an active transfer stops reading but keeps its connection and its
+// slot in the queue.
+func (t *Transfer) Pause() {
+	q := t.queue
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if t.paused || t.state.Finished() {
+		return
+	}
+	t.paused = true
+	t.resumed = make(chan struct{})
+	t.state = TransferPaused
+}
+
+// Resume continues a paused transfer.
+func (t *Transfer) Resume() {
+	q := t.queue
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if !t.paused || t.state.Finished() {
+		return
+	}
+	t.paused = false
+	close(t.resumed)
+	if t.started {
+		t.state = TransferActive
+	} else {
+		t.state = TransferQueued
+		q.dispatchLocked()
+	}
+}
+
+// Cancel stops the transfer. The destination is aborted if the transfer had
+// started.
+func (t *Transfer) Cancel() {
+	q := t.queue
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if t.state.Finished() {
+		return
+	}
+	t.canceled = true
+	if t.started {
+		t.cancel()
+		return
+	}
+	q.pending = slices.DeleteFunc(q.pending, func(p *Transfer) bool { return p == t })
+	t.finishLocked(TransferCanceled, context.Canceled)
+}
+
+// runsBefore reports whether t should start before other.
+func (t *Transfer) runsBefore(other *Transfer) bool {
+	if t.req.Priority != other.req.Priority {
+		return t.req.Priority > other.req.Priority
+	}
+	a, b := t.req.Size, other.req.Size
+	switch {
+	case a > 0 && b > 0 && a != b:
+		return a < b
+	case a > 0 && b <= 0:
+		return true
+	case a <= 0 && b > 0:
+		return false
+	}
+	return t.seq < other.seq
+}
+
+func (t *Transfer) progress(downloaded, total int64) {
+	t.queue.mu.Lock()
+	t.downloaded, t.total = downloaded, total
+	t.queue.mu.Unlock()
+
+	if t.req.Progress != nil {
+		t.req.Progress(downloaded, total)
+	}
+}
+
+// waitResumed blocks while the transfer is paused.
+func (t *Transfer) waitResumed(ctx context.Context) error {
+	t.queue.mu.Lock()
+	paused, resumed := t.paused, t.resumed
+	t.queue.mu.Unlock()
+
+	if !paused {
+		return nil
+	}
+	select {
+	case <-resumed:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (t *Transfer) finishLocked(state TransferState, err error) {
+	t.state = state
+	t.err = err
+	if t.paused {
+		t.paused = false
+		close(t.resumed)
+	}
+	close(t.done)
+}
@@ -0,0 +1,215 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/robert-malhotra/go-stac-client/pkg/stac"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newQueueServer serves every path with its own name as the body. Requests
+// for /block wait until release is closed. Paths are recorded in order.
+func newQueueServer(t *testing.T) (srv *httptest.Server, order func() []string, release chan struct{}) {
+	t.Helper()
+	var (
+		mu   sync.Mutex
+		seen []string
+	)
+	release = make(chan struct{})
+
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		seen = append(seen, r.URL.Path)
+		mu.Unlock()
+		if r.URL.Path == "/block" {
+			<-release
+		}
+		w.Write([]byte(strings.TrimPrefix(r.URL.Path, "/")))
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), seen...)
+	}, release
+}
+
+func TestDownloadQueue_Order(t *testing.T) {
+	srv, order, release := newQueueServer(t)
+	cli, err := NewClient(srv.URL)
+	require.NoError(t, err)
+
+	q := cli.NewDownloadQueue(context.Background(), 1)
+	defer q.Close()
+
+	blocker, err := q.Enqueue(DownloadRequest{Href: "block", Dest: WriterDestination(&bytes.Buffer{})})
+	require.NoError(t, err)
+
+	for _, req := range []DownloadRequest{
+		{Href: "large", Size: 1 << 30},
+		{Href: "unknown"},
+		{Href: "small", Size: 1 << 10},
+		AssetDownloadRequest(&stac.Asset{
+			Href:             "thumb",
+			Roles:            []string{"thumbnail"},
+			AdditionalFields: map[string]any{"file:size": float64(1 << 20)},
+		}, nil),
+	} {
+		req.Dest = WriterDestination(&bytes.Buffer{})
+		_, err := q.Enqueue(req)
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, TransferActive, blocker.State())
+	close(release)
+	q.Wait()
+
+	assert.Equal(t, []string{"/block", "/thumb", "/small", "/large", "/unknown"}, order())
+	for _, tr := range q.Transfers() {
+		assert.Equal(t, TransferDone, tr.State(), tr.Href())
+	}
+}
+
+func TestDownloadQueue_PauseResumeCancel(t *testing.T) {
+	srv, order, release := newQueueServer(t)
+	cli, err := NewClient(srv.URL)
+	require.NoError(t, err)
+
+	q := cli.NewDownloadQueue(context.Background(), 1)
+	defer q.Close()
+
+	blocker, err := q.Enqueue(DownloadRequest{Href: "block", Dest: WriterDestination(&bytes.Buffer{})})
+	require.NoError(t, err)
+
+	var paused, canceled bytes.Buffer
+	pausedTr, err := q.Enqueue(DownloadRequest{Href: "paused", Dest: WriterDestination(&paused)})
+	require.NoError(t, err)
+	canceledTr, err := q.Enqueue(DownloadRequest{Href: "canceled", Dest: WriterDestination(&canceled)})
+	require.NoError(t, err)
+
+	pausedTr.Pause()
+	canceledTr.Cancel()
+	assert.Equal(t, TransferPaused, pausedTr.State())
+	assert.ErrorIs(t, canceledTr.Wait(), context.Canceled)
+	assert.Equal(t, TransferCanceled, canceledTr.State())
+
+	close(release)
+	require.NoError(t, blocker.Wait())
+
+	select {
+	case <-pausedTr.Done():
+		t.Fatal("paused transfer must not start")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	pausedTr.Resume()
+	require.NoError(t, pausedTr.Wait())
+	assert.Equal(t, "paused", paused.String())
+	assert.Empty(t, canceled.String())
+	assert.Equal(t, []string{"/block", "/paused"}, order())
+
+	q.ClearFinished()
+	assert.Empty(t, q.Transfers())
+}
+
+func TestDownloadQueue_PauseActive(t *testing.T) {
+	content := testContent(3000)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(content)
+	}))
+	defer srv.Close()
+
+	cli, err := NewClient(srv.URL)
+	require.NoError(t, err)
+	q := cli.NewDownloadQueue(context.Background(), 1)
+	defer q.Close()
+
+	var (
+		buf   bytes.Buffer
+		once  sync.Once
+		first = make(chan struct{})
+	)
+	tr, err := q.Enqueue(DownloadRequest{
+		Href:           "asset.bin",
+		Dest:           WriterDestination(&buf),
+		BandwidthLimit: 2_000, // 200-byte reads, the last 1000 bytes throttled
+		Progress: func(downloaded, total int64) {
+			if downloaded > 0 {
+				once.Do(func() { close(first) })
+			}
+		},
+	})
+	require.NoError(t, err)
+
+	<-first
+	tr.Pause()
+	assert.Equal(t, TransferPaused, tr.State())
+	time.Sleep(50 * time.Millisecond)
+	got, _ := tr.Progress()
+	assert.Less(t, got, int64(len(content)), "paused transfer stops reading")
+
+	tr.Resume()
+	require.NoError(t, tr.Wait())
+	assert.Equal(t, content, buf.Bytes())
+}
+
+func TestDownloadQueue_Closed(t *testing.T) {
+	cli, err := NewClient("https://stac.example.com")
+	require.NoError(t, err)
+
+	q := cli.NewDownloadQueue(context.Background(), 2)
+	q.Close()
+
+	_, err = q.Enqueue(DownloadRequest{Href: "x", Dest: WriterDestination(&bytes.Buffer{})})
+	assert.ErrorIs(t, err, ErrQueueClosed)
+}
+
+func TestDownloadQueue_PauseLongerThanTimeout(t *testing.T) {
+	content := testContent(3000)
+	more := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(content[:1000])
+		w.(http.Flusher).Flush()
+		<-more
+		w.Write(content[1000:])
+	}))
+	defer srv.Close()
+
+	cli, err := NewClient(srv.URL, WithTimeout(200*time.Millisecond))
+	require.NoError(t, err)
+	q := cli.NewDownloadQueue(context.Background(), 1)
+	defer q.Close()
+
+	var (
+		buf   bytes.Buffer
+		once  sync.Once
+		first = make(chan struct{})
+	)
+	tr, err := q.Enqueue(DownloadRequest{
+		Href: "asset.bin",
+		Dest: WriterDestination(&buf),
+		Progress: func(downloaded, total int64) {
+			if downloaded > 0 {
+				once.Do(func() { close(first) })
+			}
+		},
+	})
+	require.NoError(t, err)
+
+	<-first
+	tr.Pause()
+	time.Sleep(400 * time.Millisecond)
+	tr.Resume()
+	close(more)
+	require.NoError(t, tr.Wait(), "the timeout does not apply to reading the body")
+	assert.Equal(t, content, buf.Bytes())
+}
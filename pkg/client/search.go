@@ -26,6 +26,11 @@ type SearchParams struct {
 	Limit       int            `json:"limit,omitempty"`
 	SortBy      []SortField    `json:"sortby,omitempty"`
 	Fields      *FieldsFilter  `json:"fields,omitempty"`
+	// Q is a free-text query (Free-text extension); see TextQuery for the
+	// boolean and phrase syntax.
+	Q string `json:"q,omitempty"`
+	// Filter is a CQL2 expression (Filter extension), sent as CQL2-JSON.
+	Filter *Filter `json:"filter,omitempty"`
 }
 
 type SortField struct {
@@ -46,51 +51,7 @@ type Error struct {
 
 // SearchSimple performs a GET-based STAC search using URL query parameters.
 func (c *Client) SearchSimple(ctx context.Context, params SearchParams) iter.Seq2[*stac.Item, error] {
-	// Build query parameters
-	q := url.Values{}
-	for _, coll := range params.Collections {
-		q.Add("collections", coll)
-	}
-
-	var marshalErr error
-	if len(params.Bbox) >= 4 && len(params.Bbox)%2 == 0 {
-		coords := make([]string, len(params.Bbox))
-		for i, v := range params.Bbox {
-			coords[i] = fmt.Sprintf("%g", v)
-		}
-		q.Set("bbox", strings.Join(coords, ","))
-	}
-	if params.Datetime != "" {
-		q.Set("datetime", params.Datetime)
-	}
-	if params.Limit > 0 {
-		q.Set("limit", fmt.Sprintf("%d", params.Limit))
-	}
-	if len(params.SortBy) > 0 {
-		var parts []string
-		for _, s := range params.SortBy {
-			dir := strings.ToLower(s.Direction)
-			if dir != "asc" && dir != "desc" {
-				dir = "desc"
-			}
-			parts = append(parts, fmt.Sprintf("%s:%s", s.Field, dir))
-		}
-		q.Set("sortby", strings.Join(parts, ","))
-	}
-	if params.Query != nil {
-		if queryJSON, err := json.Marshal(params.Query); err == nil {
-			q.Set("query", string(queryJSON))
-		} else if marshalErr == nil {
-			marshalErr = fmt.Errorf("error encoding query parameters: %w", err)
-		}
-	}
-	if params.Fields != nil {
-		if fieldsJSON, err := json.Marshal(params.Fields); err == nil {
-			q.Set("fields", string(fieldsJSON))
-		} else if marshalErr == nil {
-			marshalErr = fmt.Errorf("error encoding fields parameters: %w", err)
-		}
-	}
+	q, marshalErr := searchValues(params)
 	if marshalErr != nil {
 		return func(y func(*stac.Item, error) bool) {
 			y(nil, marshalErr)
@@ -157,17 +118,21 @@ func (c *Client) SearchWithDecoder(ctx context.Context, params SearchParams, dec
 				defer resp.Body.Close()
 				var apiErr Error
 				if err := json.NewDecoder(resp.Body).Decode(&apiErr); err != nil {
-					yield(nil, fmt.Errorf("unexpected status %d on %s", resp.StatusCode, current))
+					yield(nil, newStatusError(resp))
 					return
 				}
 				if apiErr.Code == 0 {
 					apiErr.Code = resp.StatusCode
 				}
-				yield(nil, fmt.Errorf("search error: %s (code %d, type %s)", apiErr.Description, apiErr.Code, apiErr.Type))
+				yield(nil, fmt.Errorf("search error: %s (code %d, type %s): %w", apiErr.Description, apiErr.Code, apiErr.Type, newStatusError(resp)))
 				return
 			}
 
-			page, err := decoder(resp.Body)
+			pageBody, err := c.migrateBody(resp.Body)
+			var page *PageResponse[stac.Item]
+			if err == nil {
+				page, err = decoder(pageBody)
+			}
 			resp.Body.Close()
 			if err != nil {
 				yield(nil, fmt.Errorf("error decoding response from %s: %w", current, err))
@@ -175,6 +140,10 @@ func (c *Client) SearchWithDecoder(ctx context.Context, params SearchParams, dec
 			}
 
 			for _, it := range page.Items {
+				if err := c.prepareItem(ctx, it); err != nil {
+					yield(nil, err)
+					return
+				}
 				if !yield(it, nil) {
 					return
 				}
@@ -201,3 +170,63 @@ func (c *Client) SearchWithDecoder(ctx context.Context, params SearchParams, dec
 		}
 	}
 }
+
+// searchValues encodes search parameters as a GET query string.
+func searchValues(params SearchParams) (url.Values, error) {
+	q := url.Values{}
+	for _, coll := range params.Collections {
+		q.Add("collections", coll)
+	}
+
+	var marshalErr error
+	if len(params.Bbox) >= 4 && len(params.Bbox)%2 == 0 {
+		coords := make([]string, len(params.Bbox))
+		for i, v := range params.Bbox {
+			coords[i] = fmt.Sprintf("%g", v)
+		}
+		q.Set("bbox", strings.Join(coords, ","))
+	}
+	if params.Datetime != "" {
+		q.Set("datetime", params.Datetime)
+	}
+	if params.Limit > 0 {
+		q.Set("limit", fmt.Sprintf("%d", params.Limit))
+	}
+	if len(params.SortBy) > 0 {
+		var parts []string
+		for _, s := range params.SortBy {
+			dir := strings.ToLower(s.Direction)
+			if dir != "asc" && dir != "desc" {
+				dir = "desc"
+			}
+			parts = append(parts, fmt.Sprintf("%s:%s", s.Field, dir))
+		}
+		q.Set("sortby", strings.Join(parts, ","))
+	}
+	if params.Query != nil {
+		if queryJSON, err := json.Marshal(params.Query); err == nil {
+			q.Set("query", string(queryJSON))
+		} else if marshalErr == nil {
+			marshalErr = fmt.Errorf("error encoding query parameters: %w", err)
+		}
+	}
+	if params.Fields != nil {
+		if fieldsJSON, err := json.Marshal(params.Fields); err == nil {
+			q.Set("fields", string(fieldsJSON))
+		} else if marshalErr == nil {
+			marshalErr = fmt.Errorf("error encoding fields parameters: %w", err)
+		}
+	}
+	if params.Q != "" {
+		q.Set("q", params.Q)
+	}
+	if params.Filter != nil {
+		if filterJSON, err := json.Marshal(params.Filter); err == nil {
+			q.Set("filter", string(filterJSON))
+			q.Set("filter-lang", "cql2-json")
+		} else if marshalErr == nil {
+			marshalErr = fmt.Errorf("error encoding filter: %w", err)
+		}
+	}
+	return q, marshalErr
+}
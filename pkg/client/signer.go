@@ -0,0 +1,303 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/robert-malhotra/go-stac-client/pkg/stac"
+)
+
+// PlanetaryComputerTokenURL is the SAS token endpoint of Microsoft Planetary Computer.
+const PlanetaryComputerTokenURL = "https://planetarycomputer.microsoft.com/api/sas/v1/token"
+
+// Signer rewrites asset hrefs so they can be fetched, for example by
+// appending a short-lived SAS token. Hrefs that need no signing are returned
+// unchanged.
+type Signer interface {
+	SignHref(ctx context.Context, href string) (string, error)
+}
+
+// ItemSigner is implemented by signers that can sign all assets of an item at
+// once, typically because they obtain credentials per collection.
+type ItemSigner interface {
+	SignItem(ctx context.Context, item *stac.Item) error
+}
+
+// SignerFunc adapts an ordinary function to the Signer interface.
+type SignerFunc func(ctx context.Context, href string) (string, error)
+
+// SignHref calls f(ctx, href).
+func (f SignerFunc) SignHref(ctx context.Context, href string) (string, error) {
+	return f(ctx, href)
+}
+
+// WithSigner signs asset hrefs before they are downloaded.
+func WithSigner(s Signer) ClientOption {
+	return func(c *Client) { c.signer = s }
+}
+
+// WithItemSigning also signs the asset hrefs of every item returned by
+// GetItem, GetItems and the search methods, so callers can hand them to other
+// tools. It has no effect without WithSigner.
+func WithItemSigning() ClientOption {
+	return func(c *Client) { c.signItems = true }
+}
+
+// SignItem signs every asset href of item in place using signer. Signers
+// implementing ItemSigner are given the whole item.
+func SignItem(ctx context.Context, signer Signer, item *stac.Item) error {
+	if signer == nil || item == nil {
+		return nil
+	}
+	if is, ok := signer.(ItemSigner); ok {
+		return is.SignItem(ctx, item)
+	}
+	for key, asset := range item.Assets {
+		if asset == nil || asset.Href == "" {
+			continue
+		}
+		signed, err := signer.SignHref(ctx, asset.Href)
+		if err != nil {
+			return fmt.Errorf("error signing asset %s of item %s: %w", key, item.Id, err)
+		}
+		asset.Href = signed
+	}
+	return nil
+}
+
+// prepareValue applies per-item processing to values yielded by paginated
+// endpoints. Non-item values pass through untouched.
+func (c *Client) prepareValue(ctx context.Context, v any) error {
+	item, ok := v.(*stac.Item)
+	if !ok {
+		return nil
+	}
+	return c.prepareItem(ctx, item)
+}
+
+// prepareItem signs item's assets when item signing is enabled.
+func (c *Client) prepareItem(ctx context.Context, item *stac.Item) error {
+	if !c.signItems || c.signer == nil {
+		return nil
+	}
+	return SignItem(ctx, c.signer, item)
+}
+
+// signAssetURL applies the client's signer, if any, to a resolved asset URL.
+func (c *Client) signAssetURL(ctx context.Context, u *url.URL) (*url.URL, error) {
+	if c.signer == nil {
+		return u, nil
+	}
+	signed, err := c.signer.SignHref(ctx, u.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign asset URL: %w", err)
+	}
+	if signed == u.String() {
+		return u, nil
+	}
+	out, err := url.Parse(signed)
+	if err != nil {
+		return nil, fmt.Errorf("signer returned invalid URL %q: %w", signed, err)
+	}
+	return out, nil
+}
+
+// -----------------------------------------------------------------------------
+// SAS token signer
+// -----------------------------------------------------------------------------
+
+// SASTokenSigner signs Azure Blob Storage hrefs with SAS tokens obtained from
+// a token endpoint, in the style of Microsoft Planetary Computer.
+//
+// Tokens are requested from {tokenURL}/{collection} when signing whole items
+// and from {tokenURL}/{account}/{container} when signing bare hrefs. Each
+// token is cached until shortly before the expiry the endpoint reports.
+type SASTokenSigner struct {
+	tokenURL   string
+	httpClient *http.Client
+	header     http.Header
+	margin     time.Duration
+	now        func() time.Time
+
+	mu     sync.Mutex
+	tokens map[string]sasToken
+}
+
+type sasToken struct {
+	token  string
+	expiry time.Time
+}
+
+// SASSignerOption configures a SASTokenSigner.
+type SASSignerOption func(*SASTokenSigner)
+
+// WithSASHTTPClient sets the HTTP client used to call the token endpoint.
+func WithSASHTTPClient(client *http.Client) SASSignerOption {
+	return func(s *SASTokenSigner) { s.httpClient = client }
+}
+
+// WithSASHeader adds a header to token requests, e.g. the
+// Ocp-Apim-Subscription-Key used by Planetary Computer.
+func WithSASHeader(name, value string) SASSignerOption {
+	return func(s *SASTokenSigner) { s.header.Set(name, value) }
+}
+
+// WithSASExpiryMargin sets how long before expiry a cached token is renewed.
+func WithSASExpiryMargin(d time.Duration) SASSignerOption {
+	return func(s *SASTokenSigner) { s.margin = d }
+}
+
+// NewSASTokenSigner creates a signer backed by the given token endpoint, for
+// example PlanetaryComputerTokenURL.
+func NewSASTokenSigner(tokenURL string, opts ...SASSignerOption) *SASTokenSigner {
+	s := &SASTokenSigner{
+		tokenURL:   strings.TrimRight(tokenURL, "/"),
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		header:     make(http.Header),
+		margin:     time.Minute,
+		now:        time.Now,
+		tokens:     make(map[string]sasToken),
+	}
+	for _, o := range opts {
+		o(s)
+	}
+	return s
+}
+
+// SignHref appends a SAS token to Azure Blob Storage hrefs. Other hrefs and
+// hrefs that already carry a signature are returned unchanged.
+func (s *SASTokenSigner) SignHref(ctx context.Context, href string) (string, error) {
+	u, ok := parseUnsignedBlobURL(href)
+	if !ok {
+		return href, nil
+	}
+
+	account := strings.TrimSuffix(strings.ToLower(u.Hostname()), azureBlobHostSuffix)
+	container, _, _ := strings.Cut(strings.TrimPrefix(u.Path, "/"), "/")
+	if container == "" {
+		return href, nil
+	}
+
+	token, err := s.token(ctx, account+"/"+container)
+	if err != nil {
+		return "", err
+	}
+	return appendSASToken(u, token), nil
+}
+
+// SignItem signs every blob asset of item with the SAS token of its
+// collection. Items without a collection fall back to per-container tokens.
+func (s *SASTokenSigner) SignItem(ctx context.Context, item *stac.Item) error {
+	if item == nil {
+		return nil
+	}
+
+	var token string
+	for key, asset := range item.Assets {
+		if asset == nil {
+			continue
+		}
+		u, ok := parseUnsignedBlobURL(asset.Href)
+		if !ok {
+			continue
+		}
+
+		if item.Collection == "" {
+			signed, err := s.SignHref(ctx, asset.Href)
+			if err != nil {
+				return fmt.Errorf("error signing asset %s of item %s: %w", key, item.Id, err)
+			}
+			asset.Href = signed
+			continue
+		}
+
+		if token == "" {
+			var err error
+			if token, err = s.token(ctx, url.PathEscape(item.Collection)); err != nil {
+				return fmt.Errorf("error signing item %s: %w", item.Id, err)
+			}
+		}
+		asset.Href = appendSASToken(u, token)
+	}
+	return nil
+}
+
+// token returns a cached token for key, fetching a new one when missing or
+// about to expire.
+func (s *SASTokenSigner) token(ctx context.Context, key string) (string, error) {
+	s.mu.Lock()
+	cached, ok := s.tokens[key]
+	s.mu.Unlock()
+	if ok && s.now().Add(s.margin).Before(cached.expiry) {
+		return cached.token, nil
+	}
+
+	fresh, err := s.fetchToken(ctx, key)
+	if err != nil {
+		return "", err
+	}
+
+	s.mu.Lock()
+	s.tokens[key] = fresh
+	s.mu.Unlock()
+	return fresh.token, nil
+}
+
+func (s *SASTokenSigner) fetchToken(ctx context.Context, key string) (sasToken, error) {
+	tokenURL := s.tokenURL + "/" + key
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, tokenURL, nil)
+	if err != nil {
+		return sasToken{}, fmt.Errorf("error creating request for %s: %w", tokenURL, err)
+	}
+	for k, vs := range s.header {
+		req.Header[k] = vs
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return sasToken{}, fmt.Errorf("failed to fetch SAS token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return sasToken{}, fmt.Errorf("failed to fetch SAS token: %w", newStatusError(resp))
+	}
+
+	var body struct {
+		Token  string    `json:"token"`
+		Expiry time.Time `json:"msft:expiry"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return sasToken{}, fmt.Errorf("error decoding SAS token from %s: %w", tokenURL, err)
+	}
+	if body.Token == "" {
+		return sasToken{}, fmt.Errorf("empty SAS token returned by %s", tokenURL)
+	}
+	return sasToken{token: body.Token, expiry: body.Expiry}, nil
+}
+
+// parseUnsignedBlobURL reports whether href is an Azure blob URL that does not
+// yet carry a SAS signature.
+func parseUnsignedBlobURL(href string) (*url.URL, bool) {
+	u, err := url.Parse(href)
+	if err != nil || !isAzureBlobHost(u.Hostname()) {
+		return nil, false
+	}
+	if u.Query().Has("sig") {
+		return nil, false
+	}
+	return u, true
+}
+
+func appendSASToken(u *url.URL, token string) string {
+	out := *u
+	appendRawQuery(&out, token)
+	return out.String()
+}
@@ -0,0 +1,188 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/robert-malhotra/go-stac-client/pkg/stac"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTokenServer mocks a Planetary Computer style SAS token endpoint. Tokens
+// embed the requested path so tests can tell them apart.
+func newTokenServer(t *testing.T, ttl time.Duration) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var hits atomic.Int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		key := strings.TrimPrefix(r.URL.Path, "/token/")
+		if strings.HasPrefix(key, "forbidden") {
+			http.Error(w, "denied", http.StatusForbidden)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"msft:expiry": %q, "token": "st=now&sig=%s"}`,
+			time.Now().Add(ttl).UTC().Format(time.RFC3339), url.QueryEscape(key))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &hits
+}
+
+func TestSASTokenSigner_SignHref(t *testing.T) {
+	srv, hits := newTokenServer(t, time.Hour)
+	signer := NewSASTokenSigner(srv.URL + "/token")
+	ctx := context.Background()
+
+	t.Run("blob href gets container token", func(t *testing.T) {
+		signed, err := signer.SignHref(ctx, "https://naipeuwest.blob.core.windows.net/naip/v002/tile.tif")
+		require.NoError(t, err)
+
+		u, err := url.Parse(signed)
+		require.NoError(t, err)
+		assert.Equal(t, "naipeuwest/naip", u.Query().Get("sig"))
+		assert.Equal(t, "/naip/v002/tile.tif", u.Path)
+	})
+
+	t.Run("token is cached", func(t *testing.T) {
+		before := hits.Load()
+		_, err := signer.SignHref(ctx, "https://naipeuwest.blob.core.windows.net/naip/other.tif")
+		require.NoError(t, err)
+		assert.Equal(t, before, hits.Load())
+	})
+
+	t.Run("non-blob and signed hrefs unchanged", func(t *testing.T) {
+		for _, href := range []string{
+			"https://example.com/data.tif",
+			"s3://bucket/key.tif",
+			"https://naipeuwest.blob.core.windows.net/naip/tile.tif?sig=already",
+		} {
+			signed, err := signer.SignHref(ctx, href)
+			require.NoError(t, err)
+			assert.Equal(t, href, signed)
+		}
+	})
+
+	t.Run("endpoint error", func(t *testing.T) {
+		_, err := signer.SignHref(ctx, "https://forbidden.blob.core.windows.net/c/x.tif")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unexpected status code 403")
+	})
+}
+
+func TestSASTokenSigner_Expiry(t *testing.T) {
+	srv, hits := newTokenServer(t, 30*time.Second)
+	signer := NewSASTokenSigner(srv.URL+"/token", WithSASExpiryMargin(time.Minute))
+
+	ctx := context.Background()
+	href := "https://acct.blob.core.windows.net/c/x.tif"
+	_, err := signer.SignHref(ctx, href)
+	require.NoError(t, err)
+	_, err = signer.SignHref(ctx, href)
+	require.NoError(t, err)
+
+	// Tokens expiring within the margin are refreshed every time.
+	assert.Equal(t, int32(2), hits.Load())
+}
+
+func TestSASTokenSigner_SignItem(t *testing.T) {
+	srv, hits := newTokenServer(t, time.Hour)
+	signer := NewSASTokenSigner(srv.URL + "/token")
+
+	item := &stac.Item{
+		Id:         "scene",
+		Collection: "sentinel-2-l2a",
+		Assets: map[string]*stac.Asset{
+			"B02":       {Href: "https://sentinel2l2a01.blob.core.windows.net/sentinel2-l2/B02.tif"},
+			"B03":       {Href: "https://sentinel2l2a01.blob.core.windows.net/sentinel2-l2/B03.tif"},
+			"thumbnail": {Href: "https://example.com/thumb.png"},
+		},
+	}
+
+	require.NoError(t, SignItem(context.Background(), signer, item))
+	assert.Equal(t, int32(1), hits.Load())
+	assert.Contains(t, item.Assets["B02"].Href, "sig=sentinel-2-l2a")
+	assert.Contains(t, item.Assets["B03"].Href, "sig=sentinel-2-l2a")
+	assert.Equal(t, "https://example.com/thumb.png", item.Assets["thumbnail"].Href)
+}
+
+func TestClient_WithSigner(t *testing.T) {
+	var gotSig string
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/asset.tif":
+			gotSig = r.URL.Query().Get("sig")
+			w.Write([]byte("signed-bytes"))
+		case "/collections/c1/items":
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprintf(w, `{"type":"FeatureCollection","features":[
+				{"type":"Feature","id":"a","collection":"c1","properties":{},"geometry":null,"links":[],
+				 "assets":{"data":{"href":"%s/asset.tif"}}}],"links":[]}`, server.URL)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	signer := SignerFunc(func(_ context.Context, href string) (string, error) {
+		if strings.Contains(href, "sig=") {
+			return href, nil
+		}
+		return href + "?sig=test", nil
+	})
+
+	t.Run("download", func(t *testing.T) {
+		cli, err := NewClient(server.URL, WithSigner(signer))
+		require.NoError(t, err)
+
+		dest := filepath.Join(t.TempDir(), "asset.tif")
+		require.NoError(t, cli.DownloadAsset(context.Background(), "asset.tif", dest))
+		assert.Equal(t, "test", gotSig)
+
+		got, err := os.ReadFile(dest)
+		require.NoError(t, err)
+		assert.Equal(t, "signed-bytes", string(got))
+
+		items, err := collect(cli.GetItems(context.Background(), "c1"))
+		require.NoError(t, err)
+		require.Len(t, items, 1)
+		assert.NotContains(t, items[0].Assets["data"].Href, "sig=", "items are left alone without WithItemSigning")
+	})
+
+	t.Run("item signing", func(t *testing.T) {
+		cli, err := NewClient(server.URL, WithSigner(signer), WithItemSigning())
+		require.NoError(t, err)
+
+		items, err := collect(cli.GetItems(context.Background(), "c1"))
+		require.NoError(t, err)
+		require.Len(t, items, 1)
+		assert.Equal(t, server.URL+"/asset.tif?sig=test", items[0].Assets["data"].Href)
+	})
+
+	t.Run("signer error", func(t *testing.T) {
+		failing := SignerFunc(func(context.Context, string) (string, error) {
+			return "", fmt.Errorf("token service down")
+		})
+		cli, err := NewClient(server.URL, WithSigner(failing), WithItemSigning())
+		require.NoError(t, err)
+
+		_, err = collect(cli.GetItems(context.Background(), "c1"))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "token service down")
+
+		err = cli.DownloadAsset(context.Background(), "asset.tif", filepath.Join(t.TempDir(), "x"))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to sign asset URL")
+	})
+}
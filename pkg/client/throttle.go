@@ -0,0 +1,116 @@
+package client
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+)
+
+// WithBandwidthLimit caps the combined throughput of all asset downloads made
+// by the client, in bytes per second. Zero or a negative value means no limit.
+// Per-download limits set with WithDownloadBandwidthLimit apply on top of it.
+func WithBandwidthLimit(bytesPerSec int64) ClientOption {
+	return func(c *Client) { c.bandwidth = newRateLimiter(bytesPerSec) }
+}
+
+// rateLimiter is a token bucket holding up to one second of bandwidth.
+// Callers may overdraw it; the debt is paid back by sleeping, which keeps
+// large reads from starving smaller ones that share the limiter.
+type rateLimiter struct {
+	rate float64 // bytes per second
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+// newRateLimiter returns nil, meaning unlimited, for non-positive rates.
+func newRateLimiter(bytesPerSec int64) *rateLimiter {
+	if bytesPerSec <= 0 {
+		return nil
+	}
+	return &rateLimiter{
+		rate:   float64(bytesPerSec),
+		tokens: float64(bytesPerSec),
+		last:   time.Now(),
+	}
+}
+
+// wait accounts for n bytes and blocks until the limiter has recovered.
+func (l *rateLimiter) wait(ctx context.Context, n int) error {
+	if l == nil || n <= 0 {
+		return nil
+	}
+
+	l.mu.Lock()
+	now := time.Now()
+	l.tokens = min(l.rate, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	l.last = now
+	l.tokens -= float64(n)
+	var delay time.Duration
+	if l.tokens < 0 {
+		delay = time.Duration(-l.tokens / l.rate * float64(time.Second))
+	}
+	l.mu.Unlock()
+
+	if delay == 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// throttledReader limits reads from r to the rates of its limiters and
+// blocks while gate reports the transfer as paused.
+type throttledReader struct {
+	ctx      context.Context
+	r        io.Reader
+	limiters []*rateLimiter
+	gate     func(context.Context) error
+	chunk    int
+}
+
+// newThrottledReader wraps r, or returns it unchanged when there is nothing
+// to enforce.
+func newThrottledReader(ctx context.Context, r io.Reader, gate func(context.Context) error, limiters ...*rateLimiter) io.Reader {
+	tr := &throttledReader{ctx: ctx, r: r, gate: gate, chunk: 32 * 1024}
+	for _, l := range limiters {
+		if l == nil {
+			continue
+		}
+		tr.limiters = append(tr.limiters, l)
+		// Read in slices of ~100ms so progress stays smooth at low rates.
+		if c := int(l.rate / 10); c < tr.chunk {
+			tr.chunk = max(c, 1)
+		}
+	}
+	if len(tr.limiters) == 0 && gate == nil {
+		return r
+	}
+	return tr
+}
+
+func (t *throttledReader) Read(p []byte) (int, error) {
+	if t.gate != nil {
+		if err := t.gate(t.ctx); err != nil {
+			return 0, err
+		}
+	}
+	if len(p) > t.chunk {
+		p = p[:t.chunk]
+	}
+	n, err := t.r.Read(p)
+	for _, l := range t.limiters {
+		if werr := l.wait(t.ctx, n); werr != nil {
+			return n, werr
+		}
+	}
+	return n, err
+}
@@ -0,0 +1,57 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBandwidthLimit(t *testing.T) {
+	content := testContent(15_000)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(content)
+	}))
+	defer srv.Close()
+
+	// The first second of bandwidth is available immediately, so 15 kB at
+	// 10 kB/s takes about half a second.
+	tests := []struct {
+		name string
+		cli  []ClientOption
+		dl   []DownloadOption
+	}{
+		{"client limit", []ClientOption{WithBandwidthLimit(10_000)}, nil},
+		{"download limit", nil, []DownloadOption{WithDownloadBandwidthLimit(10_000)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cli, err := NewClient(srv.URL, tt.cli...)
+			require.NoError(t, err)
+
+			var buf bytes.Buffer
+			start := time.Now()
+			require.NoError(t, cli.DownloadAssetTo(context.Background(), "asset.bin", &buf, nil, tt.dl...))
+			elapsed := time.Since(start)
+
+			assert.Equal(t, content, buf.Bytes())
+			assert.GreaterOrEqual(t, elapsed, 400*time.Millisecond)
+			assert.Less(t, elapsed, 3*time.Second)
+		})
+	}
+
+	t.Run("canceled while throttled", func(t *testing.T) {
+		cli, err := NewClient(srv.URL, WithBandwidthLimit(1_000))
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		err = cli.DownloadAssetTo(ctx, "asset.bin", &bytes.Buffer{}, nil)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
@@ -0,0 +1,262 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/robert-malhotra/go-stac-client/pkg/stac"
+)
+
+// WriteOption configures a transaction request.
+type WriteOption func(*writeOptions)
+
+type writeOptions struct {
+	ifMatch string
+	etag    *string
+}
+
+// IfMatch makes the request conditional on the stored document still having
+// the given ETag, as returned by GetItemWithETag or ReturnETag. A stale ETag
+// fails with ErrPreconditionFailed.
+func IfMatch(etag string) WriteOption {
+	return func(o *writeOptions) { o.ifMatch = etag }
+}
+
+// ReturnETag stores the ETag of the written document, if the server sends
+// one, in *dst.
+func ReturnETag(dst *string) WriteOption {
+	return func(o *writeOptions) { o.etag = dst }
+}
+
+// CreateItem adds item to a collection (POST /collections/{id}/items). The
+// item's collection field is filled in when empty. It returns the item as
+// stored by the server, or item itself when the server sends no body.
+func (c *Client) CreateItem(ctx context.Context, collectionID string, item *stac.Item, opts ...WriteOption) (*stac.Item, error) {
+	if collectionID == "" {
+		return nil, fmt.Errorf("collection ID cannot be empty")
+	}
+	if item == nil {
+		return nil, fmt.Errorf("item cannot be nil")
+	}
+	if item.Collection == "" {
+		cp := *item
+		cp.Collection = collectionID
+		item = &cp
+	}
+
+	u := c.baseURL.JoinPath("collections", collectionID, "items")
+	return writeDocument(ctx, c, http.MethodPost, u, "application/geo+json", item, item, opts)
+}
+
+// UpdateItem replaces an existing item (PUT
+// /collections/{collection}/items/{id}), identified by its collection and
+// ID fields.
+func (c *Client) UpdateItem(ctx context.Context, item *stac.Item, opts ...WriteOption) (*stac.Item, error) {
+	if item == nil {
+		return nil, fmt.Errorf("item cannot be nil")
+	}
+	if item.Collection == "" {
+		return nil, fmt.Errorf("item collection cannot be empty")
+	}
+	if item.Id == "" {
+		return nil, fmt.Errorf("item ID cannot be empty")
+	}
+
+	u := c.baseURL.JoinPath("collections", item.Collection, "items", item.Id)
+	return writeDocument(ctx, c, http.MethodPut, u, "application/geo+json", item, item, opts)
+}
+
+// PatchItem applies a JSON merge patch (RFC 7396) to an item (PATCH
+// /collections/{collection}/items/{id}). patch is typically a map holding
+// only the members to change, with nil values removing members. It returns
+// the patched item, or nil when the server sends no body.
+func (c *Client) PatchItem(ctx context.Context, collectionID, itemID string, patch any, opts ...WriteOption) (*stac.Item, error) {
+	if collectionID == "" {
+		return nil, fmt.Errorf("collection ID cannot be empty")
+	}
+	if itemID == "" {
+		return nil, fmt.Errorf("item ID cannot be empty")
+	}
+
+	u := c.baseURL.JoinPath("collections", collectionID, "items", itemID)
+	return writeDocument[stac.Item](ctx, c, http.MethodPatch, u, "application/merge-patch+json", patch, nil, opts)
+}
+
+// DeleteItem removes an item (DELETE /collections/{collection}/items/{id}).
+func (c *Client) DeleteItem(ctx context.Context, collectionID, itemID string, opts ...WriteOption) error {
+	if collectionID == "" {
+		return fmt.Errorf("collection ID cannot be empty")
+	}
+	if itemID == "" {
+		return fmt.Errorf("item ID cannot be empty")
+	}
+
+	u := c.baseURL.JoinPath("collections", collectionID, "items", itemID)
+	_, err := writeDocument[struct{}](ctx, c, http.MethodDelete, u, "", nil, nil, opts)
+	return err
+}
+
+// CreateCollection adds a collection (POST /collections). It returns the
+// collection as stored by the server, or col itself when the server sends
+// no body.
+func (c *Client) CreateCollection(ctx context.Context, col *stac.Collection, opts ...WriteOption) (*stac.Collection, error) {
+	if col == nil {
+		return nil, fmt.Errorf("collection cannot be nil")
+	}
+
+	u := c.baseURL.JoinPath("collections")
+	return writeDocument(ctx, c, http.MethodPost, u, "application/json", col, col, opts)
+}
+
+// UpdateCollection replaces an existing collection (PUT /collections/{id}).
+func (c *Client) UpdateCollection(ctx context.Context, col *stac.Collection, opts ...WriteOption) (*stac.Collection, error) {
+	if col == nil {
+		return nil, fmt.Errorf("collection cannot be nil")
+	}
+	if col.Id == "" {
+		return nil, fmt.Errorf("collection ID cannot be empty")
+	}
+
+	u := c.baseURL.JoinPath("collections", col.Id)
+	return writeDocument(ctx, c, http.MethodPut, u, "application/json", col, col, opts)
+}
+
+// PatchCollection applies a JSON merge patch (RFC 7396) to a collection
+// (PATCH /collections/{id}). See PatchItem.
+func (c *Client) PatchCollection(ctx context.Context, collectionID string, patch any, opts ...WriteOption) (*stac.Collection, error) {
+	if collectionID == "" {
+		return nil, fmt.Errorf("collection ID cannot be empty")
+	}
+
+	u := c.baseURL.JoinPath("collections", collectionID)
+	return writeDocument[stac.Collection](ctx, c, http.MethodPatch, u, "application/merge-patch+json", patch, nil, opts)
+}
+
+// DeleteCollection removes a collection (DELETE /collections/{id}).
+func (c *Client) DeleteCollection(ctx context.Context, collectionID string, opts ...WriteOption) error {
+	if collectionID == "" {
+		return fmt.Errorf("collection ID cannot be empty")
+	}
+
+	u := c.baseURL.JoinPath("collections", collectionID)
+	_, err := writeDocument[struct{}](ctx, c, http.MethodDelete, u, "", nil, nil, opts)
+	return err
+}
+
+// BulkMethod selects how BulkInsertItems treats items that already exist.
+type BulkMethod string
+
+const (
+	// BulkInsert fails the request if any item already exists.
+	BulkInsert BulkMethod = "insert"
+	// BulkUpsert replaces existing items.
+	BulkUpsert BulkMethod = "upsert"
+)
+
+// BulkInsertItems writes items to a collection in a single request to the
+// Bulk Transactions endpoint (POST /collections/{id}/bulk_items). Items
+// without a collection field get collectionID. Large sets should be split
+// into batches the server accepts.
+func (c *Client) BulkInsertItems(ctx context.Context, collectionID string, items []*stac.Item, method BulkMethod) error {
+	if collectionID == "" {
+		return fmt.Errorf("collection ID cannot be empty")
+	}
+	if method == "" {
+		method = BulkInsert
+	}
+
+	byID := make(map[string]*stac.Item, len(items))
+	for _, item := range items {
+		if item == nil {
+			continue
+		}
+		if item.Id == "" {
+			return fmt.Errorf("item ID cannot be empty")
+		}
+		if _, dup := byID[item.Id]; dup {
+			return fmt.Errorf("duplicate item ID %s", item.Id)
+		}
+		if item.Collection == "" {
+			cp := *item
+			cp.Collection = collectionID
+			item = &cp
+		}
+		byID[item.Id] = item
+	}
+
+	body := struct {
+		Items  map[string]*stac.Item `json:"items"`
+		Method BulkMethod            `json:"method"`
+	}{byID, method}
+
+	u := c.baseURL.JoinPath("collections", collectionID, "bulk_items")
+	_, err := writeDocument[struct{}](ctx, c, http.MethodPost, u, "application/json", body, nil, nil)
+	return err
+}
+
+// writeDocument sends a transaction request and decodes the document in the
+// response. When the response has no body it returns fallback.
+func writeDocument[T any](ctx context.Context, c *Client, method string, u *url.URL, contentType string, payload any, fallback *T, opts []WriteOption) (*T, error) {
+	var o writeOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	var body io.Reader
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return nil, fmt.Errorf("error encoding request body for %s: %w", u, err)
+		}
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request for %s: %w", u, err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if o.ifMatch != "" {
+		req.Header.Set("If-Match", o.ifMatch)
+	}
+
+	resp, err := c.do(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, newAPIError(resp)
+	}
+	if o.etag != nil {
+		*o.etag = resp.Header.Get("ETag")
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading response from %s: %w", u, err)
+	}
+	// Servers may answer with no body or a status message rather than the
+	// stored document.
+	if trimmed := bytes.TrimSpace(data); len(trimmed) == 0 || trimmed[0] != '{' {
+		return fallback, nil
+	}
+
+	var out T
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, fmt.Errorf("error decoding response from %s: %w", u, err)
+	}
+	if err := c.prepareValue(ctx, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
@@ -0,0 +1,295 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/robert-malhotra/go-stac-client/pkg/stac"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeTransactionServer is an in-memory STAC API implementing the
+// Transaction, Collection Transaction and Bulk Transactions extensions with
+// ETag versioning, in the style of stac-fastapi.
+type fakeTransactionServer struct {
+	*httptest.Server
+
+	mu          sync.Mutex
+	collections map[string]map[string]any
+	items       map[string]map[string]any // keyed by collection/id
+	versions    map[string]int
+}
+
+func newFakeTransactionServer(t *testing.T) *fakeTransactionServer {
+	f := &fakeTransactionServer{
+		collections: map[string]map[string]any{},
+		items:       map[string]map[string]any{},
+		versions:    map[string]int{},
+	}
+	f.Server = httptest.NewServer(http.HandlerFunc(f.handle))
+	t.Cleanup(f.Close)
+	return f
+}
+
+func (f *fakeTransactionServer) fail(w http.ResponseWriter, status int, code, desc string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"code": code, "description": desc})
+}
+
+func (f *fakeTransactionServer) send(w http.ResponseWriter, status int, key string, doc map[string]any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", fmt.Sprintf(`"%s-%d"`, key, f.versions[key]))
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(doc)
+}
+
+func (f *fakeTransactionServer) handle(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(parts) == 0 || parts[0] != "collections" {
+		http.NotFound(w, r)
+		return
+	}
+
+	var doc map[string]any
+	if r.Body != nil && r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&doc); err != nil {
+			f.fail(w, http.StatusBadRequest, "RequestValidationError", err.Error())
+			return
+		}
+	}
+
+	var (
+		store map[string]map[string]any
+		key   string
+	)
+	switch {
+	case len(parts) == 1 && r.Method == http.MethodPost:
+		store, key = f.collections, fmt.Sprint(doc["id"])
+	case len(parts) == 2:
+		store, key = f.collections, parts[1]
+	case len(parts) == 3 && parts[2] == "items" && r.Method == http.MethodPost:
+		if _, ok := f.collections[parts[1]]; !ok {
+			f.fail(w, http.StatusNotFound, "NotFoundError", "collection not found")
+			return
+		}
+		if doc["geometry"] == nil {
+			f.fail(w, http.StatusBadRequest, "RequestValidationError", "geometry is required")
+			return
+		}
+		store, key = f.items, parts[1]+"/"+fmt.Sprint(doc["id"])
+	case len(parts) == 3 && parts[2] == "bulk_items" && r.Method == http.MethodPost:
+		items, _ := doc["items"].(map[string]any)
+		for id := range items {
+			if _, exists := f.items[parts[1]+"/"+id]; exists && doc["method"] != "upsert" {
+				f.fail(w, http.StatusConflict, "ConflictError", "item "+id+" already exists")
+				return
+			}
+		}
+		for id, item := range items {
+			f.items[parts[1]+"/"+id] = item.(map[string]any)
+			f.versions[parts[1]+"/"+id]++
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, "%q", fmt.Sprintf("Successfully added %d items.", len(items)))
+		return
+	case len(parts) == 4 && parts[2] == "items":
+		store, key = f.items, parts[1]+"/"+parts[3]
+	default:
+		http.NotFound(w, r)
+		return
+	}
+
+	existing, exists := store[key]
+	if match := r.Header.Get("If-Match"); match != "" && exists && match != fmt.Sprintf(`"%s-%d"`, key, f.versions[key]) {
+		f.fail(w, http.StatusPreconditionFailed, "PreconditionFailed", "etag mismatch")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		if !exists {
+			f.fail(w, http.StatusNotFound, "NotFoundError", key+" not found")
+			return
+		}
+		f.send(w, http.StatusOK, key, existing)
+	case http.MethodPost:
+		if exists {
+			f.fail(w, http.StatusConflict, "ConflictError", key+" already exists")
+			return
+		}
+		store[key] = doc
+		f.versions[key]++
+		f.send(w, http.StatusCreated, key, doc)
+	case http.MethodPut:
+		if !exists {
+			f.fail(w, http.StatusNotFound, "NotFoundError", key+" not found")
+			return
+		}
+		store[key] = doc
+		f.versions[key]++
+		f.send(w, http.StatusOK, key, doc)
+	case http.MethodPatch:
+		if !exists {
+			f.fail(w, http.StatusNotFound, "NotFoundError", key+" not found")
+			return
+		}
+		if r.Header.Get("Content-Type") != "application/merge-patch+json" {
+			f.fail(w, http.StatusUnsupportedMediaType, "UnsupportedMediaType", r.Header.Get("Content-Type"))
+			return
+		}
+		mergePatch(existing, doc)
+		f.versions[key]++
+		f.send(w, http.StatusOK, key, existing)
+	case http.MethodDelete:
+		if !exists {
+			f.fail(w, http.StatusNotFound, "NotFoundError", key+" not found")
+			return
+		}
+		delete(store, key)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// mergePatch applies an RFC 7396 merge patch to dst.
+func mergePatch(dst, patch map[string]any) {
+	for k, v := range patch {
+		switch v := v.(type) {
+		case nil:
+			delete(dst, k)
+		case map[string]any:
+			sub, ok := dst[k].(map[string]any)
+			if !ok {
+				sub = map[string]any{}
+				dst[k] = sub
+			}
+			mergePatch(sub, v)
+		default:
+			dst[k] = v
+		}
+	}
+}
+
+func testCollection(id string) *stac.Collection {
+	return &stac.Collection{
+		Type: "Collection", Version: "1.1.0", Id: id, Description: "Processed scenes", License: "other",
+		Extent: &stac.Extent{Spatial: &stac.SpatialExtent{Bbox: [][]float64{{-180, -90, 180, 90}}}},
+		Links:  []*stac.Link{},
+	}
+}
+
+func testItem(id string) *stac.Item {
+	return &stac.Item{
+		Type: "Feature", Version: "1.1.0", Id: id,
+		Geometry:   map[string]any{"type": "Point", "coordinates": []float64{10, 50}},
+		Bbox:       []float64{10, 50, 10, 50},
+		Properties: map[string]any{"datetime": "2024-01-01T00:00:00Z", "eo:cloud_cover": 10.0},
+		Links:      []*stac.Link{},
+		Assets:     map[string]*stac.Asset{},
+	}
+}
+
+func TestClient_Transactions(t *testing.T) {
+	srv := newFakeTransactionServer(t)
+	c, err := NewClient(srv.URL)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	t.Run("collection lifecycle", func(t *testing.T) {
+		col, err := c.CreateCollection(ctx, testCollection("scenes"))
+		require.NoError(t, err)
+		assert.Equal(t, "scenes", col.Id)
+
+		_, err = c.CreateCollection(ctx, testCollection("scenes"))
+		assert.ErrorIs(t, err, ErrConflict)
+		var apiErr *APIError
+		require.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, http.StatusConflict, apiErr.StatusCode)
+		assert.Equal(t, "ConflictError", apiErr.Code)
+		assert.Contains(t, apiErr.Description, "already exists")
+
+		col.Title = "Scenes"
+		col, err = c.UpdateCollection(ctx, col)
+		require.NoError(t, err)
+		assert.Equal(t, "Scenes", col.Title)
+
+		col, err = c.PatchCollection(ctx, "scenes", map[string]any{"title": nil, "keywords": []string{"sar"}})
+		require.NoError(t, err)
+		assert.Empty(t, col.Title)
+		assert.Equal(t, []string{"sar"}, col.Keywords)
+	})
+
+	t.Run("item lifecycle with etags", func(t *testing.T) {
+		var etag string
+		item, err := c.CreateItem(ctx, "scenes", testItem("scene-1"), ReturnETag(&etag))
+		require.NoError(t, err)
+		assert.Equal(t, "scenes", item.Collection)
+		assert.NotEmpty(t, etag)
+
+		fetched, current, err := c.GetItemWithETag(ctx, "scenes", "scene-1")
+		require.NoError(t, err)
+		assert.Equal(t, etag, current)
+
+		fetched.Properties["eo:cloud_cover"] = 5.0
+		updated, err := c.UpdateItem(ctx, fetched, IfMatch(current), ReturnETag(&etag))
+		require.NoError(t, err)
+		assert.Equal(t, 5.0, updated.Properties["eo:cloud_cover"])
+		assert.NotEqual(t, current, etag)
+
+		// The first ETag is now stale.
+		_, err = c.PatchItem(ctx, "scenes", "scene-1", map[string]any{"properties": map[string]any{"eo:cloud_cover": 1}}, IfMatch(current))
+		assert.ErrorIs(t, err, ErrPreconditionFailed)
+
+		patched, err := c.PatchItem(ctx, "scenes", "scene-1", map[string]any{"properties": map[string]any{"eo:cloud_cover": nil}}, IfMatch(etag))
+		require.NoError(t, err)
+		assert.NotContains(t, patched.Properties, "eo:cloud_cover")
+		assert.Equal(t, "2024-01-01T00:00:00Z", patched.Properties["datetime"])
+
+		require.NoError(t, c.DeleteItem(ctx, "scenes", "scene-1"))
+		assert.ErrorIs(t, c.DeleteItem(ctx, "scenes", "scene-1"), ErrNotFound)
+	})
+
+	t.Run("validation error", func(t *testing.T) {
+		item := testItem("no-geometry")
+		item.Geometry = nil
+		_, err := c.CreateItem(ctx, "scenes", item)
+		assert.ErrorIs(t, err, ErrValidation)
+
+		_, err = c.UpdateItem(ctx, testItem("no-collection"))
+		assert.Error(t, err)
+	})
+
+	t.Run("bulk insert", func(t *testing.T) {
+		items := []*stac.Item{testItem("bulk-1"), testItem("bulk-2"), testItem("bulk-3")}
+		require.NoError(t, c.BulkInsertItems(ctx, "scenes", items, BulkInsert))
+
+		for _, item := range items {
+			got, err := c.GetItem(ctx, "scenes", item.Id)
+			require.NoError(t, err)
+			assert.Equal(t, "scenes", got.Collection)
+		}
+
+		err := c.BulkInsertItems(ctx, "scenes", items[:1], BulkInsert)
+		assert.ErrorIs(t, err, ErrConflict)
+		assert.NoError(t, c.BulkInsertItems(ctx, "scenes", items[:1], BulkUpsert))
+
+		err = c.BulkInsertItems(ctx, "scenes", []*stac.Item{testItem("dup"), testItem("dup")}, BulkInsert)
+		assert.Error(t, err)
+	})
+
+	t.Run("delete collection", func(t *testing.T) {
+		require.NoError(t, c.DeleteCollection(ctx, "scenes"))
+		_, err := c.GetCollection(ctx, "scenes")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,168 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"net/url"
+	"path/filepath"
+	"slices"
+
+	"github.com/robert-malhotra/go-stac-client/pkg/stac"
+)
+
+// WalkNode is a document reached while walking a static catalog. Exactly one
+// of Catalog, Collection and Item is set.
+type WalkNode struct {
+	// Href is the absolute URL the document was loaded from. Local files
+	// use the file scheme.
+	Href string
+	// Path holds the IDs of the documents from the root down to and
+	// including this one.
+	Path []string
+
+	Catalog    *stac.Catalog
+	Collection *stac.Collection
+	Item       *stac.Item
+}
+
+// Links returns the links of the node's document.
+func (n *WalkNode) Links() []*stac.Link {
+	switch {
+	case n.Catalog != nil:
+		return n.Catalog.Links
+	case n.Collection != nil:
+		return n.Collection.Links
+	case n.Item != nil:
+		return n.Item.Links
+	}
+	return nil
+}
+
+// Walk loads the catalog at root and recursively follows its "child" and
+// "item" links, yielding every catalog, collection and item in depth-first
+// order, parents before their children.
+//
+// root may be a local path, a file:// URL or any URL the client can fetch
+// assets from (http(s), s3, gs, az or a registered scheme); http(s)
+// documents are requested through the client's middleware. Relative link
+// hrefs are resolved against the location of the document that holds them,
+// and documents already visited are skipped, so cyclic links terminate.
+// file links are only followed from local documents, so a remote catalog
+// cannot make the walk read local files.
+//
+// A document that fails to load is reported as an error and the walk
+// continues with its siblings; stop iterating to abort.
+func (c *Client) Walk(ctx context.Context, root string) iter.Seq2[*WalkNode, error] {
+	return func(yield func(*WalkNode, error) bool) {
+		u, err := documentURL(root)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		c.walk(ctx, u, "", nil, make(map[string]bool), yield)
+	}
+}
+
+// walk yields the document at u and its descendants. It returns false once
+// the caller has stopped iterating or ctx is done.
+func (c *Client) walk(ctx context.Context, u *url.URL, rel string, path []string, seen map[string]bool, yield func(*WalkNode, error) bool) bool {
+	if err := ctx.Err(); err != nil {
+		yield(nil, err)
+		return false
+	}
+
+	href := u.String()
+	if seen[href] {
+		return true
+	}
+	seen[href] = true
+
+	node, err := c.loadNode(ctx, u, rel)
+	if err != nil {
+		return yield(nil, fmt.Errorf("failed to load %s: %w", href, err))
+	}
+	node.Path = append(slices.Clone(path), nodeID(node))
+	if !yield(node, nil) {
+		return false
+	}
+	if node.Item != nil {
+		return true
+	}
+
+	for _, link := range node.Links() {
+		if link == nil || (link.Rel != stac.RelChild && link.Rel != stac.RelItem) {
+			continue
+		}
+		ref, err := url.Parse(link.Href)
+		if err != nil {
+			if !yield(nil, fmt.Errorf("invalid %s link in %s: %w", link.Rel, href, err)) {
+				return false
+			}
+			continue
+		}
+		target := u.ResolveReference(ref)
+		if err := checkFileLink(u, target); err != nil {
+			if !yield(nil, err) {
+				return false
+			}
+			continue
+		}
+		if !c.walk(ctx, target, link.Rel, node.Path, seen, yield) {
+			return false
+		}
+	}
+	return true
+}
+
+// loadNode fetches the document at u, reached through a link with the given
+// relation type.
+func (c *Client) loadNode(ctx context.Context, u *url.URL, rel string) (*WalkNode, error) {
+	doc, err := c.loadDocument(ctx, u, rel)
+	if err != nil {
+		return nil, err
+	}
+
+	node := &WalkNode{Href: u.String()}
+	switch d := doc.(type) {
+	case *stac.Item:
+		node.Item = d
+	case *stac.Collection:
+		node.Collection = d
+	case *stac.Catalog:
+		node.Catalog = d
+	default:
+		return nil, fmt.Errorf("unexpected %T in catalog tree", doc)
+	}
+	return node, nil
+}
+
+// documentURL turns a walk root into an absolute URL. Hrefs without a scheme
+// are local paths.
+func documentURL(href string) (*url.URL, error) {
+	u, err := url.Parse(href)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse catalog URL: %w", err)
+	}
+	if u.Scheme != "" {
+		return u, nil
+	}
+	abs, err := filepath.Abs(href)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve catalog path: %w", err)
+	}
+	return &url.URL{Scheme: "file", Path: filepath.ToSlash(abs)}, nil
+}
+
+// nodeID returns the ID of the node's document.
+func nodeID(n *WalkNode) string {
+	switch {
+	case n.Catalog != nil:
+		return n.Catalog.Id
+	case n.Collection != nil:
+		return n.Collection.Id
+	case n.Item != nil:
+		return n.Item.Id
+	}
+	return ""
+}
@@ -0,0 +1,186 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// staticCatalog maps relative paths to the documents of a small static
+// catalog: a root catalog with one collection, two items and a back link
+// that must not be followed twice.
+var staticCatalog = map[string]string{
+	"catalog.json": `{
+		"type": "Catalog", "stac_version": "1.0.0", "id": "root", "description": "Root",
+		"links": [
+			{"rel": "self", "href": "./catalog.json"},
+			{"rel": "child", "href": "./sentinel-2/collection.json"}
+		]
+	}`,
+	"sentinel-2/collection.json": `{
+		"type": "Collection", "stac_version": "1.0.0", "id": "sentinel-2", "description": "S2",
+		"license": "other", "extent": {"spatial": {"bbox": [[-180, -90, 180, 90]]}, "temporal": {"interval": [[null, null]]}},
+		"links": [
+			{"rel": "root", "href": "../catalog.json"},
+			{"rel": "item", "href": "items/a.json"},
+			{"rel": "item", "href": "items/b.json"},
+			{"rel": "child", "href": "../catalog.json"}
+		]
+	}`,
+	"sentinel-2/items/a.json": `{
+		"type": "Feature", "stac_version": "1.0.0", "id": "a", "geometry": null,
+		"properties": {"datetime": "2024-01-01T00:00:00Z"},
+		"links": [{"rel": "parent", "href": "../collection.json"}], "assets": {}
+	}`,
+	"sentinel-2/items/b.json": `{
+		"type": "Feature", "stac_version": "1.0.0", "id": "b", "geometry": null,
+		"properties": {"datetime": "2024-01-02T00:00:00Z"},
+		"links": [], "assets": {}
+	}`,
+}
+
+// writeStaticCatalog writes staticCatalog to a temporary directory.
+func writeStaticCatalog(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, doc := range staticCatalog {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(doc), 0o644))
+	}
+	return dir
+}
+
+func walkPaths(t *testing.T, c *Client, root string) ([]string, []error) {
+	t.Helper()
+	var (
+		paths []string
+		errs  []error
+	)
+	for node, err := range c.Walk(context.Background(), root) {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		paths = append(paths, strings.Join(node.Path, "/"))
+	}
+	return paths, errs
+}
+
+func TestClient_Walk(t *testing.T) {
+	want := []string{"root", "root/sentinel-2", "root/sentinel-2/a", "root/sentinel-2/b"}
+
+	t.Run("local disk", func(t *testing.T) {
+		dir := writeStaticCatalog(t)
+
+		c, err := NewClient("https://example.com")
+		require.NoError(t, err)
+
+		paths, errs := walkPaths(t, c, filepath.Join(dir, "catalog.json"))
+		assert.Empty(t, errs)
+		assert.Equal(t, want, paths)
+
+		var kinds []string
+		for node, err := range c.Walk(context.Background(), filepath.Join(dir, "catalog.json")) {
+			require.NoError(t, err)
+			switch {
+			case node.Catalog != nil:
+				kinds = append(kinds, "catalog")
+			case node.Collection != nil:
+				kinds = append(kinds, "collection")
+			case node.Item != nil:
+				kinds = append(kinds, "item")
+				assert.True(t, strings.HasPrefix(node.Href, "file://"))
+				assert.True(t, strings.HasSuffix(node.Href, "/sentinel-2/items/"+node.Item.Id+".json"))
+			}
+		}
+		assert.Equal(t, []string{"catalog", "collection", "item", "item"}, kinds)
+	})
+
+	t.Run("http with middleware", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "Bearer token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			doc, ok := staticCatalog[strings.TrimPrefix(r.URL.Path, "/static/")]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Write([]byte(doc))
+		}))
+		defer server.Close()
+
+		c, err := NewClient(server.URL, WithMiddleware(func(_ context.Context, r *http.Request) error {
+			r.Header.Set("Authorization", "Bearer token")
+			return nil
+		}))
+		require.NoError(t, err)
+
+		paths, errs := walkPaths(t, c, server.URL+"/static/catalog.json")
+		assert.Empty(t, errs)
+		assert.Equal(t, want, paths)
+	})
+
+	t.Run("file links in remote catalogs", func(t *testing.T) {
+		dir := writeStaticCatalog(t)
+		local := "file://" + filepath.ToSlash(filepath.Join(dir, "sentinel-2", "items", "a.json"))
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"type": "Catalog", "stac_version": "1.0.0", "id": "root", "description": "Root", "links": [
+				{"rel": "item", "href": "` + local + `"}
+			]}`))
+		}))
+		defer server.Close()
+
+		c, err := NewClient(server.URL)
+		require.NoError(t, err)
+
+		paths, errs := walkPaths(t, c, server.URL+"/catalog.json")
+		assert.Equal(t, []string{"root"}, paths)
+		require.Len(t, errs, 1)
+		assert.ErrorContains(t, errs[0], "refusing to read local file")
+	})
+
+	t.Run("broken link continues", func(t *testing.T) {
+		dir := t.TempDir()
+		root := `{"type": "Catalog", "stac_version": "1.0.0", "id": "root", "description": "Root", "links": [
+			{"rel": "child", "href": "missing.json"},
+			{"rel": "item", "href": "item.json"}
+		]}`
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "catalog.json"), []byte(root), 0o644))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "item.json"), []byte(staticCatalog["sentinel-2/items/b.json"]), 0o644))
+
+		c, err := NewClient("https://example.com")
+		require.NoError(t, err)
+
+		paths, errs := walkPaths(t, c, filepath.Join(dir, "catalog.json"))
+		assert.Equal(t, []string{"root", "root/b"}, paths)
+		require.Len(t, errs, 1)
+		assert.Contains(t, errs[0].Error(), "missing.json")
+	})
+
+	t.Run("stop early", func(t *testing.T) {
+		dir := writeStaticCatalog(t)
+
+		c, err := NewClient("https://example.com")
+		require.NoError(t, err)
+
+		var n int
+		for _, err := range c.Walk(context.Background(), filepath.Join(dir, "catalog.json")) {
+			require.NoError(t, err)
+			n++
+			if n == 2 {
+				break
+			}
+		}
+		assert.Equal(t, 2, n)
+	})
+}
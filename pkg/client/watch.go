@@ -0,0 +1,323 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"iter"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/robert-malhotra/go-stac-client/pkg/stac"
+)
+
+// Watch defaults.
+const (
+	DefaultWatchInterval = 5 * time.Minute
+	DefaultWatchLookback = time.Hour
+	DefaultWatchField    = "updated"
+)
+
+// Watch event types.
+const (
+	WatchNew     = "new"
+	WatchUpdated = "updated"
+)
+
+// WatchEvent reports an item that is new to the watch, or whose watched
+// timestamp changed since it was last reported.
+type WatchEvent struct {
+	Type string     `json:"type"`
+	Time time.Time  `json:"time"` // when the item was found
+	Item *stac.Item `json:"item"`
+}
+
+// WatchState is the high-water mark of a watch: the latest watched
+// timestamp seen, and the items seen within the lookback window before it
+// with their timestamps. It is plain JSON so it can be persisted between
+// runs with SaveWatchState and LoadWatchState.
+type WatchState struct {
+	Field     string               `json:"field"`
+	HighWater time.Time            `json:"high_water,omitzero"`
+	Seen      map[string]time.Time `json:"seen"`
+}
+
+// LoadWatchState reads a state saved by SaveWatchState. A missing file
+// yields an empty state.
+func LoadWatchState(path string) (*WatchState, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return &WatchState{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var state WatchState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("error decoding watch state %s: %w", path, err)
+	}
+	return &state, nil
+}
+
+// SaveWatchState writes state to path, replacing the file atomically.
+func SaveWatchState(path string, state *WatchState) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// WatchOption configures Watch.
+type WatchOption func(*watchConfig)
+
+type watchConfig struct {
+	interval   time.Duration
+	lookback   time.Duration
+	field      string
+	since      time.Time
+	checkpoint func(*WatchState) error
+	maxPolls   int
+	now        func() time.Time
+}
+
+// WithWatchInterval sets the time between polls; the default is
+// DefaultWatchInterval.
+func WithWatchInterval(d time.Duration) WatchOption {
+	return func(cfg *watchConfig) {
+		if d > 0 {
+			cfg.interval = d
+		}
+	}
+}
+
+// WithWatchLookback sets how far before the high-water mark each poll
+// searches, to catch items published late or stamped by a server clock
+// that lags. The default is DefaultWatchLookback.
+func WithWatchLookback(d time.Duration) WatchOption {
+	return func(cfg *watchConfig) {
+		if d >= 0 {
+			cfg.lookback = d
+		}
+	}
+}
+
+// WithWatchField sets the item property that orders the watch: "updated"
+// (the default), "created" or "datetime".
+func WithWatchField(name string) WatchOption {
+	return func(cfg *watchConfig) { cfg.field = name }
+}
+
+// WithWatchSince sets the high-water mark of a watch without state. The
+// default is the start of the first poll, so only items published from
+// then on, less the lookback window, are reported.
+func WithWatchSince(t time.Time) WatchOption {
+	return func(cfg *watchConfig) { cfg.since = t }
+}
+
+// WithWatchCheckpoint calls fn with the state after every completed poll,
+// typically to persist it with SaveWatchState.
+func WithWatchCheckpoint(fn func(*WatchState) error) WatchOption {
+	return func(cfg *watchConfig) { cfg.checkpoint = fn }
+}
+
+// WithWatchMaxPolls ends the watch after n polls, e.g. 1 to check for new
+// items from cron. The default is to poll until ctx is done.
+func WithWatchMaxPolls(n int) WatchOption {
+	return func(cfg *watchConfig) { cfg.maxPolls = n }
+}
+
+// withWatchClock replaces time.Now, for tests.
+func withWatchClock(now func() time.Time) WatchOption {
+	return func(cfg *watchConfig) { cfg.now = now }
+}
+
+// Watch re-runs a search every interval and yields the items that are new,
+// or whose watched timestamp changed, since the high-water mark in state.
+// state is updated as items are yielded; pass an empty state to start a new
+// watch. It runs until ctx is done, the caller stops iterating or
+// WithWatchMaxPolls is reached.
+//
+// Each poll searches for items whose watched property is at or after the
+// high-water mark less the lookback window, so late arrivals within that
+// window are still found; items already seen with the same timestamp are
+// skipped. The mark follows the item timestamps reported by the server but
+// never moves past the local clock, so a few bogus future timestamps
+// cannot make the watch skip items.
+//
+// For "updated" and "created" the time condition is ANDed into
+// params.Filter, which needs a server with the Filter extension. For
+// "datetime" it is sent as the datetime parameter unless params.Datetime
+// is already set. Items without the watched property are skipped.
+//
+// Search errors are yielded and the next poll retries; stop iterating to
+// give up. An unsupported field, a nil state or a state of a watch on
+// another field is yielded as the only error and ends the watch. Items are delivered at least once: a watch resumed from a state
+// saved at a checkpoint may report items again that were yielded after it.
+func (c *Client) Watch(ctx context.Context, params SearchParams, state *WatchState, opts ...WatchOption) iter.Seq2[*WatchEvent, error] {
+	cfg := watchConfig{
+		interval: DefaultWatchInterval,
+		lookback: DefaultWatchLookback,
+		field:    DefaultWatchField,
+		now:      time.Now,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	return func(yield func(*WatchEvent, error) bool) {
+		switch cfg.field {
+		case "updated", "created", "datetime":
+		default:
+			yield(nil, fmt.Errorf("unsupported watch field %q", cfg.field))
+			return
+		}
+		if state == nil {
+			yield(nil, fmt.Errorf("watch state is nil"))
+			return
+		}
+		if state.Field != "" && state.Field != cfg.field {
+			yield(nil, fmt.Errorf("watch state is for field %q, not %q", state.Field, cfg.field))
+			return
+		}
+		state.Field = cfg.field
+		if state.Seen == nil {
+			state.Seen = make(map[string]time.Time)
+		}
+		if state.HighWater.IsZero() {
+			state.HighWater = cfg.since
+			if state.HighWater.IsZero() {
+				state.HighWater = cfg.now()
+			}
+		}
+
+		for polls := 1; ; polls++ {
+			ok, err := c.watchPoll(ctx, params, state, &cfg, yield)
+			if !ok {
+				return
+			}
+			if err != nil {
+				if ctx.Err() != nil {
+					yield(nil, ctx.Err())
+					return
+				}
+				if !yield(nil, err) {
+					return
+				}
+			}
+			if err == nil && cfg.checkpoint != nil {
+				if err := cfg.checkpoint(state); err != nil && !yield(nil, fmt.Errorf("watch checkpoint: %w", err)) {
+					return
+				}
+			}
+
+			if cfg.maxPolls > 0 && polls >= cfg.maxPolls {
+				return
+			}
+
+			timer := time.NewTimer(cfg.interval)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				yield(nil, ctx.Err())
+				return
+			case <-timer.C:
+			}
+		}
+	}
+}
+
+// watchPoll runs one search and yields its new and updated items. It
+// returns false when the caller has stopped iterating, and the search
+// error, if any, otherwise.
+func (c *Client) watchPoll(ctx context.Context, params SearchParams, state *WatchState, cfg *watchConfig, yield func(*WatchEvent, error) bool) (bool, error) {
+	since := watchSince(state, cfg)
+	switch {
+	case cfg.field != "datetime":
+		cond := Gte(Property(cfg.field), TimestampFromTime(since))
+		if params.Filter == nil {
+			params.Filter = &Filter{Expression: cond}
+		} else {
+			params.Filter = &Filter{Expression: And(params.Filter.Expression, cond)}
+		}
+	case params.Datetime == "":
+		params.Datetime = since.UTC().Format(time.RFC3339) + "/.."
+	}
+
+	now := cfg.now()
+	highWater := state.HighWater
+	for item, err := range c.SearchCQL2(ctx, params) {
+		if err != nil {
+			return true, err
+		}
+		ts, ok := watchTimestamp(item, cfg.field)
+		if !ok || ts.Before(since) {
+			continue
+		}
+		key := item.Collection + "/" + item.Id
+		prev, seen := state.Seen[key]
+		if seen && !ts.After(prev) {
+			continue
+		}
+		state.Seen[key] = ts
+		if ts.After(highWater) {
+			highWater = ts
+		}
+
+		event := &WatchEvent{Type: WatchNew, Time: now, Item: item}
+		if seen {
+			event.Type = WatchUpdated
+		}
+		if !yield(event, nil) {
+			return false, nil
+		}
+	}
+
+	if highWater.After(now) {
+		highWater = now
+	}
+	if highWater.After(state.HighWater) {
+		state.HighWater = highWater
+	}
+	// Forget only items the next poll cannot find again.
+	cutoff := watchSince(state, cfg)
+	for key, ts := range state.Seen {
+		if ts.Before(cutoff) {
+			delete(state.Seen, key)
+		}
+	}
+	return true, nil
+}
+
+// watchSince returns the start of the window a poll searches: the
+// high-water mark less the lookback. Whole seconds suit every server;
+// rounding down only widens the window.
+func watchSince(state *WatchState, cfg *watchConfig) time.Time {
+	return state.HighWater.Add(-cfg.lookback).Truncate(time.Second)
+}
+
+// watchTimestamp returns the watched property of item. For "datetime" the
+// start_datetime of an item with a range is used.
+func watchTimestamp(item *stac.Item, field string) (time.Time, bool) {
+	v, _ := item.Properties[field].(string)
+	if v == "" && field == "datetime" {
+		v, _ = item.Properties["start_datetime"].(string)
+	}
+	t, err := time.Parse(time.RFC3339Nano, v)
+	return t, err == nil
+}
@@ -0,0 +1,195 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_Watch(t *testing.T) {
+	start := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	stamp := func(d time.Duration) string { return start.Add(d).Format(time.RFC3339) }
+
+	// Each poll answers with the next page of items.
+	polls := [][]string{
+		{"a|" + stamp(-10*time.Minute), "b|" + stamp(-5*time.Minute)},
+		// a was reprocessed, b is unchanged, c arrived late but within the
+		// lookback window and d is stamped in the future by a skewed clock.
+		{"a|" + stamp(time.Minute), "b|" + stamp(-5*time.Minute), "c|" + stamp(-30*time.Minute), "d|" + stamp(48*time.Hour)},
+		{"e|" + stamp(2*time.Minute)},
+	}
+
+	var (
+		mu      sync.Mutex
+		filters []string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		filter, err := json.Marshal(body["filter"])
+		require.NoError(t, err)
+
+		mu.Lock()
+		n := len(filters)
+		filters = append(filters, string(filter))
+		mu.Unlock()
+
+		var features []string
+		if n < len(polls) {
+			for _, entry := range polls[n] {
+				id, updated, _ := strings.Cut(entry, "|")
+				features = append(features, fmt.Sprintf(
+					`{"type":"Feature","id":%q,"collection":"s1","properties":{"updated":%q},"geometry":null,"assets":{},"links":[]}`,
+					id, updated))
+			}
+		}
+		w.Header().Set("Content-Type", "application/geo+json")
+		fmt.Fprintf(w, `{"type":"FeatureCollection","features":[%s],"links":[]}`, strings.Join(features, ","))
+	}))
+	defer server.Close()
+
+	cli, err := NewClient(server.URL)
+	require.NoError(t, err)
+
+	state := &WatchState{}
+	var checkpoints int
+	ctx := context.Background()
+	seq := cli.Watch(ctx, SearchParams{Collections: []string{"s1"}}, state,
+		WithWatchInterval(time.Millisecond),
+		WithWatchLookback(30*time.Minute),
+		WithWatchCheckpoint(func(*WatchState) error { checkpoints++; return nil }),
+		WithWatchSince(start.Add(-time.Hour)),
+		withWatchClock(func() time.Time { return start.Add(10 * time.Minute) }),
+	)
+
+	var got []string
+	for event, err := range seq {
+		require.NoError(t, err)
+		got = append(got, event.Type+" "+event.Item.Id)
+		if event.Item.Id == "e" {
+			break
+		}
+	}
+	assert.Equal(t, []string{"new a", "new b", "updated a", "new c", "new d", "new e"}, got)
+	assert.Equal(t, 2, checkpoints)
+
+	require.Len(t, filters, 3)
+	since := func(i int) string {
+		var f struct{ Args []map[string]string }
+		require.NoError(t, json.Unmarshal([]byte(filters[i]), &f))
+		return f.Args[1]["timestamp"]
+	}
+	assert.JSONEq(t, `{"op":">=","args":[{"property":"updated"},{"timestamp":"2024-06-01T10:30:00Z"}]}`, filters[0])
+	assert.Equal(t, "2024-06-01T11:25:00Z", since(1), "the mark follows item timestamps")
+	assert.Equal(t, "2024-06-01T11:40:00Z", since(2), "the future timestamp of d moves the mark only up to the clock")
+	assert.Equal(t, start.Add(10*time.Minute), state.HighWater)
+	assert.NotContains(t, state.Seen, "s1/c", "items older than the lookback window are forgotten")
+	assert.Contains(t, state.Seen, "s1/a")
+
+	t.Run("persisted state", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "state.json")
+		empty, err := LoadWatchState(path)
+		require.NoError(t, err)
+		assert.Equal(t, &WatchState{}, empty)
+
+		require.NoError(t, SaveWatchState(path, state))
+		loaded, err := LoadWatchState(path)
+		require.NoError(t, err)
+		assert.Equal(t, state.Field, loaded.Field)
+		assert.True(t, state.HighWater.Equal(loaded.HighWater))
+		assert.Len(t, loaded.Seen, len(state.Seen))
+
+		for _, err := range cli.Watch(ctx, SearchParams{}, loaded, WithWatchField("created")) {
+			assert.ErrorContains(t, err, `for field "updated"`)
+		}
+	})
+}
+
+func TestClient_Watch_SubSecond(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/geo+json")
+		w.Write([]byte(`{"type":"FeatureCollection","features":[
+			{"type":"Feature","id":"edge","properties":{"updated":"2024-06-01T11:00:00.2Z"},"geometry":null,"assets":{},"links":[]},
+			{"type":"Feature","id":"latest","properties":{"updated":"2024-06-01T12:00:00.5Z"},"geometry":null,"assets":{},"links":[]}
+		],"links":[]}`))
+	}))
+	defer server.Close()
+
+	cli, err := NewClient(server.URL)
+	require.NoError(t, err)
+
+	// The first poll moves the mark to 12:00:00.5; both polls then search
+	// from 11:00:00 and find the item stamped in the sub-second gap.
+	start := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	state := &WatchState{}
+	var got []string
+	for event, err := range cli.Watch(context.Background(), SearchParams{}, state,
+		WithWatchInterval(time.Millisecond),
+		WithWatchLookback(time.Hour),
+		WithWatchSince(start),
+		WithWatchMaxPolls(2),
+		withWatchClock(func() time.Time { return start.Add(10 * time.Minute) }),
+	) {
+		require.NoError(t, err)
+		got = append(got, event.Type+" "+event.Item.Id)
+	}
+	assert.Equal(t, []string{"new edge", "new latest"}, got, "items still in the window are not reported again")
+	assert.Contains(t, state.Seen, "/edge")
+}
+
+func TestClient_Watch_Datetime(t *testing.T) {
+	since := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	var body map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		w.Header().Set("Content-Type", "application/geo+json")
+		w.Write([]byte(`{"type":"FeatureCollection","features":[
+			{"type":"Feature","id":"undated","properties":{},"geometry":null,"assets":{},"links":[]},
+			{"type":"Feature","id":"range","properties":{"datetime":null,"start_datetime":"2024-06-01T06:00:00Z"},"geometry":null,"assets":{},"links":[]}
+		],"links":[]}`))
+	}))
+	defer server.Close()
+
+	cli, err := NewClient(server.URL)
+	require.NoError(t, err)
+
+	var ids []string
+	for event, err := range cli.Watch(context.Background(), SearchParams{}, &WatchState{},
+		WithWatchField("datetime"), WithWatchSince(since), WithWatchLookback(time.Hour), WithWatchMaxPolls(1)) {
+		require.NoError(t, err)
+		ids = append(ids, event.Item.Id)
+	}
+	assert.Equal(t, []string{"range"}, ids)
+	assert.Equal(t, "2024-05-31T23:00:00Z/..", body["datetime"])
+	assert.NotContains(t, body, "filter")
+}
+
+func TestClient_Watch_Cancel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/geo+json")
+		w.Write([]byte(`{"type":"FeatureCollection","features":[],"links":[]}`))
+	}))
+	defer server.Close()
+
+	cli, err := NewClient(server.URL)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	var errs []error
+	for _, err := range cli.Watch(ctx, SearchParams{}, &WatchState{}, WithWatchInterval(10*time.Millisecond)) {
+		errs = append(errs, err)
+	}
+	require.Len(t, errs, 1)
+	assert.ErrorIs(t, errs[0], context.DeadlineExceeded)
+}
@@ -0,0 +1,457 @@
+// Package config loads and saves the named connection profiles shared by
+// stac-cli and the TUI.
+//
+// Profiles live in a YAML file, by default config.yaml in the
+// go-stac-client directory of the user configuration directory
+// ($XDG_CONFIG_HOME/go-stac-client/config.yaml on Linux):
+//
+//	default: earth-search
+//	profiles:
+//	  earth-search:
+//	    url: https://earth-search.aws.element84.com/v1
+//	    page_size: 100
+//	    collections: [sentinel-2-l2a]
+//	    s3:
+//	      anonymous: true
+//	  internal:
+//	    url: https://stac.example.com
+//	    timeout: 1m
+//	    download_dir: ~/data/stac
+//	    auth:
+//	      mode: bearer
+//	      token:
+//	        command: [pass, show, stac/internal]
+//
+// Secrets are read from an environment variable (env) or from the output of
+// a command (command). Plain-text values (value) are only accepted, by Load
+// and Save alike, when the file sets allow_plaintext_secrets: true.
+package config
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/robert-malhotra/go-stac-client/pkg/client"
+	"gopkg.in/yaml.v3"
+)
+
+// ErrProfileNotFound is returned by File.Profile for an unknown name.
+var ErrProfileNotFound = errors.New("profile not found")
+
+// ErrPlaintextSecret is returned when a profile holds a plain-text secret
+// but the file does not allow them.
+var ErrPlaintextSecret = errors.New("plain-text secrets are not allowed; use env or command, or set allow_plaintext_secrets")
+
+// Authentication modes.
+const (
+	AuthNone   = "none"
+	AuthBearer = "bearer"
+	AuthBasic  = "basic"
+	AuthHeader = "header"
+)
+
+// File is the contents of a config file.
+type File struct {
+	// Default names the profile used when none is selected.
+	Default string `yaml:"default,omitempty"`
+
+	// AllowPlaintextSecrets opts in to secrets stored as plain values.
+	AllowPlaintextSecrets bool `yaml:"allow_plaintext_secrets,omitempty"`
+
+	Profiles map[string]*Profile `yaml:"profiles,omitempty"`
+}
+
+// Profile holds the connection settings of one STAC API.
+type Profile struct {
+	URL  string `yaml:"url,omitempty"`
+	Auth Auth   `yaml:"auth,omitempty"`
+
+	// Timeout applies to each HTTP request; zero keeps the default.
+	Timeout time.Duration `yaml:"timeout,omitempty"`
+
+	// PageSize is the limit requested per page of search results.
+	PageSize int `yaml:"page_size,omitempty"`
+
+	// Collections are searched when no collections are given.
+	Collections []string `yaml:"collections,omitempty"`
+
+	S3 *S3 `yaml:"s3,omitempty"`
+
+	// DownloadDir is where assets are saved; a leading ~ is expanded to
+	// the home directory.
+	DownloadDir string `yaml:"download_dir,omitempty"`
+}
+
+// Auth configures how requests are authenticated. When Mode is empty it is
+// inferred from the fields that are set.
+type Auth struct {
+	Mode        string `yaml:"mode,omitempty"`
+	Token       Secret `yaml:"token,omitempty"`
+	Username    string `yaml:"username,omitempty"`
+	Password    Secret `yaml:"password,omitempty"`
+	Header      string `yaml:"header,omitempty"`
+	HeaderValue Secret `yaml:"header_value,omitempty"`
+}
+
+// S3 configures access to s3:// asset hrefs; see client.S3Options.
+type S3 struct {
+	Endpoint      string `yaml:"endpoint,omitempty"`
+	UsePathStyle  bool   `yaml:"use_path_style,omitempty"`
+	Region        string `yaml:"region,omitempty"`
+	Profile       string `yaml:"profile,omitempty"`
+	Anonymous     bool   `yaml:"anonymous,omitempty"`
+	RequesterPays bool   `yaml:"requester_pays,omitempty"`
+}
+
+// Options returns the client options for s.
+func (s S3) Options() client.S3Options {
+	return client.S3Options{
+		Endpoint:      s.Endpoint,
+		UsePathStyle:  s.UsePathStyle,
+		Region:        s.Region,
+		Profile:       s.Profile,
+		Anonymous:     s.Anonymous,
+		RequesterPays: s.RequesterPays,
+	}
+}
+
+// DefaultPath returns the default location of the config file.
+func DefaultPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "go-stac-client", "config.yaml"), nil
+}
+
+// Load reads and validates the config file at path. A missing file is
+// reported with an error wrapping fs.ErrNotExist.
+func Load(path string) (*File, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var f File
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&f); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("error parsing %s: %w", path, err)
+	}
+	if err := f.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &f, nil
+}
+
+// Save validates f and writes it to path, readable only by the user. When
+// path already holds a config, its comments and the order of its keys are
+// kept; the rest of its layout is normalized.
+func (f *File) Save(path string) error {
+	if err := f.Validate(); err != nil {
+		return err
+	}
+	var doc yaml.Node
+	if err := doc.Encode(f); err != nil {
+		return err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	var old yaml.Node
+	if err := yaml.Unmarshal(data, &old); err != nil {
+		return fmt.Errorf("error parsing %s: %w", path, err)
+	}
+	if old.Kind == yaml.DocumentNode && len(old.Content) == 1 {
+		mergeNode(old.Content[0], &doc)
+		doc = old
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return err
+	}
+	if err := enc.Close(); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(buf.Bytes()); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// mergeNode makes dst hold the values of src. Mapping keys present in both
+// keep their position and comments in dst; keys only in src are appended.
+// Other nodes are replaced, keeping their comments, the flow or block style
+// of sequences and the quoting of unchanged scalars.
+func mergeNode(dst, src *yaml.Node) {
+	if dst.Kind != yaml.MappingNode || src.Kind != yaml.MappingNode {
+		head, line, foot, style := dst.HeadComment, dst.LineComment, dst.FootComment, dst.Style
+		keepStyle := dst.Kind == src.Kind && (dst.Kind == yaml.SequenceNode || dst.Value == src.Value)
+		*dst = *src
+		dst.HeadComment, dst.LineComment, dst.FootComment = head, line, foot
+		if keepStyle {
+			dst.Style = style
+		}
+		return
+	}
+	var content []*yaml.Node
+	for i := 0; i+1 < len(dst.Content); i += 2 {
+		if v := mappingValue(src, dst.Content[i].Value); v != nil {
+			mergeNode(dst.Content[i+1], v)
+			content = append(content, dst.Content[i], dst.Content[i+1])
+		}
+	}
+	for i := 0; i+1 < len(src.Content); i += 2 {
+		if mappingValue(dst, src.Content[i].Value) == nil {
+			content = append(content, src.Content[i], src.Content[i+1])
+		}
+	}
+	dst.Content = content
+}
+
+// mappingValue returns the value of key in the mapping node m, or nil.
+func mappingValue(m *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			return m.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// Validate checks every profile, and that Default names one of them.
+func (f *File) Validate() error {
+	if f.Default != "" {
+		if _, ok := f.Profiles[f.Default]; !ok {
+			return fmt.Errorf("default profile %q: %w", f.Default, ErrProfileNotFound)
+		}
+	}
+	for _, name := range f.Names() {
+		p := f.Profiles[name]
+		if p == nil {
+			return fmt.Errorf("profile %q is empty", name)
+		}
+		if err := p.Auth.validate(f.AllowPlaintextSecrets); err != nil {
+			return fmt.Errorf("profile %q: %w", name, err)
+		}
+		if p.Timeout < 0 || p.PageSize < 0 {
+			return fmt.Errorf("profile %q: timeout and page_size must not be negative", name)
+		}
+	}
+	return nil
+}
+
+// Names returns the profile names in sorted order.
+func (f *File) Names() []string {
+	return slices.Sorted(maps.Keys(f.Profiles))
+}
+
+// Profile returns the named profile, or the default profile when name is
+// empty. It returns nil without an error when name is empty and there is
+// no default.
+func (f *File) Profile(name string) (*Profile, error) {
+	if name == "" {
+		name = f.Default
+		if name == "" {
+			return nil, nil
+		}
+	}
+	p, ok := f.Profiles[name]
+	if !ok {
+		return nil, fmt.Errorf("%q: %w", name, ErrProfileNotFound)
+	}
+	return p, nil
+}
+
+// Set adds or replaces the named profile.
+func (f *File) Set(name string, p *Profile) {
+	if f.Profiles == nil {
+		f.Profiles = make(map[string]*Profile)
+	}
+	f.Profiles[name] = p
+}
+
+// DownloadDirectory returns DownloadDir with a leading ~ expanded.
+func (p *Profile) DownloadDirectory() string {
+	dir := p.DownloadDir
+	if dir == "~" || strings.HasPrefix(dir, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, dir[1:])
+		}
+	}
+	return dir
+}
+
+// EffectiveMode returns Mode, or the mode implied by the fields that are
+// set when Mode is empty.
+func (a Auth) EffectiveMode() string {
+	switch {
+	case a.Mode != "":
+		return a.Mode
+	case !a.Token.IsZero():
+		return AuthBearer
+	case a.Username != "":
+		return AuthBasic
+	case a.Header != "":
+		return AuthHeader
+	}
+	return AuthNone
+}
+
+func (a Auth) validate(allowPlaintext bool) error {
+	switch a.EffectiveMode() {
+	case AuthNone:
+	case AuthBearer:
+		if a.Token.IsZero() {
+			return fmt.Errorf("auth: bearer mode needs a token")
+		}
+	case AuthBasic:
+		if a.Username == "" {
+			return fmt.Errorf("auth: basic mode needs a username")
+		}
+	case AuthHeader:
+		if a.Header == "" || a.HeaderValue.IsZero() {
+			return fmt.Errorf("auth: header mode needs a header and a header_value")
+		}
+	default:
+		return fmt.Errorf("auth: unsupported mode %q", a.Mode)
+	}
+	secrets := []struct {
+		name   string
+		secret Secret
+	}{
+		{"token", a.Token},
+		{"password", a.Password},
+		{"header_value", a.HeaderValue},
+	}
+	for _, s := range secrets {
+		if err := s.secret.validate(allowPlaintext); err != nil {
+			return fmt.Errorf("auth.%s: %w", s.name, err)
+		}
+	}
+	return nil
+}
+
+// Credentials are the resolved secrets of an Auth.
+type Credentials struct {
+	Mode        string
+	Token       string
+	Username    string
+	Password    string
+	Header      string
+	HeaderValue string
+}
+
+// Resolve reads the secrets of the effective mode.
+func (a Auth) Resolve(ctx context.Context) (Credentials, error) {
+	c := Credentials{Mode: a.EffectiveMode()}
+	var err error
+	switch c.Mode {
+	case AuthBearer:
+		if c.Token, err = a.Token.Resolve(ctx); err != nil {
+			return c, fmt.Errorf("token: %w", err)
+		}
+	case AuthBasic:
+		c.Username = a.Username
+		if !a.Password.IsZero() {
+			if c.Password, err = a.Password.Resolve(ctx); err != nil {
+				return c, fmt.Errorf("password: %w", err)
+			}
+		}
+	case AuthHeader:
+		c.Header = a.Header
+		if c.HeaderValue, err = a.HeaderValue.Resolve(ctx); err != nil {
+			return c, fmt.Errorf("header_value: %w", err)
+		}
+	}
+	return c, nil
+}
+
+// Secret is a credential read from exactly one source.
+type Secret struct {
+	// Env names an environment variable holding the secret.
+	Env string `yaml:"env,omitempty"`
+
+	// Command is run, without a shell, and its output up to a trailing
+	// newline is the secret, e.g. [pass, show, stac/token].
+	Command []string `yaml:"command,omitempty"`
+
+	// Value is the secret in plain text; see File.AllowPlaintextSecrets.
+	Value string `yaml:"value,omitempty"`
+}
+
+// IsZero reports whether s has no source.
+func (s Secret) IsZero() bool {
+	return s.Env == "" && len(s.Command) == 0 && s.Value == ""
+}
+
+func (s Secret) validate(allowPlaintext bool) error {
+	sources := 0
+	for _, set := range []bool{s.Env != "", len(s.Command) > 0, s.Value != ""} {
+		if set {
+			sources++
+		}
+	}
+	switch {
+	case sources > 1:
+		return fmt.Errorf("set only one of env, command and value")
+	case s.Value != "" && !allowPlaintext:
+		return ErrPlaintextSecret
+	}
+	return nil
+}
+
+// Resolve returns the secret.
+func (s Secret) Resolve(ctx context.Context) (string, error) {
+	switch {
+	case s.Env != "":
+		v := os.Getenv(s.Env)
+		if v == "" {
+			return "", fmt.Errorf("environment variable %s is not set", s.Env)
+		}
+		return v, nil
+	case len(s.Command) > 0:
+		var stderr bytes.Buffer
+		cmd := exec.CommandContext(ctx, s.Command[0], s.Command[1:]...)
+		cmd.Stderr = &stderr
+		out, err := cmd.Output()
+		if err != nil {
+			if msg := strings.TrimSpace(stderr.String()); msg != "" {
+				return "", fmt.Errorf("command %s: %w: %s", s.Command[0], err, msg)
+			}
+			return "", fmt.Errorf("command %s: %w", s.Command[0], err)
+		}
+		v := strings.TrimRight(string(out), "\r\n")
+		if v == "" {
+			return "", fmt.Errorf("command %s printed nothing", s.Command[0])
+		}
+		return v, nil
+	}
+	return s.Value, nil
+}
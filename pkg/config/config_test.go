@@ -0,0 +1,213 @@
+package config_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/robert-malhotra/go-stac-client/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	path := writeConfig(t, `
+default: internal
+profiles:
+  earth-search:
+    url: https://earth-search.aws.element84.com/v1
+    page_size: 100
+    collections: [sentinel-2-l2a, landsat-c2-l2]
+    s3:
+      anonymous: true
+      region: us-west-2
+  internal:
+    url: https://stac.example.com
+    timeout: 1m30s
+    download_dir: ~/data
+    auth:
+      token:
+        env: TEST_STAC_TOKEN
+`)
+	f, err := config.Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"earth-search", "internal"}, f.Names())
+
+	p, err := f.Profile("")
+	require.NoError(t, err)
+	assert.Equal(t, "https://stac.example.com", p.URL)
+	assert.Equal(t, 90*time.Second, p.Timeout)
+	assert.Equal(t, config.AuthBearer, p.Auth.EffectiveMode())
+	home, err := os.UserHomeDir()
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(home, "data"), p.DownloadDirectory())
+
+	p, err = f.Profile("earth-search")
+	require.NoError(t, err)
+	assert.Equal(t, 100, p.PageSize)
+	assert.Equal(t, []string{"sentinel-2-l2a", "landsat-c2-l2"}, p.Collections)
+	require.NotNil(t, p.S3)
+	assert.True(t, p.S3.Options().Anonymous)
+	assert.Equal(t, "us-west-2", p.S3.Options().Region)
+	assert.Equal(t, config.AuthNone, p.Auth.EffectiveMode())
+
+	_, err = f.Profile("missing")
+	assert.ErrorIs(t, err, config.ErrProfileNotFound)
+}
+
+func TestLoad_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    error
+	}{
+		{"plaintext secret", "profiles:\n  a:\n    auth:\n      token:\n        value: secret\n", config.ErrPlaintextSecret},
+		{"unknown default", "default: b\nprofiles:\n  a:\n    url: https://example.com\n", config.ErrProfileNotFound},
+		{"two sources", "profiles:\n  a:\n    auth:\n      token:\n        env: A\n        command: [b]\n", nil},
+		{"missing header value", "profiles:\n  a:\n    auth:\n      mode: header\n      header: X-Key\n", nil},
+		{"unknown mode", "profiles:\n  a:\n    auth:\n      mode: oauth\n", nil},
+		{"unknown field", "profiles:\n  a:\n    base_url: https://example.com\n", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := config.Load(writeConfig(t, tt.content))
+			require.Error(t, err)
+			if tt.want != nil {
+				assert.ErrorIs(t, err, tt.want)
+			}
+		})
+	}
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := config.Load(filepath.Join(t.TempDir(), "config.yaml"))
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
+
+func TestLoad_PlaintextOptIn(t *testing.T) {
+	f, err := config.Load(writeConfig(t, `
+allow_plaintext_secrets: true
+profiles:
+  a:
+    auth:
+      username: alice
+      password:
+        value: secret
+`))
+	require.NoError(t, err)
+	creds, err := f.Profiles["a"].Auth.Resolve(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, config.Credentials{Mode: config.AuthBasic, Username: "alice", Password: "secret"}, creds)
+}
+
+func TestSave(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "go-stac-client", "config.yaml")
+
+	var f config.File
+	f.Set("a", &config.Profile{
+		URL:     "https://example.com",
+		Timeout: time.Minute,
+		Auth:    config.Auth{Mode: config.AuthBearer, Token: config.Secret{Value: "secret"}},
+	})
+	assert.ErrorIs(t, f.Save(path), config.ErrPlaintextSecret)
+	_, err := os.Stat(path)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	f.Profiles["a"].Auth.Token = config.Secret{Env: "STAC_TOKEN"}
+	require.NoError(t, f.Save(path))
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "timeout: 1m0s")
+
+	loaded, err := config.Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, &f, loaded)
+
+	t.Run("opt in", func(t *testing.T) {
+		f.AllowPlaintextSecrets = true
+		f.Profiles["a"].Auth.Token = config.Secret{Value: "secret"}
+		require.NoError(t, f.Save(path))
+		loaded, err := config.Load(path)
+		require.NoError(t, err)
+		assert.Equal(t, "secret", loaded.Profiles["a"].Auth.Token.Value)
+	})
+}
+
+func TestSave_KeepsComments(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`# Written by hand.
+default: b # used by cron
+
+profiles:
+  # The public mirror.
+  b:
+    url: https://b.example.com # old address
+    collections: [s1, s2]
+  a:
+    url: https://a.example.com
+  gone:
+    url: https://gone.example.com
+`), 0o600))
+
+	f, err := config.Load(path)
+	require.NoError(t, err)
+	f.Profiles["b"].URL = "https://b2.example.com"
+	delete(f.Profiles, "gone")
+	f.Set("c", &config.Profile{URL: "https://c.example.com"})
+	require.NoError(t, f.Save(path))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, `# Written by hand.
+default: b # used by cron
+profiles:
+  # The public mirror.
+  b:
+    url: https://b2.example.com # old address
+    collections: [s1, s2]
+  a:
+    url: https://a.example.com
+  c:
+    url: https://c.example.com
+`, string(data))
+
+	loaded, err := config.Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, f, loaded)
+}
+
+func TestSecret_Resolve(t *testing.T) {
+	ctx := context.Background()
+
+	t.Setenv("TEST_STAC_SECRET", "from-env")
+	v, err := config.Secret{Env: "TEST_STAC_SECRET"}.Resolve(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "from-env", v)
+
+	_, err = config.Secret{Env: "TEST_STAC_UNSET"}.Resolve(ctx)
+	assert.ErrorContains(t, err, "TEST_STAC_UNSET is not set")
+
+	v, err = config.Secret{Command: []string{"echo", "from-command"}}.Resolve(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "from-command", v)
+
+	_, err = config.Secret{Command: []string{"false"}}.Resolve(ctx)
+	assert.Error(t, err)
+
+	v, err = config.Secret{Value: "plain"}.Resolve(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "plain", v)
+}
@@ -0,0 +1,529 @@
+// Package mirror copies a collection from a STAC API into a local static
+// catalog and keeps the copy up to date.
+//
+// A mirror directory holds:
+//
+//	collection.json        the collection, linking to its items
+//	ITEM/ITEM.json         each item, linking back to the collection
+//	ITEM/ASSET.ext         downloaded assets (with WithAssets)
+//	.stac-mirror.json      the state of the last sync
+//
+// Sync lists every item of the collection and writes only those whose
+// "updated" timestamp changed since the last sync, or whose checksum
+// changed when either side has no "updated". Assets are downloaded again
+// only when their href or file:checksum changed. Deletions are detected
+// with WithDeletions, and WithDryRun reports the changes without making
+// them.
+//
+//	summary, err := mirror.Sync(ctx, cli, "sentinel-2-l2a", "mirror/s2",
+//		mirror.WithAssets("thumbnail"), mirror.WithDeletions())
+package mirror
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/robert-malhotra/go-stac-client/pkg/client"
+	"github.com/robert-malhotra/go-stac-client/pkg/stac"
+)
+
+// StateFile is the name of the state file in a mirror directory.
+const StateFile = ".stac-mirror.json"
+
+// collectionFile is the name of the collection document.
+const collectionFile = "collection.json"
+
+// Option configures Sync.
+type Option func(*config)
+
+type config struct {
+	assets    bool
+	assetKeys []string
+	deletions bool
+	dryRun    bool
+	progress  func(Change)
+}
+
+// WithAssets downloads the assets with the given keys, or all assets when
+// none are given, next to each item. Their hrefs in the local item point to
+// the downloaded files; other assets keep their remote hrefs.
+func WithAssets(keys ...string) Option {
+	return func(cfg *config) {
+		cfg.assets = true
+		cfg.assetKeys = keys
+	}
+}
+
+// WithDeletions removes local items that are no longer in the collection.
+func WithDeletions() Option {
+	return func(cfg *config) { cfg.deletions = true }
+}
+
+// WithDryRun reports what a sync would change without writing anything.
+func WithDryRun() Option {
+	return func(cfg *config) { cfg.dryRun = true }
+}
+
+// WithProgress calls fn for every item change as it is made.
+func WithProgress(fn func(Change)) Option {
+	return func(cfg *config) { cfg.progress = fn }
+}
+
+// ChangeType is the kind of change made to a local item.
+type ChangeType string
+
+const (
+	Added   ChangeType = "added"
+	Updated ChangeType = "updated"
+	Deleted ChangeType = "deleted"
+)
+
+// Change describes one added, updated or deleted item.
+type Change struct {
+	Type   ChangeType
+	ItemID string
+	// Assets are the keys of the assets downloaded for the item.
+	Assets []string
+}
+
+// String formats the change as a diff line, e.g. "+ item-1".
+func (c Change) String() string {
+	mark := map[ChangeType]string{Added: "+", Updated: "~", Deleted: "-"}[c.Type]
+	s := mark + " " + c.ItemID
+	if len(c.Assets) > 0 {
+		s += " (" + strings.Join(c.Assets, ", ") + ")"
+	}
+	return s
+}
+
+// Summary is the outcome of a sync.
+type Summary struct {
+	Collection string
+	// CollectionChanged reports whether the collection metadata changed.
+	CollectionChanged bool
+	Changes           []Change
+	Unchanged         int
+	// Assets is the number of assets downloaded.
+	Assets int
+	DryRun bool
+}
+
+// Count returns the number of changes of type t.
+func (s *Summary) Count(t ChangeType) int {
+	n := 0
+	for _, c := range s.Changes {
+		if c.Type == t {
+			n++
+		}
+	}
+	return n
+}
+
+// String formats the totals of the summary.
+func (s *Summary) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s: %d added, %d updated, %d deleted, %d unchanged, %d assets",
+		s.Collection, s.Count(Added), s.Count(Updated), s.Count(Deleted), s.Unchanged, s.Assets)
+	if s.CollectionChanged {
+		b.WriteString(", collection metadata changed")
+	}
+	if s.DryRun {
+		b.WriteString(" (dry run)")
+	}
+	return b.String()
+}
+
+// state is the contents of StateFile.
+type state struct {
+	Collection string               `json:"collection"`
+	Checksum   string               `json:"checksum"`
+	Items      map[string]itemState `json:"items"`
+}
+
+type itemState struct {
+	Updated  string                `json:"updated,omitempty"`
+	Checksum string                `json:"checksum"`
+	Assets   map[string]assetState `json:"assets,omitempty"`
+}
+
+type assetState struct {
+	File     string `json:"file"`
+	Href     string `json:"href"`
+	Checksum string `json:"checksum,omitempty"` // file:checksum
+}
+
+// Sync mirrors the collection into dir, creating it if needed, and returns
+// what changed. The state is saved even when the sync fails part way, so
+// the next run continues with the items that were not written.
+func Sync(ctx context.Context, cli *client.Client, collectionID, dir string, opts ...Option) (*Summary, error) {
+	var cfg config
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	s := &syncer{cli: cli, dir: dir, cfg: cfg}
+	return s.run(ctx, collectionID)
+}
+
+type syncer struct {
+	cli     *client.Client
+	dir     string
+	cfg     config
+	summary Summary
+}
+
+func (s *syncer) run(ctx context.Context, collectionID string) (summary *Summary, err error) {
+	s.summary = Summary{Collection: collectionID, DryRun: s.cfg.dryRun}
+
+	prev, err := loadState(filepath.Join(s.dir, StateFile))
+	if err != nil {
+		return nil, err
+	}
+	if prev.Collection != "" && prev.Collection != collectionID {
+		return nil, fmt.Errorf("%s is a mirror of collection %s", s.dir, prev.Collection)
+	}
+
+	col, err := s.cli.GetCollection(ctx, collectionID)
+	if err != nil {
+		return nil, err
+	}
+	colSum, err := checksum(col)
+	if err != nil {
+		return nil, err
+	}
+	s.summary.CollectionChanged = prev.Checksum != "" && colSum != prev.Checksum
+
+	next := &state{Collection: collectionID, Checksum: prev.Checksum, Items: maps.Clone(prev.Items)}
+	if next.Items == nil {
+		next.Items = make(map[string]itemState)
+	}
+	if !s.cfg.dryRun {
+		if err := os.MkdirAll(s.dir, 0o755); err != nil {
+			return nil, err
+		}
+		defer func() {
+			if saveErr := saveJSON(filepath.Join(s.dir, StateFile), next); err == nil && saveErr != nil {
+				summary, err = nil, saveErr
+			}
+		}()
+	}
+
+	seen := make(map[string]bool)
+	for item, err := range s.cli.GetItems(ctx, collectionID) {
+		if err != nil {
+			return nil, err
+		}
+		if seen[item.Id] {
+			continue
+		}
+		seen[item.Id] = true
+		if err := s.syncItem(ctx, item, prev.Items, next.Items); err != nil {
+			return nil, fmt.Errorf("item %s: %w", item.Id, err)
+		}
+	}
+
+	for _, id := range slices.Sorted(maps.Keys(prev.Items)) {
+		if seen[id] || !s.cfg.deletions {
+			continue
+		}
+		if !s.cfg.dryRun {
+			dirName, err := pathName(id)
+			if err != nil {
+				return nil, fmt.Errorf("item %s: %w", id, err)
+			}
+			if err := os.RemoveAll(filepath.Join(s.dir, dirName)); err != nil {
+				return nil, err
+			}
+		}
+		delete(next.Items, id)
+		s.record(Change{Type: Deleted, ItemID: id})
+	}
+
+	if !s.cfg.dryRun {
+		if err := s.writeCollection(col, next); err != nil {
+			return nil, err
+		}
+		next.Checksum = colSum
+	}
+	return &s.summary, nil
+}
+
+// syncItem writes item if it is new or changed, and records its state.
+func (s *syncer) syncItem(ctx context.Context, item *stac.Item, prev, next map[string]itemState) error {
+	dirName, err := pathName(item.Id)
+	if err != nil {
+		return err
+	}
+	sum, err := checksum(item)
+	if err != nil {
+		return err
+	}
+	updated, _ := item.Properties["updated"].(string)
+	old, exists := prev[item.Id]
+
+	changed := !exists
+	switch {
+	case !exists:
+	case updated != "" && old.Updated != "":
+		changed = updated != old.Updated
+	default:
+		changed = sum != old.Checksum
+	}
+	itemPath := filepath.Join(s.dir, dirName, dirName+".json")
+	if !changed && !s.cfg.dryRun && !fileExists(itemPath) {
+		changed = true
+	}
+
+	base := ""
+	if self := item.Self(); self != nil {
+		base = self.Href
+	}
+	downloads, err := s.assetDownloads(item, base, dirName, old)
+	if err != nil {
+		return err
+	}
+	if !changed && len(downloads) == 0 {
+		s.summary.Unchanged++
+		return nil
+	}
+
+	change := Change{Type: Updated, ItemID: item.Id, Assets: slices.Sorted(maps.Keys(downloads))}
+	if !exists {
+		change.Type = Added
+	}
+	s.summary.Assets += len(downloads)
+	if s.cfg.dryRun {
+		s.record(change)
+		return nil
+	}
+
+	st := itemState{Updated: updated, Checksum: sum}
+	if err := os.MkdirAll(filepath.Join(s.dir, dirName), 0o755); err != nil {
+		return err
+	}
+	for _, key := range slices.Sorted(maps.Keys(item.Assets)) {
+		asset := item.Assets[key]
+		if asset == nil {
+			continue
+		}
+		href, err := asset.ResolveHref(base)
+		if err != nil {
+			return fmt.Errorf("asset %s: %w", key, err)
+		}
+		a, ok := downloads[key]
+		if !ok {
+			a, ok = old.Assets[key]
+			ok = ok && s.wantAsset(key)
+		}
+		if !ok {
+			asset.Href = href
+			continue
+		}
+		if _, download := downloads[key]; download {
+			dest := client.AtomicFileDestination(filepath.Join(s.dir, dirName, a.File))
+			if err := s.cli.DownloadAssetToDestination(ctx, href, dest, nil); err != nil {
+				return fmt.Errorf("asset %s: %w", key, err)
+			}
+		}
+		if st.Assets == nil {
+			st.Assets = make(map[string]assetState)
+		}
+		st.Assets[key] = a
+		asset.Href = "./" + a.File
+	}
+	// Remove files of assets that were dropped or renamed.
+	for key, a := range old.Assets {
+		if cur, ok := st.Assets[key]; !ok || cur.File != a.File {
+			os.Remove(filepath.Join(s.dir, dirName, a.File))
+		}
+	}
+
+	if err := localizeLinks(&item.Links, base, []*stac.Link{
+		{Rel: stac.RelRoot, Href: "../" + collectionFile, Type: "application/json"},
+		{Rel: stac.RelParent, Href: "../" + collectionFile, Type: "application/json"},
+		{Rel: stac.RelCollection, Href: "../" + collectionFile, Type: "application/json"},
+	}); err != nil {
+		return err
+	}
+	if err := saveJSON(itemPath, item); err != nil {
+		return err
+	}
+	next[item.Id] = st
+	s.record(change)
+	return nil
+}
+
+// assetDownloads returns the wanted assets of item that are new, whose href
+// or file:checksum changed, or whose file is missing.
+func (s *syncer) assetDownloads(item *stac.Item, base, dirName string, old itemState) (map[string]assetState, error) {
+	if !s.cfg.assets {
+		return nil, nil
+	}
+	downloads := make(map[string]assetState)
+	for key, asset := range item.Assets {
+		if asset == nil || !s.wantAsset(key) {
+			continue
+		}
+		href, err := asset.ResolveHref(base)
+		if err != nil {
+			return nil, fmt.Errorf("asset %s: %w", key, err)
+		}
+		name, err := pathName(key)
+		if err != nil {
+			return nil, fmt.Errorf("asset %s: %w", key, err)
+		}
+		if u, err := url.Parse(href); err == nil {
+			name += path.Ext(u.Path)
+		}
+		sum, _ := asset.AdditionalFields["file:checksum"].(string)
+		a := assetState{File: name, Href: href, Checksum: sum}
+
+		if prev, ok := old.Assets[key]; ok && prev == a && fileExists(filepath.Join(s.dir, dirName, name)) {
+			continue
+		}
+		downloads[key] = a
+	}
+	return downloads, nil
+}
+
+func (s *syncer) wantAsset(key string) bool {
+	return s.cfg.assets && (len(s.cfg.assetKeys) == 0 || slices.Contains(s.cfg.assetKeys, key))
+}
+
+func (s *syncer) record(c Change) {
+	s.summary.Changes = append(s.summary.Changes, c)
+	if s.cfg.progress != nil {
+		s.cfg.progress(c)
+	}
+}
+
+// writeCollection writes collection.json with item links to the local
+// items, unless the file already has that content.
+func (s *syncer) writeCollection(col *stac.Collection, st *state) error {
+	links := []*stac.Link{{Rel: stac.RelRoot, Href: "./" + collectionFile, Type: "application/json"}}
+	for _, id := range slices.Sorted(maps.Keys(st.Items)) {
+		dirName, err := pathName(id)
+		if err != nil {
+			return err
+		}
+		links = append(links, &stac.Link{Rel: stac.RelItem, Href: "./" + dirName + "/" + dirName + ".json", Type: "application/geo+json"})
+	}
+	// Asset hrefs relative to the remote collection would dangle locally.
+	if err := col.MakeHrefsAbsolute(""); err != nil && !errors.Is(err, stac.ErrNoSelfLink) {
+		return err
+	}
+	if err := localizeLinks(&col.Links, "", links); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(col, "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	path := filepath.Join(s.dir, collectionFile)
+	if existing, err := os.ReadFile(path); err == nil && string(existing) == string(data) {
+		return nil
+	}
+	return writeFile(path, data)
+}
+
+// localizeLinks drops the links that tie a document to its place in the
+// API, makes the remaining hrefs absolute and adds local.
+func localizeLinks(links *[]*stac.Link, base string, local []*stac.Link) error {
+	var kept []*stac.Link
+	for _, l := range *links {
+		if l == nil {
+			continue
+		}
+		switch l.Rel {
+		case stac.RelSelf, stac.RelRoot, stac.RelParent, stac.RelCollection, stac.RelItem, stac.RelItems, "next", "prev":
+			continue
+		}
+		href, err := l.ResolveHref(base)
+		if err != nil {
+			return fmt.Errorf("link %q: %w", l.Rel, err)
+		}
+		l.Href = href
+		kept = append(kept, l)
+	}
+	*links = append(local, kept...)
+	return nil
+}
+
+// pathName returns the file name used for an item ID or asset key.
+func pathName(id string) (string, error) {
+	name := url.PathEscape(id)
+	if name == "" || name == "." || name == ".." || strings.HasPrefix(name, ".stac-") {
+		return "", fmt.Errorf("%q cannot be used as a file name", id)
+	}
+	return name, nil
+}
+
+// checksum returns the SHA-256 of the JSON encoding of v.
+func checksum(v any) (string, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:]), nil
+}
+
+func loadState(path string) (*state, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return &state{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var st state
+	if err := json.Unmarshal(data, &st); err != nil {
+		return nil, fmt.Errorf("error decoding %s: %w", path, err)
+	}
+	return &st, nil
+}
+
+func saveJSON(path string, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFile(path, append(data, '\n'))
+}
+
+// writeFile replaces the file at path atomically, the way assets are
+// downloaded.
+func writeFile(path string, data []byte) error {
+	dest := client.AtomicFileDestination(path)
+	w, err := dest.Open(context.Background(), int64(len(data)))
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(data); err != nil {
+		dest.Abort()
+		return err
+	}
+	if err := dest.Commit(); err != nil {
+		dest.Abort()
+		return err
+	}
+	return nil
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
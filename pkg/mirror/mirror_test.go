@@ -0,0 +1,230 @@
+package mirror_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/robert-malhotra/go-stac-client/pkg/client"
+	"github.com/robert-malhotra/go-stac-client/pkg/mirror"
+	"github.com/robert-malhotra/go-stac-client/pkg/stac"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeAPI serves one collection whose items can be changed between syncs.
+type fakeAPI struct {
+	mu        sync.Mutex
+	items     map[string]string // id -> updated
+	order     []string
+	downloads int
+}
+
+func (f *fakeAPI) set(id, updated string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.items[id]; !ok {
+		f.order = append(f.order, id)
+	}
+	f.items[id] = updated
+}
+
+func (f *fakeAPI) remove(id string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.items, id)
+	for i, o := range f.order {
+		if o == id {
+			f.order = append(f.order[:i], f.order[i+1:]...)
+			break
+		}
+	}
+}
+
+func (f *fakeAPI) handler() http.Handler {
+	mux := http.NewServeMux()
+	var server string
+	mux.HandleFunc("/collections/col", func(w http.ResponseWriter, r *http.Request) {
+		server = "http://" + r.Host
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"type":"Collection","stac_version":"1.0.0","id":"col","description":"test","license":"CC-BY-4.0",
+			"extent":{"spatial":{"bbox":[[-180,-90,180,90]]},"temporal":{"interval":[[null,null]]}},
+			"links":[{"rel":"self","href":"%[1]s/collections/col"},{"rel":"license","href":"LICENSE"}],
+			"assets":{"thumbnail":{"href":"thumb.png"}}}`, server)
+	})
+	mux.HandleFunc("/collections/col/items", func(w http.ResponseWriter, r *http.Request) {
+		server = "http://" + r.Host
+		f.mu.Lock()
+		order := append([]string(nil), f.order...)
+		items := make(map[string]string, len(f.items))
+		for k, v := range f.items {
+			items[k] = v
+		}
+		f.mu.Unlock()
+
+		// One item per page, to exercise pagination.
+		page := 0
+		fmt.Sscan(r.URL.Query().Get("page"), &page)
+		var features, links []string
+		if page < len(order) {
+			id := order[page]
+			features = append(features, fmt.Sprintf(`{"type":"Feature","stac_version":"1.0.0","id":%[1]q,"collection":"col",
+				"properties":{"datetime":"2024-01-01T00:00:00Z","updated":%[2]q},"geometry":null,
+				"links":[{"rel":"self","href":"%[3]s/collections/col/items/%[1]s"},{"rel":"via","href":"../../../about"}],
+				"assets":{"data":{"href":"/files/%[1]s.tif","file:checksum":%[2]q},"metadata":{"href":"/files/%[1]s.xml"}}}`,
+				id, items[id], server))
+		}
+		if page+1 < len(order) {
+			links = append(links, fmt.Sprintf(`{"rel":"next","href":"%s/collections/col/items?page=%d"}`, server, page+1))
+		}
+		w.Header().Set("Content-Type", "application/geo+json")
+		fmt.Fprintf(w, `{"type":"FeatureCollection","features":[%s],"links":[%s]}`,
+			strings.Join(features, ","), strings.Join(links, ","))
+	})
+	mux.HandleFunc("/files/", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		f.downloads++
+		f.mu.Unlock()
+		fmt.Fprint(w, "content of "+r.URL.Path)
+	})
+	return mux
+}
+
+func TestSync(t *testing.T) {
+	api := &fakeAPI{items: make(map[string]string)}
+	api.set("a", "2024-01-01T00:00:00Z")
+	api.set("b", "2024-01-01T00:00:00Z")
+	server := httptest.NewServer(api.handler())
+	defer server.Close()
+
+	cli, err := client.NewClient(server.URL)
+	require.NoError(t, err)
+	ctx := context.Background()
+	dir := filepath.Join(t.TempDir(), "mirror")
+
+	var progress []string
+	summary, err := mirror.Sync(ctx, cli, "col", dir, mirror.WithAssets("data"),
+		mirror.WithProgress(func(c mirror.Change) { progress = append(progress, c.String()) }))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"+ a (data)", "+ b (data)"}, progress)
+	assert.Equal(t, "col: 2 added, 0 updated, 0 deleted, 0 unchanged, 2 assets", summary.String())
+	assert.Equal(t, 2, api.downloads)
+
+	data, err := os.ReadFile(filepath.Join(dir, "a", "data.tif"))
+	require.NoError(t, err)
+	assert.Equal(t, "content of /files/a.tif", string(data))
+
+	// Mirrored files get the mode os.Create gives.
+	ref, err := os.Create(filepath.Join(t.TempDir(), "ref"))
+	require.NoError(t, err)
+	ref.Close()
+	refInfo, err := os.Stat(ref.Name())
+	require.NoError(t, err)
+	for _, name := range []string{"collection.json", "a/a.json", "a/data.tif"} {
+		info, err := os.Stat(filepath.Join(dir, name))
+		require.NoError(t, err)
+		assert.Equal(t, refInfo.Mode().Perm(), info.Mode().Perm(), name)
+	}
+
+	var item stac.Item
+	readJSON(t, filepath.Join(dir, "a", "a.json"), &item)
+	assert.Equal(t, "./data.tif", item.Assets["data"].Href)
+	assert.Equal(t, server.URL+"/files/a.xml", item.Assets["metadata"].Href, "assets not mirrored keep a remote href")
+	assert.Equal(t, "../collection.json", item.Root().Href)
+	assert.Equal(t, "../collection.json", item.CollectionLink().Href)
+	assert.Nil(t, item.Self())
+	assert.Equal(t, server.URL+"/about", item.LinksByRel("via")[0].Href)
+
+	var col stac.Collection
+	readJSON(t, filepath.Join(dir, "collection.json"), &col)
+	assert.Equal(t, "col", col.Id)
+	assert.Nil(t, col.Self())
+	assert.Equal(t, "./collection.json", col.Root().Href)
+	var itemLinks []string
+	for _, l := range col.LinksByRel(stac.RelItem) {
+		itemLinks = append(itemLinks, l.Href)
+	}
+	assert.Equal(t, []string{"./a/a.json", "./b/b.json"}, itemLinks)
+	assert.Equal(t, server.URL+"/collections/LICENSE", col.LinksByRel("license")[0].Href)
+	assert.Equal(t, server.URL+"/collections/thumb.png", col.Assets["thumbnail"].Href)
+
+	t.Run("unchanged", func(t *testing.T) {
+		summary, err := mirror.Sync(ctx, cli, "col", dir, mirror.WithAssets("data"))
+		require.NoError(t, err)
+		assert.Empty(t, summary.Changes)
+		assert.Equal(t, 2, summary.Unchanged)
+		assert.False(t, summary.CollectionChanged)
+		assert.Equal(t, 2, api.downloads)
+	})
+
+	api.set("a", "2024-02-01T00:00:00Z")
+	api.set("c", "2024-02-01T00:00:00Z")
+	api.remove("b")
+
+	t.Run("dry run", func(t *testing.T) {
+		before, err := os.ReadFile(filepath.Join(dir, mirror.StateFile))
+		require.NoError(t, err)
+
+		summary, err := mirror.Sync(ctx, cli, "col", dir, mirror.WithAssets("data"), mirror.WithDeletions(), mirror.WithDryRun())
+		require.NoError(t, err)
+		assert.Equal(t, []mirror.Change{
+			{Type: mirror.Updated, ItemID: "a", Assets: []string{"data"}},
+			{Type: mirror.Added, ItemID: "c", Assets: []string{"data"}},
+			{Type: mirror.Deleted, ItemID: "b"},
+		}, summary.Changes)
+		assert.Equal(t, "col: 1 added, 1 updated, 1 deleted, 0 unchanged, 2 assets (dry run)", summary.String())
+
+		after, err := os.ReadFile(filepath.Join(dir, mirror.StateFile))
+		require.NoError(t, err)
+		assert.Equal(t, string(before), string(after))
+		assert.DirExists(t, filepath.Join(dir, "b"))
+		assert.NoDirExists(t, filepath.Join(dir, "c"))
+		assert.Equal(t, 2, api.downloads)
+	})
+
+	t.Run("without deletions", func(t *testing.T) {
+		summary, err := mirror.Sync(ctx, cli, "col", dir)
+		require.NoError(t, err)
+		assert.Equal(t, "col: 1 added, 1 updated, 0 deleted, 0 unchanged, 0 assets", summary.String())
+		assert.DirExists(t, filepath.Join(dir, "b"))
+		assert.NoFileExists(t, filepath.Join(dir, "a", "data.tif"), "files of assets no longer mirrored are removed")
+	})
+
+	t.Run("with deletions", func(t *testing.T) {
+		summary, err := mirror.Sync(ctx, cli, "col", dir, mirror.WithAssets("data"), mirror.WithDeletions())
+		require.NoError(t, err)
+		assert.Equal(t, []mirror.Change{
+			{Type: mirror.Updated, ItemID: "a", Assets: []string{"data"}},
+			{Type: mirror.Updated, ItemID: "c", Assets: []string{"data"}},
+			{Type: mirror.Deleted, ItemID: "b"},
+		}, summary.Changes)
+		assert.NoDirExists(t, filepath.Join(dir, "b"))
+		assert.FileExists(t, filepath.Join(dir, "c", "data.tif"))
+
+		readJSON(t, filepath.Join(dir, "collection.json"), &col)
+		itemLinks = nil
+		for _, l := range col.LinksByRel(stac.RelItem) {
+			itemLinks = append(itemLinks, l.Href)
+		}
+		assert.Equal(t, []string{"./a/a.json", "./c/c.json"}, itemLinks)
+	})
+
+	t.Run("other collection", func(t *testing.T) {
+		_, err := mirror.Sync(ctx, cli, "other", dir)
+		assert.ErrorContains(t, err, "is a mirror of collection col")
+	})
+}
+
+func readJSON(t *testing.T, path string, v any) {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(data, v))
+}
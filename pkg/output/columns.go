@@ -0,0 +1,130 @@
+package output
+
+import (
+	"encoding/json"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/robert-malhotra/go-stac-client/pkg/stac"
+)
+
+// toFields returns the JSON object form of doc, which columns are looked up
+// in.
+func toFields(doc any) (map[string]any, error) {
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// defaultColumns returns the columns used when none were selected; all adds
+// the flattened properties of an item.
+func defaultColumns(doc any, fields map[string]any, all bool) []string {
+	if _, ok := doc.(*stac.Collection); ok {
+		if all {
+			return []string{"id", "title", "license", "description"}
+		}
+		return []string{"id", "title"}
+	}
+
+	columns := []string{"id", "collection", "datetime"}
+	if !all {
+		return columns
+	}
+	props, _ := fields["properties"].(map[string]any)
+	flat := make(map[string]bool)
+	flatten("", props, flat)
+	delete(flat, "datetime")
+	return append(columns, slices.Sorted(maps.Keys(flat))...)
+}
+
+// flatten records the dotted paths of the non-object values in m.
+func flatten(prefix string, m map[string]any, out map[string]bool) {
+	for k, v := range m {
+		if nested, ok := v.(map[string]any); ok && len(nested) > 0 {
+			flatten(prefix+k+".", nested, out)
+			continue
+		}
+		out[prefix+k] = true
+	}
+}
+
+// row returns the cells of fields for columns.
+func row(fields map[string]any, columns []string) []string {
+	cells := make([]string, len(columns))
+	for i, col := range columns {
+		if v, ok := lookup(fields, col); ok {
+			cells[i] = cell(v)
+		}
+	}
+	return cells
+}
+
+// lookup finds column in the top-level fields or, failing that, the
+// properties.
+func lookup(fields map[string]any, column string) (any, bool) {
+	if v, ok := lookupPath(fields, column); ok {
+		return v, true
+	}
+	return lookupPath(fields["properties"], column)
+}
+
+// lookupPath resolves a dotted path in v. Keys may themselves contain dots,
+// so the longest matching key is tried first; array elements are selected
+// by index.
+func lookupPath(v any, path string) (any, bool) {
+	switch v := v.(type) {
+	case map[string]any:
+		if x, ok := v[path]; ok {
+			return x, true
+		}
+		for i := len(path) - 1; i > 0; i-- {
+			if path[i] != '.' {
+				continue
+			}
+			if x, ok := v[path[:i]]; ok {
+				if r, ok := lookupPath(x, path[i+1:]); ok {
+					return r, true
+				}
+			}
+		}
+	case []any:
+		head, rest, nested := strings.Cut(path, ".")
+		i, err := strconv.Atoi(head)
+		if err != nil || i < 0 || i >= len(v) {
+			return nil, false
+		}
+		if !nested {
+			return v[i], true
+		}
+		return lookupPath(v[i], rest)
+	}
+	return nil, false
+}
+
+// cell formats a value for CSV and tables: strings as they are, numbers
+// without exponents and anything else as JSON.
+func cell(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
@@ -0,0 +1,327 @@
+// Package output renders streams of STAC items and collections as
+// newline-delimited JSON, a GeoJSON FeatureCollection, CSV or an aligned
+// text table.
+//
+// Every format streams: documents are written as they arrive rather than
+// collected first, so a Writer can sit at the end of a search that pages
+// through millions of items.
+//
+//	w, err := output.NewWriter(os.Stdout, output.CSV,
+//		output.WithColumns("id", "datetime", "eo:cloud_cover", "proj:centroid.lat"))
+//	if err != nil {
+//		return err
+//	}
+//	for item, err := range cli.SearchCQL2(ctx, params) {
+//		if err != nil {
+//			return err
+//		}
+//		if err := w.Write(item); err != nil {
+//			return err
+//		}
+//	}
+//	return w.Close()
+//
+// CSV and table columns name a top-level field or a property, with dots
+// reaching into nested objects and arrays: "id", "eo:cloud_cover",
+// "proj:centroid.lat", "extent.spatial.bbox.0". Without WithColumns the
+// columns are chosen from the first document; see WithColumns.
+package output
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"iter"
+	"strings"
+
+	"github.com/robert-malhotra/go-stac-client/pkg/stac"
+)
+
+// Format is an output format.
+type Format string
+
+// Supported formats.
+const (
+	NDJSON  Format = "ndjson"  // one JSON document per line
+	GeoJSON Format = "geojson" // a single GeoJSON FeatureCollection
+	CSV     Format = "csv"     // a header row and one row per document
+	Table   Format = "table"   // aligned columns for terminals
+)
+
+// Formats lists the supported formats.
+var Formats = []Format{NDJSON, GeoJSON, CSV, Table}
+
+// ParseFormat parses a format name, ignoring case.
+func ParseFormat(s string) (Format, error) {
+	f := Format(strings.ToLower(strings.TrimSpace(s)))
+	for _, known := range Formats {
+		if f == known {
+			return f, nil
+		}
+	}
+	return "", fmt.Errorf("unknown output format %q (want ndjson, geojson, csv or table)", s)
+}
+
+// Ext returns the usual file extension for f, including the dot.
+func (f Format) Ext() string {
+	switch f {
+	case GeoJSON:
+		return ".geojson"
+	case CSV:
+		return ".csv"
+	case Table:
+		return ".txt"
+	}
+	return ".ndjson"
+}
+
+// Option configures a Writer.
+type Option func(*Writer)
+
+// WithColumns selects the CSV and table columns. Without it, CSV output has
+// the id, collection and datetime of items followed by every property of the
+// first item, nested objects flattened into dotted columns, and table output
+// has only the id, collection and datetime. Collections default to id,
+// title, license and description in CSV and id and title in tables.
+// Properties missing from the first item are not added later, since the
+// header has already been written.
+func WithColumns(columns ...string) Option {
+	return func(w *Writer) {
+		w.columns = append(w.columns, columns...)
+	}
+}
+
+// WithMaxCellWidth caps the width of table columns; longer values are cut
+// and end in "…". The default is 40. It has no effect on other formats.
+func WithMaxCellWidth(n int) Option {
+	return func(w *Writer) {
+		if n > 0 {
+			w.maxWidth = n
+		}
+	}
+}
+
+// Writer writes STAC items and collections in one format. Write documents
+// of one kind per Writer and call Close to finish the output.
+type Writer struct {
+	columns  []string
+	maxWidth int
+	enc      encoder
+	closed   bool
+}
+
+// encoder is implemented by each format.
+type encoder interface {
+	write(doc any) error
+	close() error
+}
+
+// NewWriter returns a Writer that renders documents to w in format.
+func NewWriter(w io.Writer, format Format, opts ...Option) (*Writer, error) {
+	wr := &Writer{maxWidth: 40}
+	for _, opt := range opts {
+		opt(wr)
+	}
+
+	switch format {
+	case NDJSON:
+		wr.enc = &ndjsonEncoder{enc: json.NewEncoder(w)}
+	case GeoJSON:
+		wr.enc = &geojsonEncoder{w: w}
+	case CSV:
+		wr.enc = &csvEncoder{w: csv.NewWriter(w), columns: wr.columns}
+	case Table:
+		wr.enc = &tableEncoder{w: w, columns: wr.columns, maxWidth: wr.maxWidth}
+	default:
+		return nil, fmt.Errorf("unknown output format %q", format)
+	}
+	return wr, nil
+}
+
+// Write renders doc, which must be a *stac.Item or *stac.Collection.
+func (w *Writer) Write(doc any) error {
+	if w.closed {
+		return fmt.Errorf("write after close")
+	}
+	switch doc.(type) {
+	case *stac.Item, *stac.Collection:
+	default:
+		return fmt.Errorf("cannot write %T, want *stac.Item or *stac.Collection", doc)
+	}
+	return w.enc.write(doc)
+}
+
+// Close completes the output, such as the end of a FeatureCollection or the
+// rows a table holds back to size its columns. It does not close the
+// underlying io.Writer.
+func (w *Writer) Close() error {
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+	return w.enc.close()
+}
+
+// WriteItems writes the items of seq to w in format and returns how many
+// were written. An error from seq stops the output after completing what
+// was written so far.
+func WriteItems(w io.Writer, format Format, seq iter.Seq2[*stac.Item, error], opts ...Option) (int, error) {
+	return writeSeq(w, format, seq, opts)
+}
+
+// WriteCollections writes the collections of seq to w in format and returns
+// how many were written. An error from seq stops the output after
+// completing what was written so far.
+func WriteCollections(w io.Writer, format Format, seq iter.Seq2[*stac.Collection, error], opts ...Option) (int, error) {
+	return writeSeq(w, format, seq, opts)
+}
+
+func writeSeq[T any](w io.Writer, format Format, seq iter.Seq2[*T, error], opts []Option) (int, error) {
+	wr, err := NewWriter(w, format, opts...)
+	if err != nil {
+		return 0, err
+	}
+
+	n := 0
+	for doc, err := range seq {
+		if err != nil {
+			wr.Close()
+			return n, err
+		}
+		if err := wr.Write(doc); err != nil {
+			return n, err
+		}
+		n++
+	}
+	return n, wr.Close()
+}
+
+type ndjsonEncoder struct {
+	enc *json.Encoder
+}
+
+func (e *ndjsonEncoder) write(doc any) error { return e.enc.Encode(doc) }
+func (e *ndjsonEncoder) close() error        { return nil }
+
+// geojsonEncoder writes one feature per line between the FeatureCollection
+// header, written with the first feature, and the footer written by close.
+type geojsonEncoder struct {
+	w       io.Writer
+	started bool
+}
+
+const geojsonHeader = `{"type":"FeatureCollection","features":[`
+
+func (e *geojsonEncoder) write(doc any) error {
+	if col, ok := doc.(*stac.Collection); ok {
+		doc = collectionFeature(col)
+	}
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+
+	sep := ",\n"
+	if !e.started {
+		e.started = true
+		sep = geojsonHeader + "\n"
+	}
+	_, err = io.WriteString(e.w, sep+string(data))
+	return err
+}
+
+func (e *geojsonEncoder) close() error {
+	footer := "\n]}\n"
+	if !e.started {
+		footer = geojsonHeader + "]}\n"
+	}
+	_, err := io.WriteString(e.w, footer)
+	return err
+}
+
+// collectionFeature represents a collection as a GeoJSON Feature whose
+// geometry is the overall spatial extent.
+func collectionFeature(col *stac.Collection) map[string]any {
+	props := map[string]any{
+		"title":       col.Title,
+		"description": col.Description,
+		"license":     col.License,
+	}
+	if len(col.Keywords) > 0 {
+		props["keywords"] = col.Keywords
+	}
+
+	var bbox []float64
+	if col.Extent != nil {
+		bbox = col.Extent.Spatial.Overall()
+		if t := col.Extent.Temporal; t != nil && len(t.Interval) > 0 && len(t.Interval[0]) == 2 {
+			props["start_datetime"] = t.Interval[0][0]
+			props["end_datetime"] = t.Interval[0][1]
+		}
+	}
+
+	feature := map[string]any{
+		"type":       "Feature",
+		"id":         col.Id,
+		"geometry":   nil,
+		"properties": props,
+	}
+	if len(bbox) >= 4 {
+		minX, minY, maxX, maxY := bbox[0], bbox[1], bbox[2], bbox[3]
+		if len(bbox) == 6 {
+			minX, minY, maxX, maxY = bbox[0], bbox[1], bbox[3], bbox[4]
+		}
+		feature["bbox"] = bbox
+		feature["geometry"] = map[string]any{
+			"type": "Polygon",
+			"coordinates": [][][]float64{{
+				{minX, minY}, {maxX, minY}, {maxX, maxY}, {minX, maxY}, {minX, minY},
+			}},
+		}
+	}
+	return feature
+}
+
+// csvEncoder writes the header with the first row, choosing default
+// columns from that document when none were given, and flushes every row.
+type csvEncoder struct {
+	w       *csv.Writer
+	columns []string
+	started bool
+}
+
+func (e *csvEncoder) write(doc any) error {
+	fields, err := toFields(doc)
+	if err != nil {
+		return err
+	}
+	if !e.started {
+		if len(e.columns) == 0 {
+			e.columns = defaultColumns(doc, fields, true)
+		}
+		if err := e.header(); err != nil {
+			return err
+		}
+	}
+	if err := e.w.Write(row(fields, e.columns)); err != nil {
+		return err
+	}
+	e.w.Flush()
+	return e.w.Error()
+}
+
+func (e *csvEncoder) header() error {
+	e.started = true
+	return e.w.Write(e.columns)
+}
+
+func (e *csvEncoder) close() error {
+	if !e.started && len(e.columns) > 0 {
+		if err := e.header(); err != nil {
+			return err
+		}
+	}
+	e.w.Flush()
+	return e.w.Error()
+}
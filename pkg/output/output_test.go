@@ -0,0 +1,198 @@
+package output_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"strings"
+	"testing"
+
+	"github.com/robert-malhotra/go-stac-client/pkg/output"
+	"github.com/robert-malhotra/go-stac-client/pkg/stac"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testItems(n int) []*stac.Item {
+	items := make([]*stac.Item, n)
+	for i := range items {
+		items[i] = &stac.Item{
+			Type:       "Feature",
+			Version:    "1.0.0",
+			Id:         fmt.Sprintf("item-%d", i+1),
+			Collection: "s2",
+			Geometry:   map[string]any{"type": "Point", "coordinates": []any{10.0, 50.0}},
+			Properties: map[string]any{
+				"datetime":       "2024-01-01T00:00:00Z",
+				"eo:cloud_cover": 12.5,
+				"proj:centroid":  map[string]any{"lat": 50.0, "lon": 10.0},
+				"instruments":    []any{"msi"},
+			},
+		}
+	}
+	return items
+}
+
+func seqOf[T any](docs []*T, err error) iter.Seq2[*T, error] {
+	return func(yield func(*T, error) bool) {
+		for _, d := range docs {
+			if !yield(d, nil) {
+				return
+			}
+		}
+		if err != nil {
+			yield(nil, err)
+		}
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	f, err := output.ParseFormat("GeoJSON")
+	require.NoError(t, err)
+	assert.Equal(t, output.GeoJSON, f)
+	assert.Equal(t, ".geojson", f.Ext())
+
+	_, err = output.ParseFormat("xml")
+	assert.Error(t, err)
+}
+
+func TestWriteItems(t *testing.T) {
+	t.Run("ndjson", func(t *testing.T) {
+		var buf bytes.Buffer
+		n, err := output.WriteItems(&buf, output.NDJSON, seqOf(testItems(2), nil))
+		require.NoError(t, err)
+		assert.Equal(t, 2, n)
+
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		require.Len(t, lines, 2)
+		var item stac.Item
+		require.NoError(t, json.Unmarshal([]byte(lines[1]), &item))
+		assert.Equal(t, "item-2", item.Id)
+	})
+
+	t.Run("geojson", func(t *testing.T) {
+		var buf bytes.Buffer
+		_, err := output.WriteItems(&buf, output.GeoJSON, seqOf(testItems(3), nil))
+		require.NoError(t, err)
+
+		var fc stac.ItemCollection
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &fc))
+		require.Len(t, fc.Features, 3)
+		assert.Equal(t, "item-3", fc.Features[2].Id)
+	})
+
+	t.Run("empty geojson", func(t *testing.T) {
+		var buf bytes.Buffer
+		_, err := output.WriteItems(&buf, output.GeoJSON, seqOf[stac.Item](nil, nil))
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"type":"FeatureCollection","features":[]}`, buf.String())
+	})
+
+	t.Run("csv default columns", func(t *testing.T) {
+		var buf bytes.Buffer
+		_, err := output.WriteItems(&buf, output.CSV, seqOf(testItems(1), nil))
+		require.NoError(t, err)
+
+		records, err := csv.NewReader(&buf).ReadAll()
+		require.NoError(t, err)
+		assert.Equal(t, [][]string{
+			{"id", "collection", "datetime", "eo:cloud_cover", "instruments", "proj:centroid.lat", "proj:centroid.lon"},
+			{"item-1", "s2", "2024-01-01T00:00:00Z", "12.5", `["msi"]`, "50", "10"},
+		}, records)
+	})
+
+	t.Run("csv selected columns", func(t *testing.T) {
+		var buf bytes.Buffer
+		_, err := output.WriteItems(&buf, output.CSV, seqOf(testItems(2), nil),
+			output.WithColumns("id", "properties.eo:cloud_cover", "geometry.coordinates.1", "missing"))
+		require.NoError(t, err)
+		assert.Equal(t, "id,properties.eo:cloud_cover,geometry.coordinates.1,missing\n"+
+			"item-1,12.5,50,\nitem-2,12.5,50,\n", buf.String())
+	})
+
+	t.Run("table", func(t *testing.T) {
+		items := testItems(2)
+		items[1].Id = "a-much-longer-item-identifier"
+		var buf bytes.Buffer
+		_, err := output.WriteItems(&buf, output.Table, seqOf(items, nil), output.WithMaxCellWidth(12))
+		require.NoError(t, err)
+		assert.Equal(t, ""+
+			"ID            COLLECTION  DATETIME\n"+
+			"item-1        s2          2024-01-01T…\n"+
+			"a-much-long…  s2          2024-01-01T…\n", buf.String())
+	})
+
+	t.Run("error completes output", func(t *testing.T) {
+		boom := errors.New("boom")
+		var buf bytes.Buffer
+		n, err := output.WriteItems(&buf, output.GeoJSON, seqOf(testItems(1), boom))
+		assert.ErrorIs(t, err, boom)
+		assert.Equal(t, 1, n)
+		assert.True(t, json.Valid(buf.Bytes()))
+	})
+}
+
+func TestWriter_Table_Streams(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := output.NewWriter(&buf, output.Table, output.WithColumns("id"))
+	require.NoError(t, err)
+
+	for _, item := range testItems(60) {
+		require.NoError(t, w.Write(item))
+	}
+	// The first rows are written once enough have arrived to size the
+	// columns, and later ones as they come.
+	assert.Equal(t, 61, strings.Count(buf.String(), "\n"))
+	require.NoError(t, w.Close())
+	assert.Equal(t, 61, strings.Count(buf.String(), "\n"))
+}
+
+func TestWriteCollections(t *testing.T) {
+	cols := []*stac.Collection{{
+		Id:          "s2",
+		Title:       "Sentinel-2",
+		Description: "Multi\nspectral",
+		License:     "proprietary",
+		Extent: &stac.Extent{
+			Spatial:  &stac.SpatialExtent{Bbox: [][]float64{{-10, -20, 30, 40}}},
+			Temporal: &stac.TemporalExtent{Interval: [][]any{{"2015-06-23T00:00:00Z", nil}}},
+		},
+	}}
+
+	t.Run("geojson", func(t *testing.T) {
+		var buf bytes.Buffer
+		_, err := output.WriteCollections(&buf, output.GeoJSON, seqOf(cols, nil))
+		require.NoError(t, err)
+
+		var fc struct {
+			Features []struct {
+				ID       string `json:"id"`
+				Geometry struct {
+					Type        string        `json:"type"`
+					Coordinates [][][]float64 `json:"coordinates"`
+				} `json:"geometry"`
+				Properties map[string]any `json:"properties"`
+			} `json:"features"`
+		}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &fc))
+		require.Len(t, fc.Features, 1)
+		f := fc.Features[0]
+		assert.Equal(t, "s2", f.ID)
+		assert.Equal(t, "Polygon", f.Geometry.Type)
+		assert.Equal(t, []float64{30, 40}, f.Geometry.Coordinates[0][2])
+		assert.Equal(t, "2015-06-23T00:00:00Z", f.Properties["start_datetime"])
+	})
+
+	t.Run("table", func(t *testing.T) {
+		var buf bytes.Buffer
+		_, err := output.WriteCollections(&buf, output.Table, seqOf(cols, nil),
+			output.WithColumns("id", "description", "extent.spatial.bbox.0.2"))
+		require.NoError(t, err)
+		assert.Equal(t, ""+
+			"ID  DESCRIPTION     EXTENT.SPATIAL.BBOX.0.2\n"+
+			"s2  Multi spectral  30\n", buf.String())
+	})
+}
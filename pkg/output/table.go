@@ -0,0 +1,105 @@
+package output
+
+import (
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// tableSample is how many rows a table holds back to size its columns.
+// Later rows are cut to the same widths, so output starts after the first
+// tableSample documents instead of at the end of the stream.
+const tableSample = 50
+
+// tableEncoder writes space-aligned columns under an upper-case header.
+type tableEncoder struct {
+	w        io.Writer
+	columns  []string
+	maxWidth int
+	pending  [][]string
+	widths   []int
+}
+
+func (e *tableEncoder) write(doc any) error {
+	fields, err := toFields(doc)
+	if err != nil {
+		return err
+	}
+	if len(e.columns) == 0 {
+		e.columns = defaultColumns(doc, fields, false)
+	}
+
+	cells := row(fields, e.columns)
+	for i, c := range cells {
+		cells[i] = strings.Join(strings.Fields(c), " ")
+	}
+	if e.widths != nil {
+		return e.writeRow(cells)
+	}
+	e.pending = append(e.pending, cells)
+	if len(e.pending) < tableSample {
+		return nil
+	}
+	return e.flush()
+}
+
+func (e *tableEncoder) close() error {
+	if e.widths != nil || len(e.columns) == 0 {
+		return nil
+	}
+	return e.flush()
+}
+
+// flush sizes the columns from the header and the held-back rows, then
+// writes them.
+func (e *tableEncoder) flush() error {
+	header := make([]string, len(e.columns))
+	e.widths = make([]int, len(e.columns))
+	for i, col := range e.columns {
+		header[i] = strings.ToUpper(col)
+		e.widths[i] = utf8.RuneCountInString(header[i])
+	}
+	for _, cells := range e.pending {
+		for i, c := range cells {
+			e.widths[i] = max(e.widths[i], utf8.RuneCountInString(c))
+		}
+	}
+	for i := range e.widths {
+		e.widths[i] = min(e.widths[i], max(e.maxWidth, utf8.RuneCountInString(header[i])))
+	}
+
+	if err := e.writeRow(header); err != nil {
+		return err
+	}
+	for _, cells := range e.pending {
+		if err := e.writeRow(cells); err != nil {
+			return err
+		}
+	}
+	e.pending = nil
+	return nil
+}
+
+// writeRow pads each cell to its column width, leaving the last unpadded.
+func (e *tableEncoder) writeRow(cells []string) error {
+	var b strings.Builder
+	for i, c := range cells {
+		c = truncate(c, e.widths[i])
+		b.WriteString(c)
+		if i < len(cells)-1 {
+			b.WriteString(strings.Repeat(" ", e.widths[i]-utf8.RuneCountInString(c)+2))
+		}
+	}
+	b.WriteByte('\n')
+	_, err := io.WriteString(e.w, b.String())
+	return err
+}
+
+// truncate cuts s to width runes, ending it in "…" when shortened.
+func truncate(s string, width int) string {
+	if utf8.RuneCountInString(s) <= width {
+		return s
+	}
+	r := []rune(s)
+	return string(r[:width-1]) + "…"
+}
@@ -0,0 +1,81 @@
+package stac
+
+import "encoding/json"
+
+// Catalog represents a STAC Catalog with support for foreign members.
+type Catalog struct {
+	Type        string   `json:"type"`
+	Version     string   `json:"stac_version"`
+	Extensions  []string `json:"stac_extensions,omitempty"`
+	Id          string   `json:"id"`
+	Title       string   `json:"title,omitempty"`
+	Description string   `json:"description"`
+	Links       []*Link  `json:"links"`
+
+	// AdditionalFields holds foreign members not defined in the STAC spec
+	// (e.g., "conformsTo" on an API landing page).
+	AdditionalFields map[string]any `json:"-"`
+}
+
+var knownCatalogFields = map[string]bool{
+	"type": true, "stac_version": true, "stac_extensions": true,
+	"id": true, "title": true, "description": true, "links": true,
+}
+
+// UnmarshalJSON implements custom unmarshaling to capture foreign members.
+func (cat *Catalog) UnmarshalJSON(data []byte) error {
+	type catalogAlias Catalog
+	var aux catalogAlias
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	*cat = Catalog(aux)
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	cat.AdditionalFields = make(map[string]any)
+	for key, val := range raw {
+		if !knownCatalogFields[key] {
+			var decoded any
+			if err := json.Unmarshal(val, &decoded); err != nil {
+				continue
+			}
+			cat.AdditionalFields[key] = decoded
+		}
+	}
+
+	return nil
+}
+
+// MarshalJSON implements custom marshaling to include foreign members.
+func (cat Catalog) MarshalJSON() ([]byte, error) {
+	type catalogAlias Catalog
+	aux := catalogAlias(cat)
+
+	data, err := json.Marshal(aux)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(cat.AdditionalFields) == 0 {
+		return data, nil
+	}
+
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return nil, err
+	}
+
+	for key, val := range cat.AdditionalFields {
+		encoded, err := json.Marshal(val)
+		if err != nil {
+			return nil, err
+		}
+		obj[key] = encoded
+	}
+
+	return json.Marshal(obj)
+}
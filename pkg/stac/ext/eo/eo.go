@@ -0,0 +1,119 @@
+// Package eo implements the Electro-Optical extension.
+//
+// https://github.com/stac-extensions/eo
+package eo
+
+import (
+	"strings"
+
+	"github.com/robert-malhotra/go-stac-client/pkg/stac"
+	"github.com/robert-malhotra/go-stac-client/pkg/stac/ext"
+)
+
+// SchemaURI identifies the extension in stac_extensions.
+const SchemaURI = "https://stac-extensions.github.io/eo/v1.1.0/schema.json"
+
+// SchemaV2URI identifies version 2 of the extension, used by STAC 1.1
+// documents whose bands are in the common bands array.
+const SchemaV2URI = "https://stac-extensions.github.io/eo/v2.0.0/schema.json"
+
+const (
+	cloudCoverKey = "eo:cloud_cover"
+	snowCoverKey  = "eo:snow_cover"
+	bandsKey      = "eo:bands"
+
+	commonBandsKey = ext.CommonBandsKey
+)
+
+// Band describes a spectral band.
+type Band struct {
+	Name              string  `json:"name,omitempty"`
+	CommonName        string  `json:"common_name,omitempty"`
+	Description       string  `json:"description,omitempty"`
+	CenterWavelength  float64 `json:"center_wavelength,omitempty"`
+	FullWidthHalfMax  float64 `json:"full_width_half_max,omitempty"`
+	SolarIllumination float64 `json:"solar_illumination,omitempty"`
+}
+
+// EO is a typed view of eo:* fields.
+type EO struct {
+	f ext.Fields
+}
+
+// FromItem returns a view of the item's properties.
+func FromItem(item *stac.Item) EO {
+	return EO{ext.ItemFields(item, SchemaURI)}
+}
+
+// FromAsset returns a view of an asset's fields. Setters do not register the
+// schema; use FromItemAsset for that.
+func FromAsset(asset *stac.Asset) EO {
+	return EO{ext.AssetFields(asset, nil, SchemaURI)}
+}
+
+// FromItemAsset returns a view of an asset belonging to item. Setters
+// register the schema on item.
+func FromItemAsset(item *stac.Item, asset *stac.Asset) EO {
+	return EO{ext.AssetFields(asset, item, SchemaURI)}
+}
+
+// CloudCover returns eo:cloud_cover as a percentage (0-100).
+func (e EO) CloudCover() (float64, bool) { return e.f.Float(cloudCoverKey) }
+
+// SetCloudCover sets eo:cloud_cover.
+func (e EO) SetCloudCover(pct float64) { e.f.Set(cloudCoverKey, pct) }
+
+// SnowCover returns eo:snow_cover as a percentage (0-100).
+func (e EO) SnowCover() (float64, bool) { return e.f.Float(snowCoverKey) }
+
+// SetSnowCover sets eo:snow_cover.
+func (e EO) SetSnowCover(pct float64) { e.f.Set(snowCoverKey, pct) }
+
+// Bands returns eo:bands, or for STAC 1.1 documents the eo:* fields of the
+// common bands array.
+func (e EO) Bands() ([]Band, bool) {
+	var bands []Band
+	if e.f.Decode(bandsKey, &bands) {
+		return bands, true
+	}
+
+	var common []struct {
+		Name              string  `json:"name"`
+		Description       string  `json:"description"`
+		CommonName        string  `json:"eo:common_name"`
+		CenterWavelength  float64 `json:"eo:center_wavelength"`
+		FullWidthHalfMax  float64 `json:"eo:full_width_half_max"`
+		SolarIllumination float64 `json:"eo:solar_illumination"`
+	}
+	if !e.f.Decode(commonBandsKey, &common) {
+		return nil, false
+	}
+	bands = make([]Band, len(common))
+	for i, b := range common {
+		bands[i] = Band{
+			Name:              b.Name,
+			Description:       b.Description,
+			CommonName:        b.CommonName,
+			CenterWavelength:  b.CenterWavelength,
+			FullWidthHalfMax:  b.FullWidthHalfMax,
+			SolarIllumination: b.SolarIllumination,
+		}
+	}
+	return bands, true
+}
+
+// SetBands sets eo:bands. When the item declares eo v2, or the object
+// already holds common bands, it sets the eo:* fields, name and description
+// of the common bands array instead and registers SchemaV2URI.
+func (e EO) SetBands(bands []Band) {
+	if !e.f.UsesCommonBands(bandsKey) {
+		e.f.Set(bandsKey, bands)
+		return
+	}
+	common := make([]map[string]any, len(bands))
+	for i, b := range bands {
+		common[i] = ext.BandFields(b, "eo:", "name", "description")
+	}
+	e.f.Delete(bandsKey)
+	e.f.WithSchema(SchemaV2URI).SetCommonBands(common, func(key string) bool { return strings.HasPrefix(key, "eo:") })
+}
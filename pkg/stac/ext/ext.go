@@ -0,0 +1,368 @@
+// Package ext provides the shared plumbing for typed STAC extension views.
+//
+// Each extension lives in its own subpackage (eo, proj, sat, view, sar,
+// raster, file, timestamps) and exposes a view over an Item's properties or
+// an Asset's fields:
+//
+//	cc, ok := eo.FromItem(item).CloudCover()
+//	epsg, ok := proj.FromAsset(item.Assets["B04"]).EPSG()
+//
+// Getters return the value and whether it was present with the expected
+// type. Setters write the field and add the extension's schema URI to the
+// owning Item's stac_extensions, unless it already declares a newer version
+// of the extension, as Items upgraded by stac.Migrate do.
+package ext
+
+import (
+	"encoding/json"
+	"maps"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/robert-malhotra/go-stac-client/pkg/stac"
+)
+
+// CommonBandsKey holds the bands of STAC 1.1 documents, with the fields of
+// extensions such as eo and raster inside each band.
+const CommonBandsKey = "bands"
+
+// Fields is the JSON object an extension view reads and writes, together
+// with the stac_extensions list its schema is registered in on writes.
+type Fields struct {
+	values     *map[string]any
+	extensions *[]string
+	schema     string
+}
+
+// ItemFields returns the properties of item, registering schema on item.
+func ItemFields(item *stac.Item, schema string) Fields {
+	return Fields{values: &item.Properties, extensions: &item.Extensions, schema: schema}
+}
+
+// AssetFields returns the fields of asset. When owner is non-nil, schema is
+// registered on it; otherwise setters only modify the asset.
+func AssetFields(asset *stac.Asset, owner *stac.Item, schema string) Fields {
+	f := Fields{values: &asset.AdditionalFields, schema: schema}
+	if owner != nil {
+		f.extensions = &owner.Extensions
+	}
+	return f
+}
+
+// Has reports whether key is present.
+func (f Fields) Has(key string) bool {
+	_, ok := f.get(key)
+	return ok
+}
+
+// Float returns key as a float64.
+func (f Fields) Float(key string) (float64, bool) {
+	v, ok := f.get(key)
+	if !ok {
+		return 0, false
+	}
+	return toFloat(v)
+}
+
+// Int returns key as an int. Floats with a fractional part are rejected.
+func (f Fields) Int(key string) (int, bool) {
+	n, ok := f.Int64(key)
+	return int(n), ok
+}
+
+// Int64 returns key as an int64. Floats with a fractional part are rejected.
+func (f Fields) Int64(key string) (int64, bool) {
+	v, ok := f.Float(key)
+	if !ok || v != math.Trunc(v) {
+		return 0, false
+	}
+	return int64(v), true
+}
+
+// String returns key as a string.
+func (f Fields) String(key string) (string, bool) {
+	v, ok := f.get(key)
+	if !ok {
+		return "", false
+	}
+	s, ok := v.(string)
+	return s, ok
+}
+
+// Strings returns key as a list of strings.
+func (f Fields) Strings(key string) ([]string, bool) {
+	v, ok := f.get(key)
+	if !ok {
+		return nil, false
+	}
+	switch vals := v.(type) {
+	case []string:
+		return slices.Clone(vals), true
+	case []any:
+		out := make([]string, len(vals))
+		for i, e := range vals {
+			s, ok := e.(string)
+			if !ok {
+				return nil, false
+			}
+			out[i] = s
+		}
+		return out, true
+	}
+	return nil, false
+}
+
+// Floats returns key as a list of numbers.
+func (f Fields) Floats(key string) ([]float64, bool) {
+	v, ok := f.get(key)
+	if !ok {
+		return nil, false
+	}
+	switch vals := v.(type) {
+	case []float64:
+		return slices.Clone(vals), true
+	case []int:
+		out := make([]float64, len(vals))
+		for i, e := range vals {
+			out[i] = float64(e)
+		}
+		return out, true
+	case []any:
+		out := make([]float64, len(vals))
+		for i, e := range vals {
+			n, ok := toFloat(e)
+			if !ok {
+				return nil, false
+			}
+			out[i] = n
+		}
+		return out, true
+	}
+	return nil, false
+}
+
+// Ints returns key as a list of integers.
+func (f Fields) Ints(key string) ([]int, bool) {
+	vals, ok := f.Floats(key)
+	if !ok {
+		return nil, false
+	}
+	out := make([]int, len(vals))
+	for i, v := range vals {
+		if v != math.Trunc(v) {
+			return nil, false
+		}
+		out[i] = int(v)
+	}
+	return out, true
+}
+
+// Time returns key parsed as an RFC 3339 timestamp.
+func (f Fields) Time(key string) (time.Time, bool) {
+	s, ok := f.String(key)
+	if !ok {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// Decode unmarshals key into v, which must be a pointer. It reports false if
+// the key is missing or does not match v's shape.
+func (f Fields) Decode(key string, v any) bool {
+	raw, ok := f.get(key)
+	if !ok {
+		return false
+	}
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return false
+	}
+	return json.Unmarshal(data, v) == nil
+}
+
+// Value returns the raw value of key.
+func (f Fields) Value(key string) (any, bool) {
+	return f.get(key)
+}
+
+// Set writes key and registers the extension schema.
+func (f Fields) Set(key string, value any) {
+	if *f.values == nil {
+		*f.values = make(map[string]any)
+	}
+	(*f.values)[key] = value
+	f.register()
+}
+
+// WithSchema returns f registering schema instead, e.g. another version of
+// the extension.
+func (f Fields) WithSchema(schema string) Fields {
+	f.schema = schema
+	return f
+}
+
+// UsesCommonBands reports whether band fields of the extension belong in the
+// common bands array rather than in its own field key: the owning Item
+// declares version 2 or later of the extension, or the object already holds
+// common bands and no key.
+func (f Fields) UsesCommonBands(key string) bool {
+	if major, ok := f.declaredMajor(); ok {
+		return major >= 2
+	}
+	return f.Has(CommonBandsKey) && !f.Has(key)
+}
+
+// SetCommonBands writes bands into the common bands array and registers the
+// extension schema. Band i keeps the fields for which owned is false and
+// takes the fields of bands[i]; bands beyond len(bands) only lose the owned
+// fields.
+func (f Fields) SetCommonBands(bands []map[string]any, owned func(key string) bool) {
+	var current []any
+	if v, ok := f.get(CommonBandsKey); ok {
+		current, _ = v.([]any)
+	}
+	out := make([]any, max(len(bands), len(current)))
+	for i := range out {
+		band := make(map[string]any)
+		if i < len(current) {
+			if m, ok := current[i].(map[string]any); ok {
+				for k, v := range m {
+					if !owned(k) {
+						band[k] = v
+					}
+				}
+			}
+		}
+		if i < len(bands) {
+			maps.Copy(band, bands[i])
+		}
+		out[i] = band
+	}
+	f.Set(CommonBandsKey, out)
+}
+
+// BandFields encodes band as a common band, prefixing its fields except
+// those listed in unprefixed, e.g. center_wavelength becomes
+// eo:center_wavelength.
+func BandFields(band any, prefix string, unprefixed ...string) map[string]any {
+	data, err := json.Marshal(band)
+	if err != nil {
+		return nil
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil
+	}
+	out := make(map[string]any, len(fields))
+	for k, v := range fields {
+		if !slices.Contains(unprefixed, k) {
+			k = prefix + k
+		}
+		out[k] = v
+	}
+	return out
+}
+
+// SetTime writes key as an RFC 3339 timestamp in UTC.
+func (f Fields) SetTime(key string, t time.Time) {
+	f.Set(key, t.UTC().Format(time.RFC3339Nano))
+}
+
+// Delete removes key. The schema stays registered.
+func (f Fields) Delete(key string) {
+	delete(*f.values, key)
+}
+
+func (f Fields) get(key string) (any, bool) {
+	if f.values == nil || *f.values == nil {
+		return nil, false
+	}
+	v, ok := (*f.values)[key]
+	if !ok || v == nil {
+		return nil, false
+	}
+	return v, true
+}
+
+// register adds the extension schema to the owning Item, unless it already
+// declares this or a newer version of the extension.
+func (f Fields) register() {
+	if f.extensions == nil || f.schema == "" || slices.Contains(*f.extensions, f.schema) {
+		return
+	}
+	if base, version, ok := schemaVersion(f.schema); ok {
+		for _, e := range *f.extensions {
+			if b, v, ok := schemaVersion(e); ok && b == base && slices.Compare(v, version) > 0 {
+				return
+			}
+		}
+	}
+	*f.extensions = append(*f.extensions, f.schema)
+}
+
+// declaredMajor returns the highest major version of the extension declared
+// by the owning Item.
+func (f Fields) declaredMajor() (int, bool) {
+	if f.extensions == nil {
+		return 0, false
+	}
+	base, _, ok := schemaVersion(f.schema)
+	if !ok {
+		return 0, false
+	}
+	major, found := 0, false
+	for _, e := range *f.extensions {
+		if b, v, ok := schemaVersion(e); ok && b == base {
+			major, found = max(major, v[0]), true
+		}
+	}
+	return major, found
+}
+
+// versionedSchema matches extension schema URIs such as
+// https://stac-extensions.github.io/eo/v1.1.0/schema.json.
+var versionedSchema = regexp.MustCompile(`^(.+)/v(\d+)\.(\d+)\.(\d+)(/[^/]+)$`)
+
+// schemaVersion splits an extension schema URI into the URI without its
+// version and the version numbers.
+func schemaVersion(uri string) (base string, version []int, ok bool) {
+	m := versionedSchema.FindStringSubmatch(uri)
+	if m == nil {
+		return "", nil, false
+	}
+	version = make([]int, 3)
+	for i := range version {
+		n, err := strconv.Atoi(m[i+2])
+		if err != nil {
+			return "", nil, false
+		}
+		version[i] = n
+	}
+	return m[1] + m[5], version, true
+}
+
+func toFloat(v any) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
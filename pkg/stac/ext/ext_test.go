@@ -0,0 +1,252 @@
+package ext_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/robert-malhotra/go-stac-client/pkg/stac"
+	"github.com/robert-malhotra/go-stac-client/pkg/stac/ext/eo"
+	"github.com/robert-malhotra/go-stac-client/pkg/stac/ext/file"
+	"github.com/robert-malhotra/go-stac-client/pkg/stac/ext/proj"
+	"github.com/robert-malhotra/go-stac-client/pkg/stac/ext/raster"
+	"github.com/robert-malhotra/go-stac-client/pkg/stac/ext/sar"
+	"github.com/robert-malhotra/go-stac-client/pkg/stac/ext/sat"
+	"github.com/robert-malhotra/go-stac-client/pkg/stac/ext/timestamps"
+	"github.com/robert-malhotra/go-stac-client/pkg/stac/ext/view"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const itemJSON = `{
+	"type": "Feature",
+	"stac_version": "1.0.0",
+	"stac_extensions": ["https://stac-extensions.github.io/eo/v1.1.0/schema.json"],
+	"id": "S2A_scene",
+	"geometry": null,
+	"properties": {
+		"datetime": "2023-06-01T10:20:30Z",
+		"eo:cloud_cover": 12.5,
+		"proj:epsg": 32633,
+		"proj:shape": [10980, 10980],
+		"proj:transform": [10, 0, 399960, 0, -10, 5000040],
+		"sat:orbit_state": "descending",
+		"sat:relative_orbit": 22,
+		"view:sun_elevation": 54.3,
+		"sar:polarizations": ["VV", "VH"],
+		"published": "2023-06-02T00:00:00Z",
+		"eo:snow_cover": "not-a-number"
+	},
+	"links": [],
+	"assets": {
+		"B04": {
+			"href": "https://example.com/B04.tif",
+			"proj:code": "EPSG:32633",
+			"file:size": 123456789,
+			"eo:bands": [{"name": "B04", "common_name": "red", "center_wavelength": 0.665}],
+			"raster:bands": [{"nodata": 0, "data_type": "uint16", "scale": 0.0001, "statistics": {"minimum": 1, "maximum": 10000}}]
+		}
+	}
+}`
+
+func loadItem(t *testing.T) *stac.Item {
+	t.Helper()
+	var item stac.Item
+	require.NoError(t, json.Unmarshal([]byte(itemJSON), &item))
+	return &item
+}
+
+func TestGetters(t *testing.T) {
+	item := loadItem(t)
+	asset := item.Assets["B04"]
+
+	cc, ok := eo.FromItem(item).CloudCover()
+	assert.True(t, ok)
+	assert.Equal(t, 12.5, cc)
+
+	_, ok = eo.FromItem(item).SnowCover()
+	assert.False(t, ok, "wrong type is reported as missing")
+
+	epsg, ok := proj.FromItem(item).EPSG()
+	assert.True(t, ok)
+	assert.Equal(t, 32633, epsg, "proj:epsg is read")
+	code, _ := proj.FromItem(item).Code()
+	assert.Equal(t, "EPSG:32633", code)
+
+	epsg, ok = proj.FromAsset(asset).EPSG()
+	assert.True(t, ok)
+	assert.Equal(t, 32633, epsg, "proj:code is read")
+
+	shape, ok := proj.FromItem(item).Shape()
+	assert.True(t, ok)
+	assert.Equal(t, []int{10980, 10980}, shape)
+	transform, _ := proj.FromItem(item).Transform()
+	assert.Len(t, transform, 6)
+
+	state, _ := sat.FromItem(item).OrbitState()
+	assert.Equal(t, sat.OrbitStateDescending, state)
+	orbit, _ := sat.FromItem(item).RelativeOrbit()
+	assert.Equal(t, 22, orbit)
+
+	elev, ok := view.FromItem(item).SunElevation()
+	assert.True(t, ok)
+	assert.Equal(t, 54.3, elev)
+
+	pols, ok := sar.FromItem(item).Polarizations()
+	assert.True(t, ok)
+	assert.Equal(t, []string{"VV", "VH"}, pols)
+
+	published, ok := timestamps.FromItem(item).Published()
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2023, 6, 2, 0, 0, 0, 0, time.UTC), published)
+
+	size, ok := file.FromAsset(asset).Size()
+	assert.True(t, ok)
+	assert.Equal(t, int64(123456789), size)
+
+	bands, ok := eo.FromAsset(asset).Bands()
+	require.True(t, ok)
+	require.Len(t, bands, 1)
+	assert.Equal(t, "red", bands[0].CommonName)
+	assert.Equal(t, 0.665, bands[0].CenterWavelength)
+
+	rbands, ok := raster.FromAsset(asset).Bands()
+	require.True(t, ok)
+	require.Len(t, rbands, 1)
+	assert.Equal(t, "uint16", rbands[0].DataType)
+	assert.Equal(t, 0.0001, rbands[0].Scale)
+	require.NotNil(t, rbands[0].Statistics)
+	assert.Equal(t, 10000.0, *rbands[0].Statistics.Maximum)
+
+	_, ok = view.FromItem(item).OffNadir()
+	assert.False(t, ok)
+}
+
+func TestSetters(t *testing.T) {
+	item := &stac.Item{Id: "new"}
+
+	eo.FromItem(item).SetCloudCover(3)
+	proj.FromItem(item).SetEPSG(4326)
+	sat.FromItem(item).SetAnxDatetime(time.Date(2024, 1, 2, 3, 4, 5, 0, time.FixedZone("x", 3600)))
+	sar.FromItem(item).SetPolarizations([]string{"HH"})
+	eo.FromItem(item).SetSnowCover(1)
+
+	assert.Equal(t, []string{eo.SchemaURI, proj.SchemaURI, sat.SchemaURI, sar.SchemaURI}, item.Extensions,
+		"each schema is registered once")
+
+	data, err := json.Marshal(item)
+	require.NoError(t, err)
+
+	var decoded stac.Item
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, "EPSG:4326", decoded.Properties["proj:code"])
+	assert.Equal(t, "2024-01-02T02:04:05Z", decoded.Properties["sat:anx_datetime"])
+
+	cc, _ := eo.FromItem(&decoded).CloudCover()
+	assert.Equal(t, 3.0, cc)
+	pols, _ := sar.FromItem(&decoded).Polarizations()
+	assert.Equal(t, []string{"HH"}, pols)
+
+	t.Run("SetCode replaces proj:epsg", func(t *testing.T) {
+		item := loadItem(t)
+		proj.FromItem(item).SetCode("ESRI:102100")
+		assert.NotContains(t, item.Properties, "proj:epsg")
+		_, ok := proj.FromItem(item).EPSG()
+		assert.False(t, ok, "non-EPSG authorities have no EPSG code")
+	})
+
+	t.Run("asset setters", func(t *testing.T) {
+		item := loadItem(t)
+		asset := &stac.Asset{Href: "x.tif"}
+
+		file.FromAsset(asset).SetSize(42)
+		assert.NotContains(t, item.Extensions, file.SchemaURI)
+
+		file.FromItemAsset(item, asset).SetChecksum("1220abcd")
+		assert.Contains(t, item.Extensions, file.SchemaURI)
+
+		size, _ := file.FromAsset(asset).Size()
+		assert.Equal(t, int64(42), size)
+
+		raster.FromItemAsset(item, asset).SetBands([]raster.Band{{DataType: "float32", Nodata: "nan"}})
+		bands, ok := raster.FromAsset(asset).Bands()
+		require.True(t, ok)
+		assert.Equal(t, "nan", bands[0].Nodata)
+	})
+}
+
+func TestCommonBands(t *testing.T) {
+	data, err := stac.MigrateJSON([]byte(`{
+		"type": "Feature", "stac_version": "1.0.0", "id": "a", "geometry": null,
+		"properties": {"datetime": "2024-01-01T00:00:00Z"}, "links": [],
+		"assets": {"B04": {
+			"href": "B04.tif",
+			"eo:bands": [{"name": "B04", "common_name": "red", "center_wavelength": 0.665}],
+			"raster:bands": [{"data_type": "uint16", "scale": 0.0001, "statistics": {"minimum": 1}}]
+		}}
+	}`))
+	require.NoError(t, err)
+	var item stac.Item
+	require.NoError(t, json.Unmarshal(data, &item))
+
+	asset := item.Assets["B04"]
+	require.NotContains(t, asset.AdditionalFields, "eo:bands")
+
+	eoBands, ok := eo.FromAsset(asset).Bands()
+	require.True(t, ok)
+	assert.Equal(t, []eo.Band{{Name: "B04", CommonName: "red", CenterWavelength: 0.665}}, eoBands)
+
+	rasterBands, ok := raster.FromAsset(asset).Bands()
+	require.True(t, ok)
+	require.Len(t, rasterBands, 1)
+	assert.Equal(t, "uint16", rasterBands[0].DataType)
+	assert.Equal(t, 0.0001, rasterBands[0].Scale)
+	assert.Equal(t, 1.0, *rasterBands[0].Statistics.Minimum)
+}
+
+func TestSettersAfterMigrate(t *testing.T) {
+	data, err := stac.MigrateJSON([]byte(`{
+		"type": "Feature", "stac_version": "1.0.0", "id": "a", "geometry": null,
+		"stac_extensions": [
+			"https://stac-extensions.github.io/eo/v1.1.0/schema.json",
+			"https://stac-extensions.github.io/raster/v1.1.0/schema.json"
+		],
+		"properties": {"datetime": "2024-01-01T00:00:00Z"}, "links": [],
+		"assets": {"B04": {
+			"href": "B04.tif",
+			"eo:bands": [{"name": "B04", "common_name": "red"}],
+			"raster:bands": [{"data_type": "uint16", "scale": 0.0001}]
+		}}
+	}`))
+	require.NoError(t, err)
+	var item stac.Item
+	require.NoError(t, json.Unmarshal(data, &item))
+	require.Equal(t, []string{eo.SchemaV2URI, raster.SchemaV2URI}, item.Extensions)
+
+	eo.FromItem(&item).SetCloudCover(5)
+	assert.Equal(t, []string{eo.SchemaV2URI, raster.SchemaV2URI}, item.Extensions,
+		"v1 is not registered next to v2")
+
+	asset := item.Assets["B04"]
+	eo.FromItemAsset(&item, asset).SetBands([]eo.Band{{Name: "B04", CommonName: "red", CenterWavelength: 0.665}})
+	raster.FromItemAsset(&item, asset).SetBands([]raster.Band{{DataType: "uint16", Nodata: 0.0, Scale: 0.0001}})
+	assert.Equal(t, []string{eo.SchemaV2URI, raster.SchemaV2URI}, item.Extensions)
+	assert.NotContains(t, asset.AdditionalFields, "eo:bands")
+	assert.NotContains(t, asset.AdditionalFields, "raster:bands")
+
+	data, err = json.Marshal(asset.AdditionalFields["bands"])
+	require.NoError(t, err)
+	assert.JSONEq(t, `[{"name":"B04","eo:common_name":"red","eo:center_wavelength":0.665,"data_type":"uint16","nodata":0,"raster:scale":0.0001}]`, string(data),
+		"bands are written in the common form, keeping the fields of other extensions")
+
+	eoBands, ok := eo.FromAsset(asset).Bands()
+	require.True(t, ok)
+	assert.Equal(t, 0.665, eoBands[0].CenterWavelength)
+
+	t.Run("v1 item", func(t *testing.T) {
+		item := loadItem(t)
+		eo.FromItemAsset(item, item.Assets["B04"]).SetBands([]eo.Band{{Name: "B04"}})
+		assert.Contains(t, item.Assets["B04"].AdditionalFields, "eo:bands")
+		assert.Equal(t, []string{eo.SchemaURI}, item.Extensions)
+	})
+}
@@ -0,0 +1,73 @@
+// Package file implements the File Info extension.
+//
+// https://github.com/stac-extensions/file
+package file
+
+import (
+	"github.com/robert-malhotra/go-stac-client/pkg/stac"
+	"github.com/robert-malhotra/go-stac-client/pkg/stac/ext"
+)
+
+// SchemaURI identifies the extension in stac_extensions.
+const SchemaURI = "https://stac-extensions.github.io/file/v2.1.0/schema.json"
+
+// Byte orders defined by the extension.
+const (
+	BigEndian    = "big-endian"
+	LittleEndian = "little-endian"
+)
+
+const (
+	sizeKey       = "file:size"
+	checksumKey   = "file:checksum"
+	headerSizeKey = "file:header_size"
+	byteOrderKey  = "file:byte_order"
+	localPathKey  = "file:local_path"
+)
+
+// File is a typed view of file:* fields, normally found on assets and links.
+type File struct {
+	f ext.Fields
+}
+
+// FromAsset returns a view of an asset's fields. Setters do not register the
+// schema; use FromItemAsset for that.
+func FromAsset(asset *stac.Asset) File {
+	return File{ext.AssetFields(asset, nil, SchemaURI)}
+}
+
+// FromItemAsset returns a view of an asset belonging to item. Setters
+// register the schema on item.
+func FromItemAsset(item *stac.Item, asset *stac.Asset) File {
+	return File{ext.AssetFields(asset, item, SchemaURI)}
+}
+
+// Size returns file:size in bytes.
+func (f File) Size() (int64, bool) { return f.f.Int64(sizeKey) }
+
+// SetSize sets file:size.
+func (f File) SetSize(n int64) { f.f.Set(sizeKey, n) }
+
+// Checksum returns file:checksum, a hex-encoded multihash.
+func (f File) Checksum() (string, bool) { return f.f.String(checksumKey) }
+
+// SetChecksum sets file:checksum.
+func (f File) SetChecksum(multihash string) { f.f.Set(checksumKey, multihash) }
+
+// HeaderSize returns file:header_size in bytes.
+func (f File) HeaderSize() (int64, bool) { return f.f.Int64(headerSizeKey) }
+
+// SetHeaderSize sets file:header_size.
+func (f File) SetHeaderSize(n int64) { f.f.Set(headerSizeKey, n) }
+
+// ByteOrder returns file:byte_order.
+func (f File) ByteOrder() (string, bool) { return f.f.String(byteOrderKey) }
+
+// SetByteOrder sets file:byte_order.
+func (f File) SetByteOrder(order string) { f.f.Set(byteOrderKey, order) }
+
+// LocalPath returns file:local_path.
+func (f File) LocalPath() (string, bool) { return f.f.String(localPathKey) }
+
+// SetLocalPath sets file:local_path.
+func (f File) SetLocalPath(path string) { f.f.Set(localPathKey, path) }
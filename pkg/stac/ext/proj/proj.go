@@ -0,0 +1,146 @@
+// Package proj implements the Projection extension.
+//
+// https://github.com/stac-extensions/projection
+package proj
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/robert-malhotra/go-stac-client/pkg/stac"
+	"github.com/robert-malhotra/go-stac-client/pkg/stac/ext"
+)
+
+// SchemaURI identifies the extension in stac_extensions.
+const SchemaURI = "https://stac-extensions.github.io/projection/v2.0.0/schema.json"
+
+const (
+	codeKey      = "proj:code"
+	epsgKey      = "proj:epsg" // projection v1.x
+	wkt2Key      = "proj:wkt2"
+	projjsonKey  = "proj:projjson"
+	geometryKey  = "proj:geometry"
+	bboxKey      = "proj:bbox"
+	centroidKey  = "proj:centroid"
+	shapeKey     = "proj:shape"
+	transformKey = "proj:transform"
+)
+
+// Centroid is the proj:centroid of an item or asset.
+type Centroid struct {
+	Lat float64 `json:"lat"`
+	Lon float64 `json:"lon"`
+}
+
+// Projection is a typed view of proj:* fields.
+type Projection struct {
+	f ext.Fields
+}
+
+// FromItem returns a view of the item's properties.
+func FromItem(item *stac.Item) Projection {
+	return Projection{ext.ItemFields(item, SchemaURI)}
+}
+
+// FromAsset returns a view of an asset's fields. Setters do not register the
+// schema; use FromItemAsset for that.
+func FromAsset(asset *stac.Asset) Projection {
+	return Projection{ext.AssetFields(asset, nil, SchemaURI)}
+}
+
+// FromItemAsset returns a view of an asset belonging to item. Setters
+// register the schema on item.
+func FromItemAsset(item *stac.Item, asset *stac.Asset) Projection {
+	return Projection{ext.AssetFields(asset, item, SchemaURI)}
+}
+
+// Code returns the CRS identifier, e.g. "EPSG:32633". Documents using the
+// older proj:epsg field are reported as "EPSG:<code>".
+func (p Projection) Code() (string, bool) {
+	if code, ok := p.f.String(codeKey); ok {
+		return code, true
+	}
+	if epsg, ok := p.f.Int(epsgKey); ok {
+		return "EPSG:" + strconv.Itoa(epsg), true
+	}
+	return "", false
+}
+
+// SetCode sets proj:code and removes any proj:epsg.
+func (p Projection) SetCode(code string) {
+	p.f.Delete(epsgKey)
+	p.f.Set(codeKey, code)
+}
+
+// EPSG returns the EPSG code from proj:code or proj:epsg. It reports false
+// for CRSs from other authorities.
+func (p Projection) EPSG() (int, bool) {
+	code, ok := p.Code()
+	if !ok {
+		return 0, false
+	}
+	authority, id, ok := strings.Cut(code, ":")
+	if !ok || !strings.EqualFold(authority, "EPSG") {
+		return 0, false
+	}
+	n, err := strconv.Atoi(id)
+	if err != nil {
+		return 0, false
+	}
+	return n, true
+}
+
+// SetEPSG sets proj:code to "EPSG:<code>".
+func (p Projection) SetEPSG(code int) {
+	p.SetCode("EPSG:" + strconv.Itoa(code))
+}
+
+// WKT2 returns proj:wkt2.
+func (p Projection) WKT2() (string, bool) { return p.f.String(wkt2Key) }
+
+// SetWKT2 sets proj:wkt2.
+func (p Projection) SetWKT2(wkt string) { p.f.Set(wkt2Key, wkt) }
+
+// PROJJSON returns proj:projjson.
+func (p Projection) PROJJSON() (map[string]any, bool) {
+	var v map[string]any
+	ok := p.f.Decode(projjsonKey, &v)
+	return v, ok
+}
+
+// SetPROJJSON sets proj:projjson.
+func (p Projection) SetPROJJSON(v map[string]any) { p.f.Set(projjsonKey, v) }
+
+// Geometry returns proj:geometry, a GeoJSON geometry in the item's CRS.
+func (p Projection) Geometry() (any, bool) { return p.f.Value(geometryKey) }
+
+// SetGeometry sets proj:geometry.
+func (p Projection) SetGeometry(geom any) { p.f.Set(geometryKey, geom) }
+
+// BBox returns proj:bbox in the item's CRS.
+func (p Projection) BBox() ([]float64, bool) { return p.f.Floats(bboxKey) }
+
+// SetBBox sets proj:bbox.
+func (p Projection) SetBBox(bbox []float64) { p.f.Set(bboxKey, bbox) }
+
+// Centroid returns proj:centroid.
+func (p Projection) Centroid() (Centroid, bool) {
+	var c Centroid
+	ok := p.f.Decode(centroidKey, &c)
+	return c, ok
+}
+
+// SetCentroid sets proj:centroid.
+func (p Projection) SetCentroid(c Centroid) { p.f.Set(centroidKey, c) }
+
+// Shape returns proj:shape as [rows, columns].
+func (p Projection) Shape() ([]int, bool) { return p.f.Ints(shapeKey) }
+
+// SetShape sets proj:shape.
+func (p Projection) SetShape(rows, cols int) { p.f.Set(shapeKey, []int{rows, cols}) }
+
+// Transform returns the affine proj:transform coefficients.
+func (p Projection) Transform() ([]float64, bool) { return p.f.Floats(transformKey) }
+
+// SetTransform sets proj:transform.
+func (p Projection) SetTransform(transform []float64) { p.f.Set(transformKey, transform) }
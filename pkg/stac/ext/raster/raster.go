@@ -0,0 +1,134 @@
+// Package raster implements the Raster extension.
+//
+// https://github.com/stac-extensions/raster
+package raster
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/robert-malhotra/go-stac-client/pkg/stac"
+	"github.com/robert-malhotra/go-stac-client/pkg/stac/ext"
+)
+
+// SchemaURI identifies the extension in stac_extensions.
+const SchemaURI = "https://stac-extensions.github.io/raster/v1.1.0/schema.json"
+
+// SchemaV2URI identifies version 2 of the extension, used by STAC 1.1
+// documents whose bands are in the common bands array.
+const SchemaV2URI = "https://stac-extensions.github.io/raster/v2.0.0/schema.json"
+
+const (
+	bandsKey = "raster:bands"
+
+	commonBandsKey = ext.CommonBandsKey
+)
+
+// commonFields are the raster band fields that are common band metadata in
+// STAC 1.1, and so are not prefixed with raster:.
+var commonFields = []string{"nodata", "data_type", "statistics", "unit"}
+
+// Band describes a band of a raster asset.
+type Band struct {
+	// Nodata is a number, or one of the strings "nan", "inf" and "-inf".
+	Nodata            any         `json:"nodata,omitempty"`
+	Sampling          string      `json:"sampling,omitempty"`
+	DataType          string      `json:"data_type,omitempty"`
+	BitsPerSample     int         `json:"bits_per_sample,omitempty"`
+	SpatialResolution float64     `json:"spatial_resolution,omitempty"`
+	Statistics        *Statistics `json:"statistics,omitempty"`
+	Unit              string      `json:"unit,omitempty"`
+	Scale             float64     `json:"scale,omitempty"`
+	Offset            float64     `json:"offset,omitempty"`
+	Histogram         any         `json:"histogram,omitempty"`
+}
+
+// Statistics summarizes the values of a band.
+type Statistics struct {
+	Minimum      *float64 `json:"minimum,omitempty"`
+	Maximum      *float64 `json:"maximum,omitempty"`
+	Mean         *float64 `json:"mean,omitempty"`
+	Stddev       *float64 `json:"stddev,omitempty"`
+	ValidPercent *float64 `json:"valid_percent,omitempty"`
+}
+
+// Raster is a typed view of raster:* fields, normally found on assets.
+type Raster struct {
+	f ext.Fields
+}
+
+// FromItem returns a view of the item's properties.
+func FromItem(item *stac.Item) Raster {
+	return Raster{ext.ItemFields(item, SchemaURI)}
+}
+
+// FromAsset returns a view of an asset's fields. Setters do not register the
+// schema; use FromItemAsset for that.
+func FromAsset(asset *stac.Asset) Raster {
+	return Raster{ext.AssetFields(asset, nil, SchemaURI)}
+}
+
+// FromItemAsset returns a view of an asset belonging to item. Setters
+// register the schema on item.
+func FromItemAsset(item *stac.Item, asset *stac.Asset) Raster {
+	return Raster{ext.AssetFields(asset, item, SchemaURI)}
+}
+
+// Bands returns raster:bands, or for STAC 1.1 documents the raster fields of
+// the common bands array.
+func (r Raster) Bands() ([]Band, bool) {
+	var bands []Band
+	if r.f.Decode(bandsKey, &bands) {
+		return bands, true
+	}
+
+	var common []struct {
+		Nodata            any         `json:"nodata"`
+		DataType          string      `json:"data_type"`
+		Statistics        *Statistics `json:"statistics"`
+		Unit              string      `json:"unit"`
+		Sampling          string      `json:"raster:sampling"`
+		BitsPerSample     int         `json:"raster:bits_per_sample"`
+		SpatialResolution float64     `json:"raster:spatial_resolution"`
+		Scale             float64     `json:"raster:scale"`
+		Offset            float64     `json:"raster:offset"`
+		Histogram         any         `json:"raster:histogram"`
+	}
+	if !r.f.Decode(commonBandsKey, &common) {
+		return nil, false
+	}
+	bands = make([]Band, len(common))
+	for i, b := range common {
+		bands[i] = Band{
+			Nodata:            b.Nodata,
+			Sampling:          b.Sampling,
+			DataType:          b.DataType,
+			BitsPerSample:     b.BitsPerSample,
+			SpatialResolution: b.SpatialResolution,
+			Statistics:        b.Statistics,
+			Unit:              b.Unit,
+			Scale:             b.Scale,
+			Offset:            b.Offset,
+			Histogram:         b.Histogram,
+		}
+	}
+	return bands, true
+}
+
+// SetBands sets raster:bands. When the item declares raster v2, or the
+// object already holds common bands, it sets the raster fields of the common
+// bands array instead and registers SchemaV2URI.
+func (r Raster) SetBands(bands []Band) {
+	if !r.f.UsesCommonBands(bandsKey) {
+		r.f.Set(bandsKey, bands)
+		return
+	}
+	common := make([]map[string]any, len(bands))
+	for i, b := range bands {
+		common[i] = ext.BandFields(b, "raster:", commonFields...)
+	}
+	r.f.Delete(bandsKey)
+	r.f.WithSchema(SchemaV2URI).SetCommonBands(common, func(key string) bool {
+		return strings.HasPrefix(key, "raster:") || slices.Contains(commonFields, key)
+	})
+}
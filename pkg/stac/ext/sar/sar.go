@@ -0,0 +1,128 @@
+// Package sar implements the Synthetic-Aperture Radar extension.
+//
+// https://github.com/stac-extensions/sar
+package sar
+
+import (
+	"github.com/robert-malhotra/go-stac-client/pkg/stac"
+	"github.com/robert-malhotra/go-stac-client/pkg/stac/ext"
+)
+
+// SchemaURI identifies the extension in stac_extensions.
+const SchemaURI = "https://stac-extensions.github.io/sar/v1.0.0/schema.json"
+
+const (
+	instrumentModeKey        = "sar:instrument_mode"
+	frequencyBandKey         = "sar:frequency_band"
+	centerFrequencyKey       = "sar:center_frequency"
+	polarizationsKey         = "sar:polarizations"
+	productTypeKey           = "sar:product_type"
+	resolutionRangeKey       = "sar:resolution_range"
+	resolutionAzimuthKey     = "sar:resolution_azimuth"
+	pixelSpacingRangeKey     = "sar:pixel_spacing_range"
+	pixelSpacingAzimuthKey   = "sar:pixel_spacing_azimuth"
+	looksRangeKey            = "sar:looks_range"
+	looksAzimuthKey          = "sar:looks_azimuth"
+	looksEquivalentNumberKey = "sar:looks_equivalent_number"
+	observationDirectionKey  = "sar:observation_direction"
+)
+
+// SAR is a typed view of sar:* fields.
+type SAR struct {
+	f ext.Fields
+}
+
+// FromItem returns a view of the item's properties.
+func FromItem(item *stac.Item) SAR {
+	return SAR{ext.ItemFields(item, SchemaURI)}
+}
+
+// FromAsset returns a view of an asset's fields. Setters do not register the
+// schema; use FromItemAsset for that.
+func FromAsset(asset *stac.Asset) SAR {
+	return SAR{ext.AssetFields(asset, nil, SchemaURI)}
+}
+
+// FromItemAsset returns a view of an asset belonging to item. Setters
+// register the schema on item.
+func FromItemAsset(item *stac.Item, asset *stac.Asset) SAR {
+	return SAR{ext.AssetFields(asset, item, SchemaURI)}
+}
+
+// InstrumentMode returns sar:instrument_mode, e.g. "IW".
+func (s SAR) InstrumentMode() (string, bool) { return s.f.String(instrumentModeKey) }
+
+// SetInstrumentMode sets sar:instrument_mode.
+func (s SAR) SetInstrumentMode(mode string) { s.f.Set(instrumentModeKey, mode) }
+
+// FrequencyBand returns sar:frequency_band, e.g. "C".
+func (s SAR) FrequencyBand() (string, bool) { return s.f.String(frequencyBandKey) }
+
+// SetFrequencyBand sets sar:frequency_band.
+func (s SAR) SetFrequencyBand(band string) { s.f.Set(frequencyBandKey, band) }
+
+// CenterFrequency returns sar:center_frequency in gigahertz.
+func (s SAR) CenterFrequency() (float64, bool) { return s.f.Float(centerFrequencyKey) }
+
+// SetCenterFrequency sets sar:center_frequency.
+func (s SAR) SetCenterFrequency(ghz float64) { s.f.Set(centerFrequencyKey, ghz) }
+
+// Polarizations returns sar:polarizations, e.g. ["VV", "VH"].
+func (s SAR) Polarizations() ([]string, bool) { return s.f.Strings(polarizationsKey) }
+
+// SetPolarizations sets sar:polarizations.
+func (s SAR) SetPolarizations(pols []string) { s.f.Set(polarizationsKey, pols) }
+
+// ProductType returns sar:product_type, e.g. "GRD".
+func (s SAR) ProductType() (string, bool) { return s.f.String(productTypeKey) }
+
+// SetProductType sets sar:product_type.
+func (s SAR) SetProductType(t string) { s.f.Set(productTypeKey, t) }
+
+// ResolutionRange returns sar:resolution_range in meters.
+func (s SAR) ResolutionRange() (float64, bool) { return s.f.Float(resolutionRangeKey) }
+
+// SetResolutionRange sets sar:resolution_range.
+func (s SAR) SetResolutionRange(m float64) { s.f.Set(resolutionRangeKey, m) }
+
+// ResolutionAzimuth returns sar:resolution_azimuth in meters.
+func (s SAR) ResolutionAzimuth() (float64, bool) { return s.f.Float(resolutionAzimuthKey) }
+
+// SetResolutionAzimuth sets sar:resolution_azimuth.
+func (s SAR) SetResolutionAzimuth(m float64) { s.f.Set(resolutionAzimuthKey, m) }
+
+// PixelSpacingRange returns sar:pixel_spacing_range in meters.
+func (s SAR) PixelSpacingRange() (float64, bool) { return s.f.Float(pixelSpacingRangeKey) }
+
+// SetPixelSpacingRange sets sar:pixel_spacing_range.
+func (s SAR) SetPixelSpacingRange(m float64) { s.f.Set(pixelSpacingRangeKey, m) }
+
+// PixelSpacingAzimuth returns sar:pixel_spacing_azimuth in meters.
+func (s SAR) PixelSpacingAzimuth() (float64, bool) { return s.f.Float(pixelSpacingAzimuthKey) }
+
+// SetPixelSpacingAzimuth sets sar:pixel_spacing_azimuth.
+func (s SAR) SetPixelSpacingAzimuth(m float64) { s.f.Set(pixelSpacingAzimuthKey, m) }
+
+// LooksRange returns sar:looks_range.
+func (s SAR) LooksRange() (int, bool) { return s.f.Int(looksRangeKey) }
+
+// SetLooksRange sets sar:looks_range.
+func (s SAR) SetLooksRange(n int) { s.f.Set(looksRangeKey, n) }
+
+// LooksAzimuth returns sar:looks_azimuth.
+func (s SAR) LooksAzimuth() (int, bool) { return s.f.Int(looksAzimuthKey) }
+
+// SetLooksAzimuth sets sar:looks_azimuth.
+func (s SAR) SetLooksAzimuth(n int) { s.f.Set(looksAzimuthKey, n) }
+
+// LooksEquivalentNumber returns sar:looks_equivalent_number.
+func (s SAR) LooksEquivalentNumber() (float64, bool) { return s.f.Float(looksEquivalentNumberKey) }
+
+// SetLooksEquivalentNumber sets sar:looks_equivalent_number.
+func (s SAR) SetLooksEquivalentNumber(n float64) { s.f.Set(looksEquivalentNumberKey, n) }
+
+// ObservationDirection returns sar:observation_direction ("left" or "right").
+func (s SAR) ObservationDirection() (string, bool) { return s.f.String(observationDirectionKey) }
+
+// SetObservationDirection sets sar:observation_direction.
+func (s SAR) SetObservationDirection(dir string) { s.f.Set(observationDirectionKey, dir) }
@@ -0,0 +1,83 @@
+// Package sat implements the Satellite extension.
+//
+// https://github.com/stac-extensions/sat
+package sat
+
+import (
+	"time"
+
+	"github.com/robert-malhotra/go-stac-client/pkg/stac"
+	"github.com/robert-malhotra/go-stac-client/pkg/stac/ext"
+)
+
+// SchemaURI identifies the extension in stac_extensions.
+const SchemaURI = "https://stac-extensions.github.io/sat/v1.0.0/schema.json"
+
+// Orbit states defined by the extension.
+const (
+	OrbitStateAscending     = "ascending"
+	OrbitStateDescending    = "descending"
+	OrbitStateGeostationary = "geostationary"
+)
+
+const (
+	designatorKey    = "sat:platform_international_designator"
+	orbitStateKey    = "sat:orbit_state"
+	absoluteOrbitKey = "sat:absolute_orbit"
+	relativeOrbitKey = "sat:relative_orbit"
+	anxDatetimeKey   = "sat:anx_datetime"
+)
+
+// Satellite is a typed view of sat:* fields.
+type Satellite struct {
+	f ext.Fields
+}
+
+// FromItem returns a view of the item's properties.
+func FromItem(item *stac.Item) Satellite {
+	return Satellite{ext.ItemFields(item, SchemaURI)}
+}
+
+// FromAsset returns a view of an asset's fields. Setters do not register the
+// schema; use FromItemAsset for that.
+func FromAsset(asset *stac.Asset) Satellite {
+	return Satellite{ext.AssetFields(asset, nil, SchemaURI)}
+}
+
+// FromItemAsset returns a view of an asset belonging to item. Setters
+// register the schema on item.
+func FromItemAsset(item *stac.Item, asset *stac.Asset) Satellite {
+	return Satellite{ext.AssetFields(asset, item, SchemaURI)}
+}
+
+// PlatformInternationalDesignator returns sat:platform_international_designator.
+func (s Satellite) PlatformInternationalDesignator() (string, bool) {
+	return s.f.String(designatorKey)
+}
+
+// SetPlatformInternationalDesignator sets sat:platform_international_designator.
+func (s Satellite) SetPlatformInternationalDesignator(id string) { s.f.Set(designatorKey, id) }
+
+// OrbitState returns sat:orbit_state.
+func (s Satellite) OrbitState() (string, bool) { return s.f.String(orbitStateKey) }
+
+// SetOrbitState sets sat:orbit_state.
+func (s Satellite) SetOrbitState(state string) { s.f.Set(orbitStateKey, state) }
+
+// AbsoluteOrbit returns sat:absolute_orbit.
+func (s Satellite) AbsoluteOrbit() (int, bool) { return s.f.Int(absoluteOrbitKey) }
+
+// SetAbsoluteOrbit sets sat:absolute_orbit.
+func (s Satellite) SetAbsoluteOrbit(orbit int) { s.f.Set(absoluteOrbitKey, orbit) }
+
+// RelativeOrbit returns sat:relative_orbit.
+func (s Satellite) RelativeOrbit() (int, bool) { return s.f.Int(relativeOrbitKey) }
+
+// SetRelativeOrbit sets sat:relative_orbit.
+func (s Satellite) SetRelativeOrbit(orbit int) { s.f.Set(relativeOrbitKey, orbit) }
+
+// AnxDatetime returns sat:anx_datetime, the ascending node crossing time.
+func (s Satellite) AnxDatetime() (time.Time, bool) { return s.f.Time(anxDatetimeKey) }
+
+// SetAnxDatetime sets sat:anx_datetime.
+func (s Satellite) SetAnxDatetime(t time.Time) { s.f.SetTime(anxDatetimeKey, t) }
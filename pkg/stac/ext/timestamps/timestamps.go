@@ -0,0 +1,60 @@
+// Package timestamps implements the Timestamps extension.
+//
+// https://github.com/stac-extensions/timestamps
+package timestamps
+
+import (
+	"time"
+
+	"github.com/robert-malhotra/go-stac-client/pkg/stac"
+	"github.com/robert-malhotra/go-stac-client/pkg/stac/ext"
+)
+
+// SchemaURI identifies the extension in stac_extensions.
+const SchemaURI = "https://stac-extensions.github.io/timestamps/v1.1.0/schema.json"
+
+const (
+	publishedKey   = "published"
+	expiresKey     = "expires"
+	unpublishedKey = "unpublished"
+)
+
+// Timestamps is a typed view of the published, expires and unpublished fields.
+type Timestamps struct {
+	f ext.Fields
+}
+
+// FromItem returns a view of the item's properties.
+func FromItem(item *stac.Item) Timestamps {
+	return Timestamps{ext.ItemFields(item, SchemaURI)}
+}
+
+// FromAsset returns a view of an asset's fields. Setters do not register the
+// schema; use FromItemAsset for that.
+func FromAsset(asset *stac.Asset) Timestamps {
+	return Timestamps{ext.AssetFields(asset, nil, SchemaURI)}
+}
+
+// FromItemAsset returns a view of an asset belonging to item. Setters
+// register the schema on item.
+func FromItemAsset(item *stac.Item, asset *stac.Asset) Timestamps {
+	return Timestamps{ext.AssetFields(asset, item, SchemaURI)}
+}
+
+// Published returns when the data was first made available.
+func (t Timestamps) Published() (time.Time, bool) { return t.f.Time(publishedKey) }
+
+// SetPublished sets published.
+func (t Timestamps) SetPublished(ts time.Time) { t.f.SetTime(publishedKey, ts) }
+
+// Expires returns when the data may no longer be available.
+func (t Timestamps) Expires() (time.Time, bool) { return t.f.Time(expiresKey) }
+
+// SetExpires sets expires.
+func (t Timestamps) SetExpires(ts time.Time) { t.f.SetTime(expiresKey, ts) }
+
+// Unpublished returns when the data was removed.
+func (t Timestamps) Unpublished() (time.Time, bool) { return t.f.Time(unpublishedKey) }
+
+// SetUnpublished sets unpublished.
+func (t Timestamps) SetUnpublished(ts time.Time) { t.f.SetTime(unpublishedKey, ts) }
@@ -0,0 +1,72 @@
+// Package view implements the View Geometry extension.
+//
+// https://github.com/stac-extensions/view
+package view
+
+import (
+	"github.com/robert-malhotra/go-stac-client/pkg/stac"
+	"github.com/robert-malhotra/go-stac-client/pkg/stac/ext"
+)
+
+// SchemaURI identifies the extension in stac_extensions.
+const SchemaURI = "https://stac-extensions.github.io/view/v1.0.0/schema.json"
+
+const (
+	offNadirKey       = "view:off_nadir"
+	incidenceAngleKey = "view:incidence_angle"
+	azimuthKey        = "view:azimuth"
+	sunAzimuthKey     = "view:sun_azimuth"
+	sunElevationKey   = "view:sun_elevation"
+)
+
+// Geometry is a typed view of view:* fields. Angles are in degrees.
+type Geometry struct {
+	f ext.Fields
+}
+
+// FromItem returns a view of the item's properties.
+func FromItem(item *stac.Item) Geometry {
+	return Geometry{ext.ItemFields(item, SchemaURI)}
+}
+
+// FromAsset returns a view of an asset's fields. Setters do not register the
+// schema; use FromItemAsset for that.
+func FromAsset(asset *stac.Asset) Geometry {
+	return Geometry{ext.AssetFields(asset, nil, SchemaURI)}
+}
+
+// FromItemAsset returns a view of an asset belonging to item. Setters
+// register the schema on item.
+func FromItemAsset(item *stac.Item, asset *stac.Asset) Geometry {
+	return Geometry{ext.AssetFields(asset, item, SchemaURI)}
+}
+
+// OffNadir returns view:off_nadir.
+func (g Geometry) OffNadir() (float64, bool) { return g.f.Float(offNadirKey) }
+
+// SetOffNadir sets view:off_nadir.
+func (g Geometry) SetOffNadir(deg float64) { g.f.Set(offNadirKey, deg) }
+
+// IncidenceAngle returns view:incidence_angle.
+func (g Geometry) IncidenceAngle() (float64, bool) { return g.f.Float(incidenceAngleKey) }
+
+// SetIncidenceAngle sets view:incidence_angle.
+func (g Geometry) SetIncidenceAngle(deg float64) { g.f.Set(incidenceAngleKey, deg) }
+
+// Azimuth returns view:azimuth.
+func (g Geometry) Azimuth() (float64, bool) { return g.f.Float(azimuthKey) }
+
+// SetAzimuth sets view:azimuth.
+func (g Geometry) SetAzimuth(deg float64) { g.f.Set(azimuthKey, deg) }
+
+// SunAzimuth returns view:sun_azimuth.
+func (g Geometry) SunAzimuth() (float64, bool) { return g.f.Float(sunAzimuthKey) }
+
+// SetSunAzimuth sets view:sun_azimuth.
+func (g Geometry) SetSunAzimuth(deg float64) { g.f.Set(sunAzimuthKey, deg) }
+
+// SunElevation returns view:sun_elevation.
+func (g Geometry) SunElevation() (float64, bool) { return g.f.Float(sunElevationKey) }
+
+// SetSunElevation sets view:sun_elevation.
+func (g Geometry) SetSunElevation(deg float64) { g.f.Set(sunElevationKey, deg) }
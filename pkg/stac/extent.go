@@ -1,5 +1,10 @@
 package stac
 
+import (
+	"fmt"
+	"time"
+)
+
 // Extent represents the spatial and temporal extent of a STAC Collection.
 type Extent struct {
 	Spatial  *SpatialExtent  `json:"spatial,omitempty"`
@@ -15,3 +20,82 @@ type SpatialExtent struct {
 type TemporalExtent struct {
 	Interval [][]any `json:"interval"`
 }
+
+// Overall returns the bbox covering the whole collection, which the spec
+// places first, or nil when there is none.
+func (se *SpatialExtent) Overall() []float64 {
+	if se == nil || len(se.Bbox) == 0 {
+		return nil
+	}
+	return se.Bbox[0]
+}
+
+// SubBboxes returns the bboxes that follow the overall one and describe
+// clusters of data within it.
+func (se *SpatialExtent) SubBboxes() [][]float64 {
+	if se == nil || len(se.Bbox) < 2 {
+		return nil
+	}
+	return se.Bbox[1:]
+}
+
+// Intervals parses the temporal extent. A null bound becomes a zero time,
+// leaving the interval open on that side.
+func (te *TemporalExtent) Intervals() ([]TimeInterval, error) {
+	if te == nil {
+		return nil, nil
+	}
+	out := make([]TimeInterval, 0, len(te.Interval))
+	for i, pair := range te.Interval {
+		if len(pair) != 2 {
+			return nil, fmt.Errorf("interval %d: expected 2 bounds, got %d", i, len(pair))
+		}
+		start, err := intervalBound(pair[0])
+		if err != nil {
+			return nil, fmt.Errorf("interval %d: start: %w", i, err)
+		}
+		end, err := intervalBound(pair[1])
+		if err != nil {
+			return nil, fmt.Errorf("interval %d: end: %w", i, err)
+		}
+		out = append(out, TimeInterval{Start: start, End: end})
+	}
+	return out, nil
+}
+
+// Overall returns the interval covering the whole collection, which the
+// spec places first.
+func (te *TemporalExtent) Overall() (TimeInterval, error) {
+	ivs, err := te.Intervals()
+	if err != nil || len(ivs) == 0 {
+		return TimeInterval{}, err
+	}
+	return ivs[0], nil
+}
+
+// SetIntervals replaces the temporal extent. Zero bounds are written as
+// null.
+func (te *TemporalExtent) SetIntervals(ivs []TimeInterval) {
+	te.Interval = make([][]any, len(ivs))
+	for i, iv := range ivs {
+		te.Interval[i] = []any{intervalValue(iv.Start), intervalValue(iv.End)}
+	}
+}
+
+func intervalBound(v any) (time.Time, error) {
+	if v == nil {
+		return time.Time{}, nil
+	}
+	s, ok := v.(string)
+	if !ok {
+		return time.Time{}, fmt.Errorf("expected an RFC 3339 string or null, got %T", v)
+	}
+	return time.Parse(time.RFC3339Nano, s)
+}
+
+func intervalValue(t time.Time) any {
+	if t.IsZero() {
+		return nil
+	}
+	return t.UTC().Format(time.RFC3339Nano)
+}
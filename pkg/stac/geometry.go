@@ -0,0 +1,286 @@
+package stac
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/paulmach/orb"
+	"github.com/paulmach/orb/geo"
+	"github.com/paulmach/orb/geojson"
+	"github.com/paulmach/orb/planar"
+	"github.com/paulmach/orb/project"
+)
+
+// OrbGeometry decodes the item's GeoJSON geometry. It returns nil for items
+// without a geometry.
+func (item *Item) OrbGeometry() (orb.Geometry, error) {
+	switch g := item.Geometry.(type) {
+	case nil:
+		return nil, nil
+	case orb.Geometry:
+		return g, nil
+	case *geojson.Geometry:
+		if g == nil {
+			return nil, nil
+		}
+		return g.Geometry(), nil
+	}
+
+	data, err := json.Marshal(item.Geometry)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode geometry: %w", err)
+	}
+	if string(data) == "null" {
+		return nil, nil
+	}
+	g, err := geojson.UnmarshalGeometry(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode geometry: %w", err)
+	}
+	return g.Geometry(), nil
+}
+
+// SetOrbGeometry sets the item's geometry and recomputes Bbox. A nil g
+// clears both.
+func (item *Item) SetOrbGeometry(g orb.Geometry) {
+	if g == nil {
+		item.Geometry = nil
+		item.Bbox = nil
+		return
+	}
+	item.Geometry = geojson.NewGeometry(g)
+	item.Bbox = GeometryBbox(g)
+}
+
+// ComputeBbox returns the bbox of the item's geometry. See GeometryBbox.
+func (item *Item) ComputeBbox() ([]float64, error) {
+	g, err := item.OrbGeometry()
+	if err != nil {
+		return nil, err
+	}
+	return GeometryBbox(g), nil
+}
+
+// UpdateBbox sets Bbox from the item's geometry.
+func (item *Item) UpdateBbox() error {
+	bbox, err := item.ComputeBbox()
+	if err != nil {
+		return err
+	}
+	item.Bbox = bbox
+	return nil
+}
+
+// CheckBbox verifies that the item's Bbox covers its geometry, allowing
+// tolerance degrees of slack on each side.
+func (item *Item) CheckBbox(tolerance float64) error {
+	computed, err := item.ComputeBbox()
+	if err != nil {
+		return err
+	}
+	if computed == nil {
+		if item.Bbox != nil {
+			return fmt.Errorf("bbox %v set on item without geometry", item.Bbox)
+		}
+		return nil
+	}
+	if item.Bbox == nil {
+		return fmt.Errorf("item has a geometry but no bbox")
+	}
+	if _, ok := bbox2D(item.Bbox); !ok {
+		return fmt.Errorf("invalid bbox %v: expected 4 or 6 numbers", item.Bbox)
+	}
+	if !bboxContains(item.Bbox, computed, tolerance) {
+		return fmt.Errorf("bbox %v does not contain geometry bounds %v", item.Bbox, computed)
+	}
+	return nil
+}
+
+// Area returns the geodesic area of the item's geometry in square meters.
+func (item *Item) Area() (float64, error) {
+	g, err := item.OrbGeometry()
+	if err != nil || g == nil {
+		return 0, err
+	}
+	return geo.Area(g), nil
+}
+
+// Centroid returns the centroid of the item's geometry. Geometries that
+// cross the antimeridian are handled by computing in 0-360 longitudes.
+func (item *Item) Centroid() (orb.Point, error) {
+	g, err := item.OrbGeometry()
+	if err != nil {
+		return orb.Point{}, err
+	}
+	if g == nil {
+		return orb.Point{}, fmt.Errorf("item has no geometry")
+	}
+
+	if !BboxCrossesAntimeridian(GeometryBbox(g)) {
+		c, _ := planar.CentroidArea(g)
+		return c, nil
+	}
+
+	shifted := project.Geometry(orb.Clone(g), func(p orb.Point) orb.Point {
+		if p[0] < 0 {
+			p[0] += 360
+		}
+		return p
+	})
+	c, _ := planar.CentroidArea(shifted)
+	if c[0] > 180 {
+		c[0] -= 360
+	}
+	return c, nil
+}
+
+// GeometryBbox returns the [west, south, east, north] bbox of g, or nil for
+// a nil geometry.
+//
+// Geometries split at the antimeridian, such as a MultiPolygon with parts
+// near 180 and -180, get a bbox whose west edge is greater than its east
+// edge, as RFC 7946 section 5.2 describes. The smallest longitude span
+// covering every part is chosen.
+func GeometryBbox(g orb.Geometry) []float64 {
+	return boundsBbox(geometryParts(g, nil))
+}
+
+// boundsBbox returns the antimeridian-aware bbox covering parts, or nil.
+func boundsBbox(parts []orb.Bound) []float64 {
+	if len(parts) == 0 {
+		return nil
+	}
+
+	south, north := math.Inf(1), math.Inf(-1)
+	for _, b := range parts {
+		south = min(south, b.Min[1])
+		north = max(north, b.Max[1])
+	}
+
+	// Merge the parts' longitude spans, then leave out the widest gap
+	// between them, which may be the one across the antimeridian.
+	slices.SortFunc(parts, func(a, b orb.Bound) int {
+		switch {
+		case a.Min[0] < b.Min[0]:
+			return -1
+		case a.Min[0] > b.Min[0]:
+			return 1
+		}
+		return 0
+	})
+	merged := []orb.Bound{parts[0]}
+	for _, b := range parts[1:] {
+		last := &merged[len(merged)-1]
+		if b.Min[0] <= last.Max[0] {
+			last.Max[0] = max(last.Max[0], b.Max[0])
+			continue
+		}
+		merged = append(merged, b)
+	}
+
+	west, east := merged[0].Min[0], merged[len(merged)-1].Max[0]
+	widest := 360 - (east - west)
+	for i := 0; i+1 < len(merged); i++ {
+		if gap := merged[i+1].Min[0] - merged[i].Max[0]; gap > widest {
+			widest = gap
+			west, east = merged[i+1].Min[0], merged[i].Max[0]
+		}
+	}
+	return []float64{west, south, east, north}
+}
+
+// geometryParts appends the bounds of the individual parts of g to out.
+func geometryParts(g orb.Geometry, out []orb.Bound) []orb.Bound {
+	switch g := g.(type) {
+	case nil:
+	case orb.MultiPoint:
+		for _, p := range g {
+			out = append(out, p.Bound())
+		}
+	case orb.MultiLineString:
+		for _, ls := range g {
+			out = append(out, ls.Bound())
+		}
+	case orb.MultiPolygon:
+		for _, p := range g {
+			out = append(out, p.Bound())
+		}
+	case orb.Collection:
+		for _, sub := range g {
+			out = geometryParts(sub, out)
+		}
+	default:
+		out = append(out, g.Bound())
+	}
+	return out
+}
+
+// BboxCrossesAntimeridian reports whether bbox spans the antimeridian, i.e.
+// its west edge is greater than its east edge.
+func BboxCrossesAntimeridian(bbox []float64) bool {
+	b, ok := bbox2D(bbox)
+	return ok && b[0] > b[2]
+}
+
+// BboxBounds converts a 2D or 3D bbox into orb bounds. A bbox that crosses
+// the antimeridian is split into an eastern and a western part.
+func BboxBounds(bbox []float64) []orb.Bound {
+	b, ok := bbox2D(bbox)
+	if !ok {
+		return nil
+	}
+	west, south, east, north := b[0], b[1], b[2], b[3]
+	if west <= east {
+		return []orb.Bound{{Min: orb.Point{west, south}, Max: orb.Point{east, north}}}
+	}
+	return []orb.Bound{
+		{Min: orb.Point{west, south}, Max: orb.Point{180, north}},
+		{Min: orb.Point{-180, south}, Max: orb.Point{east, north}},
+	}
+}
+
+// BboxIntersects reports whether two bboxes overlap, taking antimeridian
+// crossings into account.
+func BboxIntersects(a, b []float64) bool {
+	for _, ab := range BboxBounds(a) {
+		for _, bb := range BboxBounds(b) {
+			if ab.Intersects(bb) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// bboxContains reports whether outer covers inner, with tolerance degrees
+// of slack.
+func bboxContains(outer, inner []float64, tolerance float64) bool {
+	outerBounds := BboxBounds(outer)
+	for _, ib := range BboxBounds(inner) {
+		covered := false
+		for _, ob := range outerBounds {
+			ob = ob.Pad(tolerance)
+			if ob.Contains(ib.Min) && ob.Contains(ib.Max) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			return false
+		}
+	}
+	return true
+}
+
+// bbox2D returns the horizontal extent of a 2D or 3D bbox.
+func bbox2D(bbox []float64) ([4]float64, bool) {
+	switch len(bbox) {
+	case 4:
+		return [4]float64{bbox[0], bbox[1], bbox[2], bbox[3]}, true
+	case 6:
+		return [4]float64{bbox[0], bbox[1], bbox[3], bbox[4]}, true
+	}
+	return [4]float64{}, false
+}
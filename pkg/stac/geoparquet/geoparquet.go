@@ -0,0 +1,194 @@
+// Package geoparquet writes STAC items as stac-geoparquet and reads them
+// back, for querying STAC metadata with DuckDB, GeoPandas or Spark.
+//
+// Each item is one row:
+//
+//   - type, stac_version, stac_extensions, id and collection as columns of
+//     the same name
+//   - geometry as WKB, described by the GeoParquet "geo" file metadata with
+//     the geometry types and overall bbox of the file
+//   - bbox as a struct of xmin, ymin, xmax and ymax (and zmin and zmax for 3D
+//     bboxes), computed from the geometry when the item has none and
+//     registered as the GeoParquet bbox covering
+//   - every property as a top-level column: datetime and other RFC 3339
+//     strings as UTC timestamps, numbers as int64 or double, strings and
+//     booleans as such, and arrays and objects as JSON
+//   - links as a list and assets as a map of structs, with any other fields
+//     kept as JSON in extra_fields
+//
+// Property column types are inferred across the items of the first row
+// group. Later properties that have no column, or whose values do not fit
+// the column type, are stored as JSON in the extra_properties column and
+// restored by ReadItems, so no property is lost.
+//
+//	f, err := os.Create("items.parquet")
+//	if err != nil {
+//		return err
+//	}
+//	defer f.Close()
+//	if _, err := geoparquet.WriteItems(f, cli.SearchCQL2(ctx, params)); err != nil {
+//		return err
+//	}
+//
+// Timestamps have microsecond precision and are read back in UTC. Foreign
+// members at the top level of an item are not stored.
+package geoparquet
+
+import (
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+// Version is the stac-geoparquet version recorded in the file metadata.
+const Version = "1.0.0"
+
+// geoParquetVersion is the GeoParquet version of the "geo" file metadata.
+const geoParquetVersion = "1.1.0"
+
+// DefaultRowGroupSize is the number of items per row group unless changed
+// with WithRowGroupSize.
+const DefaultRowGroupSize = 10000
+
+// Metadata keys in the Parquet file footer.
+const (
+	geoMetadataKey  = "geo"
+	stacMetadataKey = "stac-geoparquet"
+)
+
+// Row types for the fixed columns. The fields of each struct are sorted by
+// column name so that their leaf columns line up with the parquet.Group
+// nodes used for the file schema, which are sorted the same way.
+
+type bboxRow struct {
+	Xmax float64  `parquet:"xmax"`
+	Xmin float64  `parquet:"xmin"`
+	Ymax float64  `parquet:"ymax"`
+	Ymin float64  `parquet:"ymin"`
+	Zmax *float64 `parquet:"zmax,optional"`
+	Zmin *float64 `parquet:"zmin,optional"`
+}
+
+type assetRow struct {
+	Created     *string  `parquet:"created,optional"`
+	Description *string  `parquet:"description,optional"`
+	ExtraFields *string  `parquet:"extra_fields,optional"`
+	Href        string   `parquet:"href"`
+	Roles       []string `parquet:"roles,list"`
+	Title       *string  `parquet:"title,optional"`
+	Type        *string  `parquet:"type,optional"`
+}
+
+type linkRow struct {
+	ExtraFields *string `parquet:"extra_fields,optional"`
+	Href        string  `parquet:"href"`
+	Rel         string  `parquet:"rel"`
+	Title       *string `parquet:"title,optional"`
+	Type        *string `parquet:"type,optional"`
+}
+
+// column is one top-level column: the Go struct field used to build rows
+// and the node used in the file schema.
+type column struct {
+	name  string
+	field reflect.StructField
+	node  parquet.Node
+	kind  kind // for property columns
+}
+
+// Names of the fixed columns.
+const (
+	colAssets          = "assets"
+	colBbox            = "bbox"
+	colCollection      = "collection"
+	colDatetime        = "datetime"
+	colExtraProperties = "extra_properties"
+	colGeometry        = "geometry"
+	colID              = "id"
+	colLinks           = "links"
+	colExtensions      = "stac_extensions"
+	colVersion         = "stac_version"
+	colType            = "type"
+)
+
+func optionalString() parquet.Node { return parquet.Optional(parquet.String()) }
+func optionalJSON() parquet.Node   { return parquet.Optional(parquet.JSON()) }
+func double() parquet.Node         { return parquet.Leaf(parquet.DoubleType) }
+
+func timestampNode() parquet.Node {
+	return parquet.Optional(parquet.Timestamp(parquet.Microsecond))
+}
+
+// fixedColumns returns the columns every file has.
+func fixedColumns() []column {
+	return []column{
+		{name: colAssets, field: structField(colAssets, reflect.TypeOf(map[string]assetRow{}), ""),
+			node: parquet.Map(parquet.String(), parquet.Group{
+				"created":      optionalString(),
+				"description":  optionalString(),
+				"extra_fields": optionalJSON(),
+				"href":         parquet.String(),
+				"roles":        parquet.List(parquet.String()),
+				"title":        optionalString(),
+				"type":         optionalString(),
+			})},
+		{name: colBbox, field: structField(colBbox, reflect.TypeOf(&bboxRow{}), "optional"),
+			node: parquet.Optional(parquet.Group{
+				"xmax": double(),
+				"xmin": double(),
+				"ymax": double(),
+				"ymin": double(),
+				"zmax": parquet.Optional(double()),
+				"zmin": parquet.Optional(double()),
+			})},
+		{name: colCollection, field: structField(colCollection, reflect.TypeOf((*string)(nil)), "optional"),
+			node: optionalString()},
+		{name: colDatetime, field: structField(colDatetime, reflect.TypeOf(time.Time{}), "optional,timestamp(microsecond)"),
+			node: timestampNode()},
+		{name: colExtraProperties, field: structField(colExtraProperties, reflect.TypeOf((*string)(nil)), "optional"),
+			node: optionalJSON()},
+		{name: colGeometry, field: structField(colGeometry, reflect.TypeOf([]byte(nil)), "optional"),
+			node: parquet.Optional(parquet.Leaf(parquet.ByteArrayType))},
+		{name: colID, field: structField(colID, reflect.TypeOf(""), ""),
+			node: parquet.String()},
+		{name: colLinks, field: structField(colLinks, reflect.TypeOf([]linkRow(nil)), "list"),
+			node: parquet.List(parquet.Group{
+				"extra_fields": optionalJSON(),
+				"href":         parquet.String(),
+				"rel":          parquet.String(),
+				"title":        optionalString(),
+				"type":         optionalString(),
+			})},
+		{name: colExtensions, field: structField(colExtensions, reflect.TypeOf([]string(nil)), "list"),
+			node: parquet.List(parquet.String())},
+		{name: colVersion, field: structField(colVersion, reflect.TypeOf(""), ""),
+			node: parquet.String()},
+		{name: colType, field: structField(colType, reflect.TypeOf(""), ""),
+			node: parquet.String()},
+	}
+}
+
+// structField returns a struct field for the column name; the Go field name
+// is assigned when the row type is built.
+func structField(name string, typ reflect.Type, options string) reflect.StructField {
+	tag := name
+	if options != "" {
+		tag += "," + options
+	}
+	return reflect.StructField{Type: typ, Tag: reflect.StructTag(`parquet:"` + tag + `"`)}
+}
+
+// rowType builds the struct type for columns, which must be sorted by name,
+// and returns it with the index of each column's field.
+func rowType(columns []column) (reflect.Type, map[string]int) {
+	fields := make([]reflect.StructField, len(columns))
+	index := make(map[string]int, len(columns))
+	for i, c := range columns {
+		fields[i] = c.field
+		fields[i].Name = "F" + strconv.Itoa(i)
+		index[c.name] = i
+	}
+	return reflect.StructOf(fields), index
+}
@@ -0,0 +1,179 @@
+package geoparquet_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"iter"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/robert-malhotra/go-stac-client/pkg/stac"
+	"github.com/robert-malhotra/go-stac-client/pkg/stac/geoparquet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testItems = []string{`{
+	"type": "Feature",
+	"stac_version": "1.1.0",
+	"stac_extensions": ["https://stac-extensions.github.io/eo/v1.1.0/schema.json"],
+	"id": "scene-1",
+	"collection": "s2",
+	"geometry": {"type": "Polygon", "coordinates": [[[10, 50], [11, 50], [11, 51], [10, 51], [10, 50]]]},
+	"bbox": [10, 50, 11, 51],
+	"properties": {
+		"datetime": "2024-01-01T10:00:00Z",
+		"created": "2024-01-02T00:00:00.123456Z",
+		"eo:cloud_cover": 12.5,
+		"gsd": 10,
+		"platform": "sentinel-2a",
+		"instruments": ["msi"],
+		"proj:centroid": {"lat": 50.5, "lon": 10.5},
+		"sat:absolute_orbit": 1234,
+		"s2:valid": true
+	},
+	"links": [
+		{"rel": "self", "href": "https://example.com/scene-1.json", "type": "application/geo+json"},
+		{"rel": "next", "href": "https://example.com/search", "method": "POST", "body": {"page": 2}}
+	],
+	"assets": {
+		"visual": {"href": "https://example.com/visual.tif", "type": "image/tiff", "roles": ["visual"], "eo:bands": [{"name": "red"}]},
+		"thumbnail": {"href": "https://example.com/thumb.png", "title": "Thumbnail"}
+	}
+}`, `{
+	"type": "Feature",
+	"stac_version": "1.1.0",
+	"id": "scene-2",
+	"collection": "s2",
+	"geometry": {"type": "Point", "coordinates": [-120.5, 35.25]},
+	"bbox": [-120.5, 35.25, -120.5, 35.25],
+	"properties": {
+		"datetime": null,
+		"start_datetime": "2024-02-01T00:00:00Z",
+		"end_datetime": "2024-02-02T00:00:00Z",
+		"eo:cloud_cover": 3,
+		"gsd": 20,
+		"platform": "sentinel-2b",
+		"note": null
+	},
+	"links": [],
+	"assets": {}
+}`, `{
+	"type": "Feature",
+	"stac_version": "1.1.0",
+	"id": "scene-3",
+	"geometry": null,
+	"properties": {
+		"datetime": "2024-03-01T00:00:00.5Z",
+		"gsd": 10.5,
+		"late": "added after the first row group"
+	},
+	"links": [],
+	"assets": {}
+}`}
+
+func decodeItems(t *testing.T) []*stac.Item {
+	t.Helper()
+	items := make([]*stac.Item, len(testItems))
+	for i, doc := range testItems {
+		require.NoError(t, json.Unmarshal([]byte(doc), &items[i]))
+	}
+	return items
+}
+
+func seqOf(items []*stac.Item) iter.Seq2[*stac.Item, error] {
+	return func(yield func(*stac.Item, error) bool) {
+		for _, item := range items {
+			if !yield(item, nil) {
+				return
+			}
+		}
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	items := decodeItems(t)
+
+	var buf bytes.Buffer
+	n, err := geoparquet.WriteItems(&buf, seqOf(items), geoparquet.WithRowGroupSize(2))
+	require.NoError(t, err)
+	assert.Equal(t, 3, n)
+
+	var got []*stac.Item
+	for item, err := range geoparquet.ReadItems(bytes.NewReader(buf.Bytes()), int64(buf.Len())) {
+		require.NoError(t, err)
+		got = append(got, item)
+	}
+	require.Len(t, got, len(items))
+	for i := range items {
+		want, err := json.Marshal(items[i])
+		require.NoError(t, err)
+		have, err := json.Marshal(got[i])
+		require.NoError(t, err)
+		assert.JSONEq(t, string(want), string(have), items[i].Id)
+	}
+
+	t.Run("schema", func(t *testing.T) {
+		f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		require.NoError(t, err)
+		assert.Len(t, f.RowGroups(), 2)
+
+		types := make(map[string]string)
+		for _, col := range f.Schema().Fields() {
+			if col.Leaf() {
+				types[col.Name()] = col.Type().String()
+			}
+		}
+		assert.Equal(t, "TIMESTAMP(isAdjustedToUTC=true,unit=MICROS)", types["datetime"])
+		assert.Equal(t, "TIMESTAMP(isAdjustedToUTC=true,unit=MICROS)", types["start_datetime"])
+		assert.Equal(t, "DOUBLE", types["eo:cloud_cover"])
+		assert.Equal(t, "INT(64,true)", types["gsd"])
+		assert.Equal(t, "STRING", types["platform"])
+		assert.Equal(t, "BOOLEAN", types["s2:valid"])
+		assert.Equal(t, "JSON", types["instruments"])
+		assert.Equal(t, "JSON", types["proj:centroid"])
+		assert.NotContains(t, types, "late")
+		assert.NotContains(t, types, "note")
+
+		geo, ok := f.Lookup("geo")
+		require.True(t, ok)
+		assert.JSONEq(t, `{
+			"version": "1.1.0",
+			"primary_column": "geometry",
+			"columns": {"geometry": {
+				"encoding": "WKB",
+				"geometry_types": ["Point", "Polygon"],
+				"bbox": [-120.5, 35.25, 11, 51],
+				"covering": {"bbox": {
+					"xmin": ["bbox", "xmin"], "ymin": ["bbox", "ymin"],
+					"xmax": ["bbox", "xmax"], "ymax": ["bbox", "ymax"]
+				}}
+			}}
+		}`, geo)
+
+		stacMeta, ok := f.Lookup("stac-geoparquet")
+		require.True(t, ok)
+		assert.JSONEq(t, `{"version": "1.0.0"}`, stacMeta)
+	})
+}
+
+func TestWriteItems_Empty(t *testing.T) {
+	var buf bytes.Buffer
+	n, err := geoparquet.WriteItems(&buf, seqOf(nil))
+	require.NoError(t, err)
+	assert.Zero(t, n)
+
+	count := 0
+	for _, err := range geoparquet.ReadItems(bytes.NewReader(buf.Bytes()), int64(buf.Len())) {
+		require.NoError(t, err)
+		count++
+	}
+	assert.Zero(t, count)
+}
+
+func TestReadItems_NotParquet(t *testing.T) {
+	data := []byte("not a parquet file")
+	for _, err := range geoparquet.ReadItems(bytes.NewReader(data), int64(len(data))) {
+		assert.Error(t, err)
+	}
+}
@@ -0,0 +1,188 @@
+package geoparquet
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/robert-malhotra/go-stac-client/pkg/stac"
+)
+
+// kind is the type of a property column.
+type kind int
+
+const (
+	kindJSON kind = iota
+	kindBool
+	kindInt
+	kindDouble
+	kindString
+	kindTime
+)
+
+// kindOf returns the narrowest column kind for a property value.
+func kindOf(v any) kind {
+	switch v := v.(type) {
+	case bool:
+		return kindBool
+	case string:
+		if _, err := time.Parse(time.RFC3339Nano, v); err == nil {
+			return kindTime
+		}
+		return kindString
+	case int, int8, int16, int32, int64, uint8, uint16, uint32:
+		return kindInt
+	case float32:
+		return kindDouble
+	case float64:
+		if v == math.Trunc(v) && math.Abs(v) <= 1<<53 {
+			return kindInt
+		}
+		return kindDouble
+	}
+	return kindJSON
+}
+
+// merge returns the kind of a column holding values of kinds a and b.
+func merge(a, b kind) kind {
+	switch {
+	case a == b:
+		return a
+	case a == kindInt && b == kindDouble, a == kindDouble && b == kindInt:
+		return kindDouble
+	case a == kindTime && b == kindString, a == kindString && b == kindTime:
+		return kindString
+	}
+	return kindJSON
+}
+
+// fits reports whether v can be stored in a column of kind k.
+func fits(k kind, v any) bool {
+	switch got := kindOf(v); k {
+	case kindJSON:
+		return true
+	case kindDouble:
+		return got == kindInt || got == kindDouble
+	case kindString:
+		return got == kindString || got == kindTime
+	default:
+		return got == k
+	}
+}
+
+// inferColumns returns a column for every property of items, typed to fit
+// all their values. Null values do not count towards the type, and
+// properties that clash with a fixed column are left to extra_properties.
+func inferColumns(items []*stac.Item) []column {
+	kinds := make(map[string]kind)
+	for _, item := range items {
+		for name, v := range item.Properties {
+			if v == nil || !propertyColumnName(name) {
+				continue
+			}
+			k := kindOf(v)
+			if prev, ok := kinds[name]; ok {
+				k = merge(prev, k)
+			}
+			kinds[name] = k
+		}
+	}
+
+	columns := make([]column, 0, len(kinds))
+	for name, k := range kinds {
+		columns = append(columns, propertyColumn(name, k))
+	}
+	return columns
+}
+
+// propertyColumnName reports whether a property can have its own column.
+func propertyColumnName(name string) bool {
+	if name == "" || strings.ContainsAny(name, `,"`) {
+		return false
+	}
+	for _, c := range fixedColumns() {
+		if c.name == name {
+			return false
+		}
+	}
+	return true
+}
+
+func propertyColumn(name string, k kind) column {
+	c := column{name: name, kind: k}
+	switch k {
+	case kindBool:
+		c.field = structField(name, reflect.TypeOf((*bool)(nil)), "optional")
+		c.node = parquet.Optional(parquet.Leaf(parquet.BooleanType))
+	case kindInt:
+		c.field = structField(name, reflect.TypeOf((*int64)(nil)), "optional")
+		c.node = parquet.Optional(parquet.Int(64))
+	case kindDouble:
+		c.field = structField(name, reflect.TypeOf((*float64)(nil)), "optional")
+		c.node = parquet.Optional(double())
+	case kindString:
+		c.field = structField(name, reflect.TypeOf((*string)(nil)), "optional")
+		c.node = optionalString()
+	case kindTime:
+		c.field = structField(name, reflect.TypeOf(time.Time{}), "optional,timestamp(microsecond)")
+		c.node = timestampNode()
+	default:
+		c.field = structField(name, reflect.TypeOf((*string)(nil)), "optional")
+		c.node = optionalJSON()
+	}
+	return c
+}
+
+// setProperty stores v, which fits the column kind, in field.
+func setProperty(field reflect.Value, k kind, v any) error {
+	switch k {
+	case kindBool:
+		b := v.(bool)
+		field.Set(reflect.ValueOf(&b))
+	case kindInt:
+		n := int64(toFloat(v))
+		field.Set(reflect.ValueOf(&n))
+	case kindDouble:
+		f := toFloat(v)
+		field.Set(reflect.ValueOf(&f))
+	case kindString:
+		s := v.(string)
+		field.Set(reflect.ValueOf(&s))
+	case kindTime:
+		t, err := time.Parse(time.RFC3339Nano, v.(string))
+		if err != nil {
+			return err
+		}
+		field.Set(reflect.ValueOf(t.UTC()))
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		s := string(data)
+		field.Set(reflect.ValueOf(&s))
+	}
+	return nil
+}
+
+func toFloat(v any) float64 {
+	rv := reflect.ValueOf(v)
+	switch {
+	case rv.CanInt():
+		return float64(rv.Int())
+	case rv.CanUint():
+		return float64(rv.Uint())
+	case rv.CanFloat():
+		return rv.Float()
+	}
+	return 0
+}
+
+// sortColumns orders columns by name, the order of parquet.Group.
+func sortColumns(columns []column) {
+	slices.SortFunc(columns, func(a, b column) int { return strings.Compare(a.name, b.name) })
+}
@@ -0,0 +1,277 @@
+package geoparquet
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"reflect"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/paulmach/orb/encoding/wkb"
+	"github.com/paulmach/orb/geojson"
+	"github.com/robert-malhotra/go-stac-client/pkg/stac"
+)
+
+// ReadItems returns the items of the stac-geoparquet file in r, which is
+// size bytes long. Rows are decoded one at a time, so memory use does not
+// grow with the file.
+//
+// Besides the columns written by Writer, property columns may be any
+// Parquet boolean, integer, floating point, string, JSON or timestamp
+// column; other property column types are reported as an error.
+func ReadItems(r io.ReaderAt, size int64) iter.Seq2[*stac.Item, error] {
+	return func(yield func(*stac.Item, error) bool) {
+		f, err := parquet.OpenFile(r, size)
+		if err != nil {
+			yield(nil, fmt.Errorf("error opening parquet file: %w", err))
+			return
+		}
+		columns, err := readColumns(f.Schema())
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		typ, index := rowType(columns)
+
+		reader := parquet.NewReader(f, parquet.SchemaOf(reflect.New(typ).Interface()))
+		defer reader.Close()
+		for {
+			v := reflect.New(typ)
+			if err := reader.Read(v.Interface()); err != nil {
+				if !errors.Is(err, io.EOF) {
+					yield(nil, fmt.Errorf("error reading row: %w", err))
+				}
+				return
+			}
+			item, err := decode(v.Elem(), columns, index)
+			if !yield(item, err) || err != nil {
+				return
+			}
+		}
+	}
+}
+
+// readColumns maps the top-level columns of a file to row struct fields.
+func readColumns(schema *parquet.Schema) ([]column, error) {
+	fixed := make(map[string]column)
+	for _, c := range fixedColumns() {
+		fixed[c.name] = c
+	}
+
+	var columns []column
+	for _, node := range schema.Fields() {
+		name := node.Name()
+		if c, ok := fixed[name]; ok {
+			if name == colDatetime {
+				c.field = timestampField(name, node)
+			}
+			columns = append(columns, c)
+			continue
+		}
+		if !node.Leaf() {
+			return nil, fmt.Errorf("column %s: unsupported nested property column", name)
+		}
+		k, err := columnKind(node)
+		if err != nil {
+			return nil, fmt.Errorf("column %s: %w", name, err)
+		}
+		c := propertyColumn(name, k)
+		if k == kindTime {
+			c.field = timestampField(name, node)
+		}
+		columns = append(columns, c)
+	}
+	return columns, nil
+}
+
+// columnKind returns the property kind of a leaf column.
+func columnKind(node parquet.Node) (kind, error) {
+	typ := node.Type()
+	if lt := typ.LogicalType(); lt != nil {
+		switch {
+		case lt.Json != nil:
+			return kindJSON, nil
+		case lt.Timestamp != nil:
+			return kindTime, nil
+		case lt.UTF8 != nil, lt.Enum != nil:
+			return kindString, nil
+		}
+	}
+	switch typ.Kind() {
+	case parquet.Boolean:
+		return kindBool, nil
+	case parquet.Int32, parquet.Int64:
+		return kindInt, nil
+	case parquet.Float, parquet.Double:
+		return kindDouble, nil
+	case parquet.ByteArray:
+		return kindString, nil
+	}
+	return 0, fmt.Errorf("unsupported type %s", typ)
+}
+
+// timestampField returns a time.Time field in the time unit of node.
+func timestampField(name string, node parquet.Node) reflect.StructField {
+	unit := "microsecond"
+	if lt := node.Type().LogicalType(); lt != nil && lt.Timestamp != nil {
+		switch {
+		case lt.Timestamp.Unit.Millis != nil:
+			unit = "millisecond"
+		case lt.Timestamp.Unit.Nanos != nil:
+			unit = "nanosecond"
+		}
+	}
+	return structField(name, reflect.TypeOf(time.Time{}), "optional,timestamp("+unit+")")
+}
+
+// decode builds an item from a row struct.
+func decode(v reflect.Value, columns []column, index map[string]int) (*stac.Item, error) {
+	item := &stac.Item{
+		Properties: make(map[string]any),
+		Links:      []*stac.Link{},
+		Assets:     make(map[string]*stac.Asset),
+	}
+	extra := ""
+
+	for _, c := range columns {
+		f := v.Field(index[c.name])
+		switch c.name {
+		case colType:
+			item.Type = f.String()
+		case colVersion:
+			item.Version = f.String()
+		case colExtensions:
+			item.Extensions = f.Interface().([]string)
+		case colID:
+			item.Id = f.String()
+		case colCollection:
+			if s := f.Interface().(*string); s != nil {
+				item.Collection = *s
+			}
+		case colGeometry:
+			if data := f.Bytes(); data != nil {
+				g, err := wkb.Unmarshal(data)
+				if err != nil {
+					return nil, fmt.Errorf("item %s: invalid geometry: %w", item.Id, err)
+				}
+				item.Geometry = geojson.NewGeometry(g)
+			}
+		case colBbox:
+			if b := f.Interface().(*bboxRow); b != nil {
+				item.Bbox = []float64{b.Xmin, b.Ymin, b.Xmax, b.Ymax}
+				if b.Zmin != nil && b.Zmax != nil {
+					item.Bbox = []float64{b.Xmin, b.Ymin, *b.Zmin, b.Xmax, b.Ymax, *b.Zmax}
+				}
+			}
+		case colLinks:
+			for _, l := range f.Interface().([]linkRow) {
+				link := &stac.Link{Href: l.Href, Rel: l.Rel, Type: deref(l.Type), Title: deref(l.Title)}
+				if err := unmarshalExtra(l.ExtraFields, &link.AdditionalFields); err != nil {
+					return nil, fmt.Errorf("item %s: link %s: %w", item.Id, l.Href, err)
+				}
+				item.Links = append(item.Links, link)
+			}
+		case colAssets:
+			for key, a := range f.Interface().(map[string]assetRow) {
+				asset := &stac.Asset{
+					Href:        a.Href,
+					Type:        deref(a.Type),
+					Title:       deref(a.Title),
+					Description: deref(a.Description),
+					Created:     deref(a.Created),
+					Roles:       a.Roles,
+				}
+				if len(asset.Roles) == 0 {
+					asset.Roles = nil
+				}
+				if err := unmarshalExtra(a.ExtraFields, &asset.AdditionalFields); err != nil {
+					return nil, fmt.Errorf("item %s: asset %s: %w", item.Id, key, err)
+				}
+				item.Assets[key] = asset
+			}
+		case colDatetime:
+			item.Properties[colDatetime] = formatTime(f.Interface().(time.Time))
+		case colExtraProperties:
+			extra = deref(f.Interface().(*string))
+		default:
+			value, err := property(f, c.kind)
+			if err != nil {
+				return nil, fmt.Errorf("item %s: property %s: %w", item.Id, c.name, err)
+			}
+			if value != nil {
+				item.Properties[c.name] = value
+			}
+		}
+	}
+	if len(item.Extensions) == 0 {
+		item.Extensions = nil
+	}
+
+	if extra != "" {
+		var props map[string]any
+		if err := json.Unmarshal([]byte(extra), &props); err != nil {
+			return nil, fmt.Errorf("item %s: invalid extra_properties: %w", item.Id, err)
+		}
+		for name, value := range props {
+			item.Properties[name] = value
+		}
+	}
+	return item, nil
+}
+
+// property returns the value of a property column, or nil when it is null.
+// Numbers are returned as float64 like properties decoded from JSON.
+func property(f reflect.Value, k kind) (any, error) {
+	if k == kindTime {
+		if t := f.Interface().(time.Time); !t.IsZero() {
+			return formatTime(t), nil
+		}
+		return nil, nil
+	}
+	if f.IsNil() {
+		return nil, nil
+	}
+	switch v := f.Elem().Interface().(type) {
+	case bool:
+		return v, nil
+	case int64:
+		return float64(v), nil
+	case float64:
+		return v, nil
+	case string:
+		if k != kindJSON {
+			return v, nil
+		}
+		var value any
+		if err := json.Unmarshal([]byte(v), &value); err != nil {
+			return nil, err
+		}
+		return value, nil
+	}
+	return nil, nil
+}
+
+// formatTime formats a timestamp column value, which is zero when null.
+func formatTime(t time.Time) any {
+	if t.IsZero() {
+		return nil
+	}
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+func unmarshalExtra(s *string, fields *map[string]any) error {
+	if s == nil {
+		return nil
+	}
+	return json.Unmarshal([]byte(*s), fields)
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
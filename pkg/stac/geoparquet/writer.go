@@ -0,0 +1,330 @@
+package geoparquet
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"iter"
+	"maps"
+	"math"
+	"reflect"
+	"slices"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/paulmach/orb/encoding/wkb"
+	"github.com/robert-malhotra/go-stac-client/pkg/stac"
+)
+
+// Option configures a Writer.
+type Option func(*Writer)
+
+// WithRowGroupSize sets how many items are written per row group; it
+// bounds how many items the Writer holds in memory. The first row group
+// also decides the property columns. The default is DefaultRowGroupSize.
+func WithRowGroupSize(n int) Option {
+	return func(w *Writer) {
+		if n > 0 {
+			w.rowGroupSize = n
+		}
+	}
+}
+
+// Writer writes items to a stac-geoparquet file. Items are held back until
+// the first row group is complete, to infer the property columns, and then
+// written in row groups. Close writes the file footer.
+type Writer struct {
+	out          io.Writer
+	rowGroupSize int
+
+	pending []*stac.Item
+	pw      *parquet.Writer
+	schema  *parquet.Schema // row struct schema, lined up with the file schema
+	typ     reflect.Type
+	index   map[string]int
+	props   map[string]column
+
+	geometryTypes map[string]bool
+	bbox          []float64
+	closed        bool
+}
+
+// NewWriter returns a Writer that writes a stac-geoparquet file to w.
+func NewWriter(w io.Writer, opts ...Option) *Writer {
+	wr := &Writer{
+		out:           w,
+		rowGroupSize:  DefaultRowGroupSize,
+		geometryTypes: make(map[string]bool),
+	}
+	for _, opt := range opts {
+		opt(wr)
+	}
+	return wr
+}
+
+// WriteItems writes the items of seq to w as a stac-geoparquet file and
+// returns how many were written.
+func WriteItems(w io.Writer, seq iter.Seq2[*stac.Item, error], opts ...Option) (int, error) {
+	wr := NewWriter(w, opts...)
+	n := 0
+	for item, err := range seq {
+		if err != nil {
+			return n, err
+		}
+		if err := wr.Write(item); err != nil {
+			return n, err
+		}
+		n++
+	}
+	return n, wr.Close()
+}
+
+// Write adds an item to the file.
+func (w *Writer) Write(item *stac.Item) error {
+	if w.closed {
+		return fmt.Errorf("write after close")
+	}
+	if w.pw != nil {
+		return w.writeRow(item)
+	}
+	w.pending = append(w.pending, item)
+	if len(w.pending) < w.rowGroupSize {
+		return nil
+	}
+	return w.start()
+}
+
+// Close writes any held-back items and the file footer with the GeoParquet
+// metadata. It does not close the underlying io.Writer.
+func (w *Writer) Close() error {
+	if w.closed {
+		return nil
+	}
+	if w.pw == nil {
+		if err := w.start(); err != nil {
+			return err
+		}
+	}
+	w.closed = true
+
+	geo, err := json.Marshal(w.geoMetadata())
+	if err != nil {
+		return err
+	}
+	w.pw.SetKeyValueMetadata(geoMetadataKey, string(geo))
+	w.pw.SetKeyValueMetadata(stacMetadataKey, `{"version":"`+Version+`"}`)
+	return w.pw.Close()
+}
+
+// start infers the schema from the held-back items, opens the Parquet
+// writer and writes them.
+func (w *Writer) start() error {
+	props := inferColumns(w.pending)
+	w.props = make(map[string]column, len(props))
+	for _, c := range props {
+		w.props[c.name] = c
+	}
+
+	columns := append(fixedColumns(), props...)
+	sortColumns(columns)
+	group := make(parquet.Group, len(columns))
+	for _, c := range columns {
+		group[c.name] = c.node
+	}
+	w.typ, w.index = rowType(columns)
+	w.schema = parquet.SchemaOf(reflect.New(w.typ).Interface())
+	w.pw = parquet.NewWriter(w.out,
+		parquet.NewSchema("item", group),
+		parquet.MaxRowsPerRowGroup(int64(w.rowGroupSize)),
+		parquet.Compression(&parquet.Zstd),
+	)
+
+	pending := w.pending
+	w.pending = nil
+	for _, item := range pending {
+		if err := w.writeRow(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *Writer) writeRow(item *stac.Item) error {
+	v := reflect.New(w.typ)
+	if err := w.encode(item, v.Elem()); err != nil {
+		return fmt.Errorf("item %s: %w", item.Id, err)
+	}
+	row := w.schema.Deconstruct(nil, v.Interface())
+	_, err := w.pw.WriteRows([]parquet.Row{row})
+	return err
+}
+
+// encode fills the row struct v from item.
+func (w *Writer) encode(item *stac.Item, v reflect.Value) error {
+	field := func(name string) reflect.Value { return v.Field(w.index[name]) }
+
+	field(colType).SetString(item.Type)
+	field(colVersion).SetString(item.Version)
+	field(colExtensions).Set(reflect.ValueOf(item.Extensions))
+	field(colID).SetString(item.Id)
+	if item.Collection != "" {
+		field(colCollection).Set(reflect.ValueOf(&item.Collection))
+	}
+
+	g, err := item.OrbGeometry()
+	if err != nil {
+		return err
+	}
+	bbox := item.Bbox
+	if g != nil {
+		data, err := wkb.Marshal(g)
+		if err != nil {
+			return err
+		}
+		field(colGeometry).SetBytes(data)
+		w.geometryTypes[g.GeoJSONType()] = true
+		if len(bbox) == 0 {
+			bbox = stac.GeometryBbox(g)
+		}
+	}
+	if b := bboxOf(bbox); b != nil {
+		field(colBbox).Set(reflect.ValueOf(b))
+		w.extendBbox(b)
+	}
+
+	links := make([]linkRow, 0, len(item.Links))
+	for _, l := range item.Links {
+		if l == nil {
+			continue
+		}
+		extra, err := extraFields(l.AdditionalFields)
+		if err != nil {
+			return err
+		}
+		links = append(links, linkRow{Href: l.Href, Rel: l.Rel, Title: optional(l.Title), Type: optional(l.Type), ExtraFields: extra})
+	}
+	field(colLinks).Set(reflect.ValueOf(links))
+
+	assets := make(map[string]assetRow, len(item.Assets))
+	for key, a := range item.Assets {
+		if a == nil {
+			continue
+		}
+		extra, err := extraFields(a.AdditionalFields)
+		if err != nil {
+			return err
+		}
+		assets[key] = assetRow{
+			Href:        a.Href,
+			Type:        optional(a.Type),
+			Title:       optional(a.Title),
+			Description: optional(a.Description),
+			Created:     optional(a.Created),
+			Roles:       a.Roles,
+			ExtraFields: extra,
+		}
+	}
+	field(colAssets).Set(reflect.ValueOf(assets))
+
+	extra := make(map[string]any)
+	for name, value := range item.Properties {
+		if name == colDatetime {
+			if s, ok := value.(string); ok {
+				if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+					field(colDatetime).Set(reflect.ValueOf(t.UTC()))
+					continue
+				}
+			}
+			if value == nil {
+				continue
+			}
+		}
+		c, ok := w.props[name]
+		if !ok || value == nil || !fits(c.kind, value) {
+			extra[name] = value
+			continue
+		}
+		if err := setProperty(field(name), c.kind, value); err != nil {
+			return fmt.Errorf("property %s: %w", name, err)
+		}
+	}
+	if len(extra) > 0 {
+		data, err := json.Marshal(extra)
+		if err != nil {
+			return err
+		}
+		s := string(data)
+		field(colExtraProperties).Set(reflect.ValueOf(&s))
+	}
+	return nil
+}
+
+// bboxOf converts a 2D or 3D bbox to its column value.
+func bboxOf(bbox []float64) *bboxRow {
+	switch len(bbox) {
+	case 4:
+		return &bboxRow{Xmin: bbox[0], Ymin: bbox[1], Xmax: bbox[2], Ymax: bbox[3]}
+	case 6:
+		return &bboxRow{Xmin: bbox[0], Ymin: bbox[1], Zmin: &bbox[2], Xmax: bbox[3], Ymax: bbox[4], Zmax: &bbox[5]}
+	}
+	return nil
+}
+
+// extendBbox grows the file bbox recorded in the "geo" metadata.
+func (w *Writer) extendBbox(b *bboxRow) {
+	if w.bbox == nil {
+		w.bbox = []float64{math.Inf(1), math.Inf(1), math.Inf(-1), math.Inf(-1)}
+	}
+	w.bbox[0] = min(w.bbox[0], b.Xmin)
+	w.bbox[1] = min(w.bbox[1], b.Ymin)
+	w.bbox[2] = max(w.bbox[2], b.Xmax)
+	w.bbox[3] = max(w.bbox[3], b.Ymax)
+}
+
+// geoMetadata returns the GeoParquet "geo" file metadata.
+func (w *Writer) geoMetadata() map[string]any {
+	types := slices.Sorted(maps.Keys(w.geometryTypes))
+	if types == nil {
+		types = []string{}
+	}
+	geometry := map[string]any{
+		"encoding":       "WKB",
+		"geometry_types": types,
+		"covering": map[string]any{
+			"bbox": map[string][]string{
+				"xmin": {colBbox, "xmin"},
+				"ymin": {colBbox, "ymin"},
+				"xmax": {colBbox, "xmax"},
+				"ymax": {colBbox, "ymax"},
+			},
+		},
+	}
+	if w.bbox != nil {
+		geometry["bbox"] = w.bbox
+	}
+	return map[string]any{
+		"version":        geoParquetVersion,
+		"primary_column": colGeometry,
+		"columns":        map[string]any{colGeometry: geometry},
+	}
+}
+
+// extraFields encodes foreign members as JSON, or nil when there are none.
+func extraFields(fields map[string]any) (*string, error) {
+	if len(fields) == 0 {
+		return nil, nil
+	}
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+	s := string(data)
+	return &s, nil
+}
+
+func optional(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
@@ -0,0 +1,221 @@
+package stac
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"io"
+	"iter"
+	"slices"
+	"strings"
+)
+
+// ItemCollection represents a GeoJSON FeatureCollection of STAC Items, such
+// as an ItemCollection page or a saved set of search results, with support
+// for foreign members.
+type ItemCollection struct {
+	Type           string         `json:"type"`
+	Features       []*Item        `json:"features"`
+	Links          []*Link        `json:"links,omitempty"`
+	NumberMatched  *int           `json:"numberMatched,omitempty"`
+	NumberReturned *int           `json:"numberReturned,omitempty"`
+	Context        *SearchContext `json:"context,omitempty"`
+
+	// AdditionalFields holds foreign members not defined in the STAC spec.
+	AdditionalFields map[string]any `json:"-"`
+}
+
+// SearchContext is the "context" member of the STAC API context extension,
+// which predates numberMatched and numberReturned.
+type SearchContext struct {
+	Returned int  `json:"returned"`
+	Limit    *int `json:"limit,omitempty"`
+	Matched  *int `json:"matched,omitempty"`
+}
+
+var knownItemCollectionFields = map[string]bool{
+	"type": true, "features": true, "links": true,
+	"numberMatched": true, "numberReturned": true, "context": true,
+}
+
+// NewItemCollection returns a FeatureCollection holding items.
+func NewItemCollection(items []*Item) *ItemCollection {
+	if items == nil {
+		items = []*Item{}
+	}
+	return &ItemCollection{Type: "FeatureCollection", Features: items}
+}
+
+// CollectItems drains seq into a new ItemCollection. On error it returns the
+// items gathered so far together with the error.
+func CollectItems(seq iter.Seq2[*Item, error]) (*ItemCollection, error) {
+	ic := NewItemCollection(nil)
+	for item, err := range seq {
+		if err != nil {
+			return ic, err
+		}
+		ic.Features = append(ic.Features, item)
+	}
+	return ic, nil
+}
+
+// ReadItemCollection decodes a FeatureCollection from r.
+func ReadItemCollection(r io.Reader) (*ItemCollection, error) {
+	var ic ItemCollection
+	if err := json.NewDecoder(r).Decode(&ic); err != nil {
+		return nil, fmt.Errorf("failed to decode item collection: %w", err)
+	}
+	return &ic, nil
+}
+
+// Matched returns the total number of items matching the search that
+// produced the collection, from numberMatched or the context extension.
+func (ic *ItemCollection) Matched() (int, bool) {
+	if ic.NumberMatched != nil {
+		return *ic.NumberMatched, true
+	}
+	if ic.Context != nil && ic.Context.Matched != nil {
+		return *ic.Context.Matched, true
+	}
+	return 0, false
+}
+
+// All returns an iterator over the features, in the shape of the client's
+// paginated iterators.
+func (ic *ItemCollection) All() iter.Seq2[*Item, error] {
+	return func(yield func(*Item, error) bool) {
+		for _, item := range ic.Features {
+			if !yield(item, nil) {
+				return
+			}
+		}
+	}
+}
+
+// SortFunc sorts the features with cmp, keeping the order of equal items.
+func (ic *ItemCollection) SortFunc(cmp func(a, b *Item) int) {
+	slices.SortStableFunc(ic.Features, cmp)
+}
+
+// SortByDatetime sorts the features by the start of their time range,
+// oldest first. Items without a valid time sort last.
+func (ic *ItemCollection) SortByDatetime() {
+	ic.SortFunc(func(a, b *Item) int {
+		ta, erra := a.TimeRange()
+		tb, errb := b.TimeRange()
+		za, zb := erra != nil || ta.Start.IsZero(), errb != nil || tb.Start.IsZero()
+		switch {
+		case za || zb:
+			return compareBool(za, zb)
+		default:
+			return ta.Start.Compare(tb.Start)
+		}
+	})
+}
+
+// SortByID sorts the features by collection and then by ID.
+func (ic *ItemCollection) SortByID() {
+	ic.SortFunc(func(a, b *Item) int {
+		return cmp.Or(strings.Compare(a.Collection, b.Collection), strings.Compare(a.Id, b.Id))
+	})
+}
+
+// Dedupe removes features that repeat the collection and ID of an earlier
+// feature, as happens when overlapping searches are merged, and returns the
+// number removed.
+func (ic *ItemCollection) Dedupe() int {
+	type key struct{ collection, id string }
+	seen := make(map[key]bool, len(ic.Features))
+	n := len(ic.Features)
+	ic.Features = slices.DeleteFunc(ic.Features, func(item *Item) bool {
+		if item == nil {
+			return true
+		}
+		k := key{item.Collection, item.Id}
+		if seen[k] {
+			return true
+		}
+		seen[k] = true
+		return false
+	})
+	return n - len(ic.Features)
+}
+
+// WriteGeoJSON writes the collection to w as indented GeoJSON.
+func (ic *ItemCollection) WriteGeoJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(ic); err != nil {
+		return fmt.Errorf("failed to encode item collection: %w", err)
+	}
+	return nil
+}
+
+// UnmarshalJSON implements custom unmarshaling to capture foreign members.
+func (ic *ItemCollection) UnmarshalJSON(data []byte) error {
+	type itemCollectionAlias ItemCollection
+	var aux itemCollectionAlias
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	*ic = ItemCollection(aux)
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	ic.AdditionalFields = make(map[string]any)
+	for key, val := range raw {
+		if !knownItemCollectionFields[key] {
+			var decoded any
+			if err := json.Unmarshal(val, &decoded); err != nil {
+				continue
+			}
+			ic.AdditionalFields[key] = decoded
+		}
+	}
+
+	return nil
+}
+
+// MarshalJSON implements custom marshaling to include foreign members.
+func (ic ItemCollection) MarshalJSON() ([]byte, error) {
+	type itemCollectionAlias ItemCollection
+	aux := itemCollectionAlias(ic)
+
+	data, err := json.Marshal(aux)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ic.AdditionalFields) == 0 {
+		return data, nil
+	}
+
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return nil, err
+	}
+
+	for key, val := range ic.AdditionalFields {
+		encoded, err := json.Marshal(val)
+		if err != nil {
+			return nil, err
+		}
+		obj[key] = encoded
+	}
+
+	return json.Marshal(obj)
+}
+
+func compareBool(a, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case a:
+		return 1
+	default:
+		return -1
+	}
+}
@@ -0,0 +1,190 @@
+package stac
+
+import (
+	"fmt"
+	"time"
+)
+
+// TimeInterval is a span of time. A zero Start or End means the interval is
+// open on that side.
+type TimeInterval struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Contains reports whether t falls within the interval, inclusive.
+func (iv TimeInterval) Contains(t time.Time) bool {
+	if !iv.Start.IsZero() && t.Before(iv.Start) {
+		return false
+	}
+	if !iv.End.IsZero() && t.After(iv.End) {
+		return false
+	}
+	return true
+}
+
+// Datetime returns the item's datetime property. It returns the zero time
+// when the property is null or missing, as for items that only have a
+// start_datetime/end_datetime range.
+func (item *Item) Datetime() (time.Time, error) {
+	return item.timeProperty("datetime")
+}
+
+// SetDatetime sets the datetime property. A zero t writes null.
+func (item *Item) SetDatetime(t time.Time) {
+	if t.IsZero() {
+		item.setProperty("datetime", nil)
+		return
+	}
+	item.setTimeProperty("datetime", t)
+}
+
+// StartDatetime returns the start_datetime property, or the zero time.
+func (item *Item) StartDatetime() (time.Time, error) {
+	return item.timeProperty("start_datetime")
+}
+
+// EndDatetime returns the end_datetime property, or the zero time.
+func (item *Item) EndDatetime() (time.Time, error) {
+	return item.timeProperty("end_datetime")
+}
+
+// TimeRange returns the time covered by the item: start_datetime to
+// end_datetime when present, otherwise the instant given by datetime.
+func (item *Item) TimeRange() (TimeInterval, error) {
+	start, err := item.StartDatetime()
+	if err != nil {
+		return TimeInterval{}, err
+	}
+	end, err := item.EndDatetime()
+	if err != nil {
+		return TimeInterval{}, err
+	}
+	if !start.IsZero() || !end.IsZero() {
+		return TimeInterval{Start: start, End: end}, nil
+	}
+
+	dt, err := item.Datetime()
+	if err != nil {
+		return TimeInterval{}, err
+	}
+	return TimeInterval{Start: dt, End: dt}, nil
+}
+
+// SetTimeRange sets start_datetime and end_datetime, removing either one
+// whose bound is zero. If the item has no datetime yet, it is set to null as
+// the spec requires for ranges.
+func (item *Item) SetTimeRange(iv TimeInterval) {
+	item.setTimeProperty("start_datetime", iv.Start)
+	item.setTimeProperty("end_datetime", iv.End)
+	if _, ok := item.Properties["datetime"]; !ok {
+		item.setProperty("datetime", nil)
+	}
+}
+
+// Created returns the created property, or the zero time.
+func (item *Item) Created() (time.Time, error) {
+	return item.timeProperty("created")
+}
+
+// SetCreated sets the created property. A zero t removes it.
+func (item *Item) SetCreated(t time.Time) {
+	item.setTimeProperty("created", t)
+}
+
+// Updated returns the updated property, or the zero time.
+func (item *Item) Updated() (time.Time, error) {
+	return item.timeProperty("updated")
+}
+
+// SetUpdated sets the updated property. A zero t removes it.
+func (item *Item) SetUpdated(t time.Time) {
+	item.setTimeProperty("updated", t)
+}
+
+// Platform returns the platform property, e.g. "sentinel-2a".
+func (item *Item) Platform() string {
+	s, _ := item.Properties["platform"].(string)
+	return s
+}
+
+// SetPlatform sets the platform property.
+func (item *Item) SetPlatform(platform string) {
+	item.setProperty("platform", platform)
+}
+
+// Instruments returns the instruments property.
+func (item *Item) Instruments() []string {
+	switch v := item.Properties["instruments"].(type) {
+	case []string:
+		return v
+	case []any:
+		out := make([]string, 0, len(v))
+		for _, e := range v {
+			if s, ok := e.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	}
+	return nil
+}
+
+// SetInstruments sets the instruments property.
+func (item *Item) SetInstruments(instruments []string) {
+	item.setProperty("instruments", instruments)
+}
+
+// Constellation returns the constellation property, e.g. "sentinel-2".
+func (item *Item) Constellation() string {
+	s, _ := item.Properties["constellation"].(string)
+	return s
+}
+
+// SetConstellation sets the constellation property.
+func (item *Item) SetConstellation(constellation string) {
+	item.setProperty("constellation", constellation)
+}
+
+// GSD returns the ground sample distance in meters. ok is false when the
+// property is missing or not a number.
+func (item *Item) GSD() (meters float64, ok bool) {
+	return toFloat(item.Properties["gsd"])
+}
+
+// SetGSD sets the gsd property.
+func (item *Item) SetGSD(meters float64) {
+	item.setProperty("gsd", meters)
+}
+
+func (item *Item) timeProperty(key string) (time.Time, error) {
+	v, ok := item.Properties[key]
+	if !ok || v == nil {
+		return time.Time{}, nil
+	}
+	s, ok := v.(string)
+	if !ok {
+		return time.Time{}, fmt.Errorf("%s: expected an RFC 3339 string, got %T", key, v)
+	}
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s: %w", key, err)
+	}
+	return t, nil
+}
+
+// setTimeProperty writes t in UTC, or removes key when t is zero.
+func (item *Item) setTimeProperty(key string, t time.Time) {
+	if t.IsZero() {
+		delete(item.Properties, key)
+		return
+	}
+	item.setProperty(key, t.UTC().Format(time.RFC3339Nano))
+}
+
+func (item *Item) setProperty(key string, value any) {
+	if item.Properties == nil {
+		item.Properties = make(map[string]any)
+	}
+	item.Properties[key] = value
+}
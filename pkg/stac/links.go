@@ -0,0 +1,271 @@
+package stac
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// Common link relation types.
+const (
+	RelSelf       = "self"
+	RelRoot       = "root"
+	RelParent     = "parent"
+	RelChild      = "child"
+	RelItem       = "item"
+	RelItems      = "items"
+	RelCollection = "collection"
+	RelAlternate  = "alternate"
+)
+
+// ErrNoSelfLink is returned when a base href is needed but neither given
+// explicitly nor available from the document's self link.
+var ErrNoSelfLink = errors.New("document has no self link")
+
+// ResolveHref returns the link's href resolved against base, the location
+// of the document holding the link. Absolute hrefs are returned unchanged,
+// as is any href when base is empty. base may be a URL or a local path.
+func (link *Link) ResolveHref(base string) (string, error) {
+	return resolveHref(base, link.Href)
+}
+
+// ResolveHref returns the asset's href resolved against base, the location
+// of the document holding the asset. See Link.ResolveHref.
+func (asset *Asset) ResolveHref(base string) (string, error) {
+	return resolveHref(base, asset.Href)
+}
+
+// Self returns the item's "self" link, or nil.
+func (item *Item) Self() *Link { return linkByRel(item.Links, RelSelf) }
+
+// Root returns the item's "root" link, or nil.
+func (item *Item) Root() *Link { return linkByRel(item.Links, RelRoot) }
+
+// Parent returns the item's "parent" link, or nil.
+func (item *Item) Parent() *Link { return linkByRel(item.Links, RelParent) }
+
+// CollectionLink returns the item's "collection" link, or nil.
+func (item *Item) CollectionLink() *Link { return linkByRel(item.Links, RelCollection) }
+
+// Alternates returns the item's "alternate" links.
+func (item *Item) Alternates() []*Link { return linksByRel(item.Links, RelAlternate) }
+
+// LinksByRel returns the item's links with the given relation type.
+func (item *Item) LinksByRel(rel string) []*Link { return linksByRel(item.Links, rel) }
+
+// MakeHrefsAbsolute resolves every link and asset href against base, or
+// against the self link when base is empty.
+func (item *Item) MakeHrefsAbsolute(base string) error {
+	return makeHrefsAbsolute(item.Links, item.Assets, base)
+}
+
+// MakeHrefsRelative rewrites link and asset hrefs that share a scheme and
+// host with base (or the self link when base is empty) as paths relative to
+// it. The self link and hrefs on other hosts stay absolute.
+func (item *Item) MakeHrefsRelative(base string) error {
+	return makeHrefsRelative(item.Links, item.Assets, base)
+}
+
+// Self returns the collection's "self" link, or nil.
+func (col *Collection) Self() *Link { return linkByRel(col.Links, RelSelf) }
+
+// Root returns the collection's "root" link, or nil.
+func (col *Collection) Root() *Link { return linkByRel(col.Links, RelRoot) }
+
+// Parent returns the collection's "parent" link, or nil.
+func (col *Collection) Parent() *Link { return linkByRel(col.Links, RelParent) }
+
+// Alternates returns the collection's "alternate" links.
+func (col *Collection) Alternates() []*Link { return linksByRel(col.Links, RelAlternate) }
+
+// LinksByRel returns the collection's links with the given relation type.
+func (col *Collection) LinksByRel(rel string) []*Link { return linksByRel(col.Links, rel) }
+
+// MakeHrefsAbsolute resolves every link and asset href against base, or
+// against the self link when base is empty.
+func (col *Collection) MakeHrefsAbsolute(base string) error {
+	return makeHrefsAbsolute(col.Links, col.Assets, base)
+}
+
+// MakeHrefsRelative rewrites link and asset hrefs as paths relative to base.
+// See Item.MakeHrefsRelative.
+func (col *Collection) MakeHrefsRelative(base string) error {
+	return makeHrefsRelative(col.Links, col.Assets, base)
+}
+
+// Self returns the catalog's "self" link, or nil.
+func (cat *Catalog) Self() *Link { return linkByRel(cat.Links, RelSelf) }
+
+// Root returns the catalog's "root" link, or nil.
+func (cat *Catalog) Root() *Link { return linkByRel(cat.Links, RelRoot) }
+
+// Parent returns the catalog's "parent" link, or nil.
+func (cat *Catalog) Parent() *Link { return linkByRel(cat.Links, RelParent) }
+
+// Alternates returns the catalog's "alternate" links.
+func (cat *Catalog) Alternates() []*Link { return linksByRel(cat.Links, RelAlternate) }
+
+// LinksByRel returns the catalog's links with the given relation type.
+func (cat *Catalog) LinksByRel(rel string) []*Link { return linksByRel(cat.Links, rel) }
+
+// MakeHrefsAbsolute resolves every link href against base, or against the
+// self link when base is empty.
+func (cat *Catalog) MakeHrefsAbsolute(base string) error {
+	return makeHrefsAbsolute(cat.Links, nil, base)
+}
+
+// MakeHrefsRelative rewrites link hrefs as paths relative to base. See
+// Item.MakeHrefsRelative.
+func (cat *Catalog) MakeHrefsRelative(base string) error {
+	return makeHrefsRelative(cat.Links, nil, base)
+}
+
+func linkByRel(links []*Link, rel string) *Link {
+	for _, link := range links {
+		if link != nil && link.Rel == rel {
+			return link
+		}
+	}
+	return nil
+}
+
+func linksByRel(links []*Link, rel string) []*Link {
+	var out []*Link
+	for _, link := range links {
+		if link != nil && link.Rel == rel {
+			out = append(out, link)
+		}
+	}
+	return out
+}
+
+// baseHref returns base, falling back to the self link's href.
+func baseHref(links []*Link, base string) (string, error) {
+	if base != "" {
+		return base, nil
+	}
+	if self := linkByRel(links, RelSelf); self != nil && self.Href != "" {
+		return self.Href, nil
+	}
+	return "", ErrNoSelfLink
+}
+
+func makeHrefsAbsolute(links []*Link, assets map[string]*Asset, base string) error {
+	base, err := baseHref(links, base)
+	if err != nil {
+		return err
+	}
+	return rewriteHrefs(links, assets, func(link *Link, href string) (string, error) {
+		return resolveHref(base, href)
+	})
+}
+
+func makeHrefsRelative(links []*Link, assets map[string]*Asset, base string) error {
+	base, err := baseHref(links, base)
+	if err != nil {
+		return err
+	}
+	return rewriteHrefs(links, assets, func(link *Link, href string) (string, error) {
+		if link != nil && link.Rel == RelSelf {
+			return href, nil
+		}
+		abs, err := resolveHref(base, href)
+		if err != nil {
+			return "", err
+		}
+		return relativeHref(base, abs)
+	})
+}
+
+// rewriteHrefs replaces each link and asset href with fn's result. fn
+// receives a nil link for asset hrefs.
+func rewriteHrefs(links []*Link, assets map[string]*Asset, fn func(link *Link, href string) (string, error)) error {
+	for _, link := range links {
+		if link == nil || link.Href == "" {
+			continue
+		}
+		href, err := fn(link, link.Href)
+		if err != nil {
+			return fmt.Errorf("link %q: %w", link.Rel, err)
+		}
+		link.Href = href
+	}
+	for key, asset := range assets {
+		if asset == nil || asset.Href == "" {
+			continue
+		}
+		href, err := fn(nil, asset.Href)
+		if err != nil {
+			return fmt.Errorf("asset %q: %w", key, err)
+		}
+		asset.Href = href
+	}
+	return nil
+}
+
+func resolveHref(base, href string) (string, error) {
+	ref, err := url.Parse(href)
+	if err != nil {
+		return "", fmt.Errorf("invalid href %q: %w", href, err)
+	}
+	if base == "" || ref.IsAbs() {
+		return href, nil
+	}
+	b, err := url.Parse(base)
+	if err != nil {
+		return "", fmt.Errorf("invalid base href %q: %w", base, err)
+	}
+	return b.ResolveReference(ref).String(), nil
+}
+
+// relativeHref expresses target relative to the directory of base. Targets
+// on a different scheme or host are returned unchanged.
+func relativeHref(base, target string) (string, error) {
+	b, err := url.Parse(base)
+	if err != nil {
+		return "", fmt.Errorf("invalid base href %q: %w", base, err)
+	}
+	t, err := url.Parse(target)
+	if err != nil {
+		return "", fmt.Errorf("invalid href %q: %w", target, err)
+	}
+	if t.Scheme != b.Scheme || t.Host != b.Host || t.Opaque != "" || !path.IsAbs(t.Path) || !path.IsAbs(b.Path) {
+		return target, nil
+	}
+
+	dir := b.Path
+	if !strings.HasSuffix(dir, "/") {
+		dir = path.Dir(dir)
+	}
+	from := splitPath(dir)
+	to := splitPath(t.Path)
+	n := 0
+	for n < len(from) && n < len(to)-1 && from[n] == to[n] {
+		n++
+	}
+
+	rel := strings.Repeat("../", len(from)-n) + strings.Join(to[n:], "/")
+	if !strings.HasPrefix(rel, "../") {
+		rel = "./" + rel
+	}
+	if strings.HasSuffix(t.Path, "/") && !strings.HasSuffix(rel, "/") {
+		rel += "/"
+	}
+	if t.RawQuery != "" {
+		rel += "?" + t.RawQuery
+	}
+	if t.Fragment != "" {
+		rel += "#" + t.EscapedFragment()
+	}
+	return rel, nil
+}
+
+func splitPath(p string) []string {
+	p = strings.Trim(p, "/")
+	if p == "" {
+		return nil
+	}
+	return strings.Split(p, "/")
+}
@@ -1,11 +1,10 @@
 package stac
 
 // ItemsList represents a GeoJSON FeatureCollection of STAC Items.
-type ItemsList struct {
-	Type     string  `json:"type"`
-	Features []*Item `json:"features"`
-	Links    []*Link `json:"links,omitempty"`
-}
+//
+// Deprecated: Use ItemCollection, which also keeps search metadata and
+// foreign members.
+type ItemsList = ItemCollection
 
 // CollectionsList represents a list of STAC Collections.
 type CollectionsList struct {
@@ -0,0 +1,457 @@
+package stac
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// LatestVersion is the STAC version Migrate upgrades documents to.
+const LatestVersion = "1.1.0"
+
+const extensionBase = "https://stac-extensions.github.io/"
+
+// Schema URIs of the extension versions migrated documents are upgraded to.
+const (
+	eoV1URI     = extensionBase + "eo/v1.0.0/schema.json"
+	eoV2URI     = extensionBase + "eo/v2.0.0/schema.json"
+	projV1URI   = extensionBase + "projection/v1.0.0/schema.json"
+	projV2URI   = extensionBase + "projection/v2.0.0/schema.json"
+	rasterV2URI = extensionBase + "raster/v2.0.0/schema.json"
+	viewURI     = extensionBase + "view/v1.0.0/schema.json"
+	satURI      = extensionBase + "sat/v1.0.0/schema.json"
+	fileURI     = extensionBase + "file/v1.0.0/schema.json"
+	itemAssets  = "item-assets"
+)
+
+// legacyExtensions maps the short extension names used before 1.0.0 to
+// schema URIs. An empty URI means the extension was folded into the core
+// spec.
+var legacyExtensions = map[string]string{
+	"eo":                eoV1URI,
+	"proj":              projV1URI,
+	"projection":        projV1URI,
+	"sat":               satURI,
+	"view":              viewURI,
+	"file":              fileURI,
+	"checksum":          fileURI,
+	"sar":               extensionBase + "sar/v1.0.0/schema.json",
+	"scientific":        extensionBase + "scientific/v1.0.0/schema.json",
+	"label":             extensionBase + "label/v1.0.0/schema.json",
+	"pointcloud":        extensionBase + "pointcloud/v1.0.0/schema.json",
+	"timestamps":        extensionBase + "timestamps/v1.0.0/schema.json",
+	"version":           extensionBase + "version/v1.0.0/schema.json",
+	"datacube":          extensionBase + "datacube/v1.0.0/schema.json",
+	itemAssets:          extensionBase + "item-assets/v1.0.0/schema.json",
+	"asset":             "",
+	"collection-assets": "",
+	"commons":           "",
+}
+
+// legacySchemaURI matches extension schemas hosted with the core spec before
+// 1.0.0, e.g. https://schemas.stacspec.org/v1.0.0-beta.2/extensions/eo/json-schema/schema.json.
+var legacySchemaURI = regexp.MustCompile(`/extensions/([a-z-]+)/json-schema/schema\.json$`)
+
+// fieldRename moves a pre-1.0 field to its current name, registering the
+// extension that defines the new name.
+type fieldRename struct {
+	from, to  string
+	extension string
+}
+
+var legacyFields = []fieldRename{
+	{"eo:epsg", "proj:epsg", projV1URI},
+	{"eo:gsd", "gsd", ""},
+	{"eo:platform", "platform", ""},
+	{"eo:constellation", "constellation", ""},
+	{"eo:instrument", "instruments", ""},
+	{"eo:off_nadir", "view:off_nadir", viewURI},
+	{"eo:azimuth", "view:azimuth", viewURI},
+	{"eo:incidence_angle", "view:incidence_angle", viewURI},
+	{"eo:sun_azimuth", "view:sun_azimuth", viewURI},
+	{"eo:sun_elevation", "view:sun_elevation", viewURI},
+	{"sar:platform", "platform", ""},
+	{"sar:constellation", "constellation", ""},
+	{"sar:instrument", "instruments", ""},
+	{"sar:type", "sar:product_type", ""},
+	{"sar:polarization", "sar:polarizations", ""},
+	{"sar:absolute_orbit", "sat:absolute_orbit", satURI},
+	{"sar:pass_direction", "sat:orbit_state", satURI},
+	{"checksum:multihash", "file:checksum", fileURI},
+}
+
+// Band fields that move into the eo: and raster: namespaces in the STAC 1.1
+// bands array. Other band fields are common metadata and keep their names.
+var (
+	eoBandFields     = []string{"common_name", "center_wavelength", "full_width_half_max", "solar_illumination"}
+	rasterBandFields = []string{"sampling", "bits_per_sample", "spatial_resolution", "scale", "offset", "histogram"}
+)
+
+// MigrateJSON upgrades a JSON-encoded Item, Collection or Catalog. See
+// Migrate.
+func MigrateJSON(data []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var doc map[string]any
+	if err := dec.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to decode document: %w", err)
+	}
+	if err := Migrate(doc); err != nil {
+		return nil, err
+	}
+	return json.Marshal(doc)
+}
+
+// Migrate upgrades a decoded Item, Collection or Catalog in place from an
+// older STAC version (0.x, 1.0.0 pre-releases or 1.0.0) to LatestVersion:
+//
+//   - missing "type" members are filled in;
+//   - short extension names and schemas.stacspec.org extension URIs in
+//     stac_extensions become stac-extensions.github.io schema URIs;
+//   - pre-1.0 fields are renamed, e.g. eo:epsg to proj:epsg, eo:gsd to gsd
+//     and checksum:multihash to file:checksum;
+//   - Collection extents given as bare arrays are wrapped into
+//     {"spatial": {"bbox": [...]}, "temporal": {"interval": [...]}};
+//   - for 1.1, proj:epsg becomes proj:code, eo:bands and raster:bands are
+//     merged into the common bands array, item-assets moves into the core
+//     spec, and the "proprietary" and "various" licenses become "other".
+//
+// Documents already at LatestVersion or newer are left untouched. Migrate
+// returns an error only if stac_version cannot be parsed.
+func Migrate(doc map[string]any) error {
+	raw, _ := doc["stac_version"].(string)
+	from, err := parseVersion(raw)
+	if err != nil {
+		return err
+	}
+	latest, _ := parseVersion(LatestVersion)
+	if from.compare(latest) >= 0 {
+		return nil
+	}
+
+	m := migration{doc: doc}
+	m.fixType()
+	m.normalizeExtensions()
+
+	v100, _ := parseVersion("1.0.0")
+	if from.compare(v100) < 0 {
+		m.eachFields(m.renameLegacyFields)
+		m.fixExtent()
+	}
+
+	m.eachFields(m.migrateFields11)
+	if summaries, ok := doc["summaries"].(map[string]any); ok {
+		m.migrateFields11(summaries)
+	}
+	m.migrateExtensions11()
+	if license, _ := doc["license"].(string); license == "proprietary" || license == "various" {
+		doc["license"] = "other"
+	}
+
+	doc["stac_version"] = LatestVersion
+	return nil
+}
+
+// migration holds the document being migrated and the extensions it needs.
+type migration struct {
+	doc map[string]any
+
+	// bandsFrom records which extensions had bands merged into "bands".
+	bandsFrom map[string]bool
+}
+
+func (m *migration) fixType() {
+	if t, _ := m.doc["type"].(string); t != "" {
+		return
+	}
+	switch {
+	case m.doc["geometry"] != nil || m.doc["properties"] != nil && m.doc["assets"] != nil:
+		m.doc["type"] = "Feature"
+	case m.doc["extent"] != nil || m.doc["license"] != nil:
+		m.doc["type"] = "Collection"
+	default:
+		m.doc["type"] = "Catalog"
+	}
+}
+
+// eachFields calls fn on every object that carries extension fields: Item
+// properties, Item and Collection assets, and Collection item_assets.
+func (m *migration) eachFields(fn func(map[string]any)) {
+	if props, ok := m.doc["properties"].(map[string]any); ok {
+		fn(props)
+	}
+	for _, key := range []string{"assets", "item_assets"} {
+		assets, _ := m.doc[key].(map[string]any)
+		for _, a := range assets {
+			if asset, ok := a.(map[string]any); ok {
+				fn(asset)
+			}
+		}
+	}
+}
+
+func (m *migration) extensions() []string {
+	var out []string
+	switch exts := m.doc["stac_extensions"].(type) {
+	case []string:
+		out = slices.Clone(exts)
+	case []any:
+		for _, e := range exts {
+			if s, ok := e.(string); ok {
+				out = append(out, s)
+			}
+		}
+	}
+	return out
+}
+
+func (m *migration) setExtensions(exts []string) {
+	if len(exts) == 0 {
+		delete(m.doc, "stac_extensions")
+		return
+	}
+	out := make([]any, len(exts))
+	for i, e := range exts {
+		out[i] = e
+	}
+	m.doc["stac_extensions"] = out
+}
+
+func (m *migration) addExtension(uri string) {
+	exts := m.extensions()
+	if !slices.Contains(exts, uri) {
+		m.setExtensions(append(exts, uri))
+	}
+}
+
+func (m *migration) normalizeExtensions() {
+	var out []string
+	for _, e := range m.extensions() {
+		uri := e
+		name := e
+		if match := legacySchemaURI.FindStringSubmatch(e); match != nil {
+			// Extensions hosted with the core spec moved to their own
+			// repositories, starting over at v1.0.0.
+			name = match[1]
+			uri = extensionBase + name + "/v1.0.0/schema.json"
+		}
+		if mapped, ok := legacyExtensions[name]; ok {
+			uri = mapped
+		}
+		if uri != "" && !slices.Contains(out, uri) {
+			out = append(out, uri)
+		}
+	}
+	m.setExtensions(out)
+}
+
+func (m *migration) renameLegacyFields(fields map[string]any) {
+	for _, r := range legacyFields {
+		v, ok := fields[r.from]
+		if !ok {
+			continue
+		}
+		delete(fields, r.from)
+		if _, exists := fields[r.to]; exists {
+			continue
+		}
+		switch r.to {
+		case "instruments":
+			if s, ok := v.(string); ok {
+				v = []any{s}
+			}
+		case "sat:orbit_state":
+			if s, ok := v.(string); ok {
+				v = strings.ToLower(s)
+			}
+		}
+		fields[r.to] = v
+		if r.extension != "" {
+			m.addExtension(r.extension)
+		}
+	}
+}
+
+// fixExtent wraps Collection extents written as bare arrays.
+func (m *migration) fixExtent() {
+	extent, ok := m.doc["extent"].(map[string]any)
+	if !ok {
+		return
+	}
+	switch spatial := extent["spatial"].(type) {
+	case []any:
+		extent["spatial"] = map[string]any{"bbox": nestOnce(spatial)}
+	case map[string]any:
+		if bbox, ok := spatial["bbox"].([]any); ok {
+			spatial["bbox"] = nestOnce(bbox)
+		}
+	}
+	switch temporal := extent["temporal"].(type) {
+	case []any:
+		extent["temporal"] = map[string]any{"interval": nestOnce(temporal)}
+	case map[string]any:
+		if interval, ok := temporal["interval"].([]any); ok {
+			temporal["interval"] = nestOnce(interval)
+		}
+	}
+}
+
+// nestOnce wraps a flat list such as [w, s, e, n] into [[w, s, e, n]].
+func nestOnce(list []any) []any {
+	if len(list) > 0 {
+		if _, nested := list[0].([]any); nested {
+			return list
+		}
+	}
+	return []any{list}
+}
+
+// migrateFields11 applies the STAC 1.1 field changes to an object carrying
+// extension fields.
+func (m *migration) migrateFields11(fields map[string]any) {
+	if v, ok := fields["proj:epsg"]; ok {
+		delete(fields, "proj:epsg")
+		if _, exists := fields["proj:code"]; !exists {
+			fields["proj:code"] = epsgCode(v)
+		}
+	}
+
+	eoBands, _ := fields["eo:bands"].([]any)
+	rasterBands, _ := fields["raster:bands"].([]any)
+	if eoBands == nil && rasterBands == nil {
+		return
+	}
+	delete(fields, "eo:bands")
+	delete(fields, "raster:bands")
+	if _, exists := fields["bands"]; exists {
+		return
+	}
+
+	if m.bandsFrom == nil {
+		m.bandsFrom = make(map[string]bool)
+	}
+	m.bandsFrom["eo"] = m.bandsFrom["eo"] || eoBands != nil
+	m.bandsFrom["raster"] = m.bandsFrom["raster"] || rasterBands != nil
+
+	n := max(len(eoBands), len(rasterBands))
+	bands := make([]any, n)
+	for i := range n {
+		band := make(map[string]any)
+		if i < len(eoBands) {
+			mergeBand(band, eoBands[i], "eo:", eoBandFields)
+		}
+		if i < len(rasterBands) {
+			mergeBand(band, rasterBands[i], "raster:", rasterBandFields)
+		}
+		bands[i] = band
+	}
+	fields["bands"] = bands
+}
+
+// mergeBand copies src into dst, prefixing the fields listed in prefixed.
+func mergeBand(dst map[string]any, src any, prefix string, prefixed []string) {
+	obj, ok := src.(map[string]any)
+	if !ok {
+		return
+	}
+	for k, v := range obj {
+		if slices.Contains(prefixed, k) {
+			k = prefix + k
+		}
+		if _, exists := dst[k]; !exists {
+			dst[k] = v
+		}
+	}
+}
+
+// epsgCode converts a proj:epsg value, or a list of them in summaries, to
+// proj:code form.
+func epsgCode(v any) any {
+	switch v := v.(type) {
+	case nil:
+		return nil
+	case []any:
+		out := make([]any, len(v))
+		for i, e := range v {
+			out[i] = epsgCode(e)
+		}
+		return out
+	case float64:
+		return "EPSG:" + strconv.FormatFloat(v, 'f', -1, 64)
+	case int:
+		return "EPSG:" + strconv.Itoa(v)
+	case json.Number:
+		return "EPSG:" + v.String()
+	}
+	return v
+}
+
+// migrateExtensions11 updates stac_extensions for the 1.1 field changes.
+func (m *migration) migrateExtensions11() {
+	var out []string
+	for _, e := range m.extensions() {
+		switch {
+		case strings.HasPrefix(e, extensionBase+"projection/v1."):
+			e = projV2URI
+		case strings.HasPrefix(e, extensionBase+"eo/v1.") && m.bandsFrom["eo"]:
+			e = eoV2URI
+		case strings.HasPrefix(e, extensionBase+"raster/v1.") && m.bandsFrom["raster"]:
+			e = rasterV2URI
+		case strings.HasPrefix(e, extensionBase+itemAssets+"/"):
+			continue
+		}
+		if !slices.Contains(out, e) {
+			out = append(out, e)
+		}
+	}
+	m.setExtensions(out)
+}
+
+// version is a parsed STAC version such as 1.0.0-rc.2.
+type version struct {
+	major, minor, patch int
+	pre                 string
+}
+
+func parseVersion(s string) (version, error) {
+	if s == "" {
+		// Documents before 0.8 did not carry stac_version.
+		return version{0, 8, 0, ""}, nil
+	}
+	core, pre, _ := strings.Cut(s, "-")
+	parts := strings.Split(core, ".")
+	if len(parts) != 3 {
+		return version{}, fmt.Errorf("invalid stac_version %q", s)
+	}
+	var nums [3]int
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			return version{}, fmt.Errorf("invalid stac_version %q", s)
+		}
+		nums[i] = n
+	}
+	return version{nums[0], nums[1], nums[2], pre}, nil
+}
+
+// compare orders versions; a pre-release sorts before its release.
+func (v version) compare(o version) int {
+	for _, d := range [3]int{v.major - o.major, v.minor - o.minor, v.patch - o.patch} {
+		if d != 0 {
+			return d
+		}
+	}
+	switch {
+	case v.pre == o.pre:
+		return 0
+	case v.pre == "":
+		return 1
+	case o.pre == "":
+		return -1
+	}
+	return strings.Compare(v.pre, o.pre)
+}
@@ -1,9 +1,13 @@
 package stac
 
 import (
+	"bytes"
 	"encoding/json"
+	"math"
 	"testing"
+	"time"
 
+	"github.com/paulmach/orb"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -133,6 +137,33 @@ func TestCollectionForeignMembers(t *testing.T) {
 	})
 }
 
+func TestCatalogForeignMembers(t *testing.T) {
+	jsonData := `{
+		"type": "Catalog",
+		"stac_version": "1.0.0",
+		"id": "root",
+		"description": "Root catalog",
+		"links": [{"rel": "child", "href": "./sentinel-2/collection.json"}],
+		"conformsTo": ["https://api.stacspec.org/v1.0.0/core"]
+	}`
+
+	var cat Catalog
+	require.NoError(t, json.Unmarshal([]byte(jsonData), &cat))
+
+	assert.Equal(t, "root", cat.Id)
+	require.Len(t, cat.Links, 1)
+	assert.Equal(t, "child", cat.Links[0].Rel)
+	assert.Contains(t, cat.AdditionalFields, "conformsTo")
+
+	data, err := json.Marshal(cat)
+	require.NoError(t, err)
+
+	var decoded map[string]any
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, "Catalog", decoded["type"])
+	assert.Equal(t, []any{"https://api.stacspec.org/v1.0.0/core"}, decoded["conformsTo"])
+}
+
 func TestLinkForeignMembers(t *testing.T) {
 	t.Run("unmarshal preserves foreign members", func(t *testing.T) {
 		jsonData := `{
@@ -239,3 +270,609 @@ func TestItemWithNestedTypes(t *testing.T) {
 		assert.Equal(t, "asset_value", item.Assets["data"].AdditionalFields["custom"])
 	})
 }
+
+func TestItemCommonMetadata(t *testing.T) {
+	t.Run("instant", func(t *testing.T) {
+		var item Item
+		require.NoError(t, json.Unmarshal([]byte(`{
+			"type": "Feature", "stac_version": "1.0.0", "id": "a", "geometry": null, "links": [], "assets": {},
+			"properties": {
+				"datetime": "2023-01-01T10:00:00.5Z",
+				"created": "2023-01-02T00:00:00Z",
+				"platform": "sentinel-2a",
+				"constellation": "sentinel-2",
+				"instruments": ["msi"],
+				"gsd": 10
+			}
+		}`), &item))
+
+		dt, err := item.Datetime()
+		require.NoError(t, err)
+		assert.Equal(t, time.Date(2023, 1, 1, 10, 0, 0, 5e8, time.UTC), dt)
+
+		tr, err := item.TimeRange()
+		require.NoError(t, err)
+		assert.Equal(t, TimeInterval{Start: dt, End: dt}, tr)
+
+		created, err := item.Created()
+		require.NoError(t, err)
+		assert.Equal(t, 2, created.Day())
+
+		updated, err := item.Updated()
+		require.NoError(t, err)
+		assert.True(t, updated.IsZero())
+
+		assert.Equal(t, "sentinel-2a", item.Platform())
+		assert.Equal(t, "sentinel-2", item.Constellation())
+		assert.Equal(t, []string{"msi"}, item.Instruments())
+		gsd, ok := item.GSD()
+		assert.True(t, ok)
+		assert.Equal(t, 10.0, gsd)
+	})
+
+	t.Run("range with null datetime", func(t *testing.T) {
+		item := Item{Properties: map[string]any{
+			"datetime":       nil,
+			"start_datetime": "2020-01-01T00:00:00Z",
+			"end_datetime":   "2020-12-31T23:59:59Z",
+		}}
+
+		dt, err := item.Datetime()
+		require.NoError(t, err)
+		assert.True(t, dt.IsZero())
+
+		tr, err := item.TimeRange()
+		require.NoError(t, err)
+		assert.Equal(t, 2020, tr.Start.Year())
+		assert.Equal(t, time.December, tr.End.Month())
+		assert.True(t, tr.Contains(time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)))
+		assert.False(t, tr.Contains(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)))
+	})
+
+	t.Run("parse errors", func(t *testing.T) {
+		item := Item{Properties: map[string]any{"datetime": "yesterday", "updated": 5.0}}
+
+		_, err := item.Datetime()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "datetime")
+
+		_, err = item.TimeRange()
+		require.Error(t, err)
+
+		_, err = item.Updated()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "expected an RFC 3339 string")
+	})
+
+	t.Run("gsd", func(t *testing.T) {
+		for _, v := range []any{int64(30), json.Number("30"), float32(30)} {
+			gsd, ok := (&Item{Properties: map[string]any{"gsd": v}}).GSD()
+			assert.True(t, ok, "%T", v)
+			assert.Equal(t, 30.0, gsd, "%T", v)
+		}
+
+		_, ok := (&Item{Properties: map[string]any{"gsd": "30m"}}).GSD()
+		assert.False(t, ok)
+		_, ok = (&Item{}).GSD()
+		assert.False(t, ok)
+	})
+
+	t.Run("setters", func(t *testing.T) {
+		var item Item
+		start := time.Date(2024, 3, 1, 12, 0, 0, 0, time.FixedZone("CET", 3600))
+		item.SetTimeRange(TimeInterval{Start: start, End: start.Add(time.Hour)})
+		item.SetUpdated(start)
+		item.SetPlatform("landsat-9")
+		item.SetInstruments([]string{"oli", "tirs"})
+		item.SetConstellation("landsat")
+		item.SetGSD(30)
+
+		assert.Contains(t, item.Properties, "datetime")
+		assert.Nil(t, item.Properties["datetime"])
+		assert.Equal(t, "2024-03-01T11:00:00Z", item.Properties["start_datetime"])
+
+		data, err := json.Marshal(item)
+		require.NoError(t, err)
+		var decoded Item
+		require.NoError(t, json.Unmarshal(data, &decoded))
+
+		tr, err := decoded.TimeRange()
+		require.NoError(t, err)
+		assert.True(t, tr.Start.Equal(start))
+		assert.Equal(t, time.Hour, tr.End.Sub(tr.Start))
+		assert.Equal(t, []string{"oli", "tirs"}, decoded.Instruments())
+		gsd, ok := decoded.GSD()
+		assert.True(t, ok)
+		assert.Equal(t, 30.0, gsd)
+
+		decoded.SetDatetime(start)
+		dt, err := decoded.Datetime()
+		require.NoError(t, err)
+		assert.True(t, dt.Equal(start))
+
+		decoded.SetUpdated(time.Time{})
+		assert.NotContains(t, decoded.Properties, "updated")
+	})
+}
+
+func TestItemGeometry(t *testing.T) {
+	t.Run("decode and bbox", func(t *testing.T) {
+		var item Item
+		require.NoError(t, json.Unmarshal([]byte(`{
+			"type": "Feature",
+			"id": "square",
+			"geometry": {"type": "Polygon", "coordinates": [[[10, 40], [11, 40], [11, 41], [10, 41], [10, 40]]]},
+			"bbox": [10, 40, 11, 41],
+			"properties": {}
+		}`), &item))
+
+		g, err := item.OrbGeometry()
+		require.NoError(t, err)
+		require.IsType(t, orb.Polygon{}, g)
+
+		bbox, err := item.ComputeBbox()
+		require.NoError(t, err)
+		assert.Equal(t, []float64{10, 40, 11, 41}, bbox)
+		assert.NoError(t, item.CheckBbox(0))
+
+		area, err := item.Area()
+		require.NoError(t, err)
+		assert.InDelta(t, 9.4e9, area, 0.2e9)
+
+		c, err := item.Centroid()
+		require.NoError(t, err)
+		assert.InDelta(t, 10.5, c.Lon(), 1e-9)
+		assert.InDelta(t, 40.5, c.Lat(), 1e-9)
+
+		item.Bbox = []float64{10, 40, 10.5, 41}
+		assert.Error(t, item.CheckBbox(0.1))
+		require.NoError(t, item.UpdateBbox())
+		assert.Equal(t, []float64{10, 40, 11, 41}, item.Bbox)
+	})
+
+	t.Run("antimeridian", func(t *testing.T) {
+		var item Item
+		item.SetOrbGeometry(orb.MultiPolygon{
+			{{{179, -1}, {180, -1}, {180, 1}, {179, 1}, {179, -1}}},
+			{{{-180, -1}, {-179, -1}, {-179, 1}, {-180, 1}, {-180, -1}}},
+		})
+
+		assert.Equal(t, []float64{179, -1, -179, 1}, item.Bbox)
+		assert.True(t, BboxCrossesAntimeridian(item.Bbox))
+		assert.Len(t, BboxBounds(item.Bbox), 2)
+		assert.True(t, BboxIntersects(item.Bbox, []float64{-179.5, 0, -170, 5}))
+		assert.False(t, BboxIntersects(item.Bbox, []float64{0, 0, 10, 10}))
+		assert.NoError(t, item.CheckBbox(0))
+
+		c, err := item.Centroid()
+		require.NoError(t, err)
+		assert.InDelta(t, 180, math.Abs(c.Lon()), 1e-9)
+		assert.InDelta(t, 0, c.Lat(), 1e-9)
+
+		data, err := json.Marshal(item)
+		require.NoError(t, err)
+		var decoded Item
+		require.NoError(t, json.Unmarshal(data, &decoded))
+		g, err := decoded.OrbGeometry()
+		require.NoError(t, err)
+		assert.IsType(t, orb.MultiPolygon{}, g)
+	})
+
+	t.Run("3D bbox and no geometry", func(t *testing.T) {
+		item := Item{Bbox: []float64{0, 0, -10, 1, 1, 10}}
+		item.SetOrbGeometry(orb.Point{0.5, 0.5})
+		assert.Equal(t, []float64{0.5, 0.5, 0.5, 0.5}, item.Bbox)
+
+		item.Bbox = []float64{0, 0, -10, 1, 1, 10}
+		assert.NoError(t, item.CheckBbox(0))
+
+		item.SetOrbGeometry(nil)
+		assert.Nil(t, item.Geometry)
+		assert.Nil(t, item.Bbox)
+		g, err := item.OrbGeometry()
+		require.NoError(t, err)
+		assert.Nil(t, g)
+		_, err = item.Centroid()
+		assert.Error(t, err)
+	})
+}
+
+func TestMigrate(t *testing.T) {
+	decode := func(t *testing.T, data string) map[string]any {
+		t.Helper()
+		var doc map[string]any
+		require.NoError(t, json.Unmarshal([]byte(data), &doc))
+		return doc
+	}
+
+	t.Run("0.9 item", func(t *testing.T) {
+		doc := decode(t, `{
+			"stac_version": "0.9.0",
+			"stac_extensions": ["eo", "https://schemas.stacspec.org/v1.0.0-beta.1/extensions/raster/json-schema/schema.json", "checksum"],
+			"id": "old",
+			"geometry": null,
+			"properties": {
+				"datetime": "2020-01-01T00:00:00Z",
+				"eo:epsg": 32633,
+				"eo:gsd": 10,
+				"eo:instrument": "msi",
+				"eo:sun_elevation": 40.5,
+				"eo:bands": [{"name": "B04", "common_name": "red", "center_wavelength": 0.665}]
+			},
+			"links": [],
+			"assets": {
+				"B04": {
+					"href": "B04.tif",
+					"checksum:multihash": "1220abcd",
+					"eo:bands": [{"name": "B04", "common_name": "red"}],
+					"raster:bands": [{"data_type": "uint16", "nodata": 0, "spatial_resolution": 10}]
+				}
+			}
+		}`)
+		require.NoError(t, Migrate(doc))
+
+		assert.Equal(t, LatestVersion, doc["stac_version"])
+		assert.Equal(t, "Feature", doc["type"])
+
+		props := doc["properties"].(map[string]any)
+		assert.Equal(t, "EPSG:32633", props["proj:code"])
+		assert.NotContains(t, props, "eo:epsg")
+		assert.NotContains(t, props, "proj:epsg")
+		assert.Equal(t, 10.0, props["gsd"])
+		assert.Equal(t, []any{"msi"}, props["instruments"])
+		assert.Equal(t, 40.5, props["view:sun_elevation"])
+		assert.Equal(t, []any{map[string]any{"name": "B04", "eo:common_name": "red", "eo:center_wavelength": 0.665}}, props["bands"])
+
+		asset := doc["assets"].(map[string]any)["B04"].(map[string]any)
+		assert.Equal(t, "1220abcd", asset["file:checksum"])
+		assert.Equal(t, []any{map[string]any{
+			"name": "B04", "eo:common_name": "red",
+			"data_type": "uint16", "nodata": 0.0, "raster:spatial_resolution": 10.0,
+		}}, asset["bands"])
+		assert.NotContains(t, asset, "eo:bands")
+		assert.NotContains(t, asset, "raster:bands")
+
+		assert.ElementsMatch(t, []any{
+			"https://stac-extensions.github.io/eo/v2.0.0/schema.json",
+			"https://stac-extensions.github.io/raster/v2.0.0/schema.json",
+			"https://stac-extensions.github.io/file/v1.0.0/schema.json",
+			"https://stac-extensions.github.io/projection/v2.0.0/schema.json",
+			"https://stac-extensions.github.io/view/v1.0.0/schema.json",
+		}, doc["stac_extensions"])
+
+		var item Item
+		data, err := json.Marshal(doc)
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(data, &item))
+		gsd, ok := item.GSD()
+		assert.True(t, ok)
+		assert.Equal(t, 10.0, gsd)
+	})
+
+	t.Run("0.8 collection", func(t *testing.T) {
+		doc := decode(t, `{
+			"stac_version": "0.8.1",
+			"stac_extensions": ["item-assets", "commons"],
+			"id": "old-collection",
+			"description": "Old",
+			"license": "proprietary",
+			"extent": {
+				"spatial": [-180, -90, 180, 90],
+				"temporal": ["2015-06-23T00:00:00Z", null]
+			},
+			"summaries": {"proj:epsg": [32633, 32634]},
+			"links": []
+		}`)
+		require.NoError(t, Migrate(doc))
+
+		assert.Equal(t, "Collection", doc["type"])
+		assert.Equal(t, "other", doc["license"])
+		assert.NotContains(t, doc, "stac_extensions")
+		assert.Equal(t, []any{"EPSG:32633", "EPSG:32634"}, doc["summaries"].(map[string]any)["proj:code"])
+
+		data, err := json.Marshal(doc)
+		require.NoError(t, err)
+		var col Collection
+		require.NoError(t, json.Unmarshal(data, &col))
+		assert.Equal(t, [][]float64{{-180, -90, 180, 90}}, col.Extent.Spatial.Bbox)
+		assert.Equal(t, [][]any{{"2015-06-23T00:00:00Z", nil}}, col.Extent.Temporal.Interval)
+	})
+
+	t.Run("1.0.0 item", func(t *testing.T) {
+		data, err := MigrateJSON([]byte(`{
+			"type": "Feature", "stac_version": "1.0.0", "id": "a", "geometry": null,
+			"stac_extensions": ["https://stac-extensions.github.io/projection/v1.1.0/schema.json"],
+			"properties": {"datetime": null, "proj:epsg": 4326, "eo:gsd": 5},
+			"links": [], "assets": {}
+		}`))
+		require.NoError(t, err)
+
+		var item Item
+		require.NoError(t, json.Unmarshal(data, &item))
+		assert.Equal(t, "1.1.0", item.Version)
+		assert.Equal(t, "EPSG:4326", item.Properties["proj:code"])
+		assert.Equal(t, 5.0, item.Properties["eo:gsd"], "pre-1.0 renames are not applied to 1.0 documents")
+		assert.Equal(t, []string{"https://stac-extensions.github.io/projection/v2.0.0/schema.json"}, item.Extensions)
+	})
+
+	t.Run("versions", func(t *testing.T) {
+		current := decode(t, `{"type": "Feature", "stac_version": "1.1.0", "properties": {"proj:epsg": 4326}}`)
+		require.NoError(t, Migrate(current))
+		assert.Equal(t, 4326.0, current["properties"].(map[string]any)["proj:epsg"])
+
+		rc := decode(t, `{"type": "Catalog", "stac_version": "1.0.0-rc.2", "stac_extensions": ["https://schemas.stacspec.org/v1.0.0-rc.2/extensions/eo/json-schema/schema.json"]}`)
+		require.NoError(t, Migrate(rc))
+		assert.Equal(t, []any{"https://stac-extensions.github.io/eo/v1.0.0/schema.json"}, rc["stac_extensions"])
+
+		assert.Error(t, Migrate(map[string]any{"stac_version": "one"}))
+	})
+}
+
+func TestLinkHelpers(t *testing.T) {
+	newItem := func() *Item {
+		return &Item{
+			Id: "a",
+			Links: []*Link{
+				{Rel: "self", Href: "https://example.com/catalog/s2/items/a.json"},
+				{Rel: "root", Href: "../../catalog.json"},
+				{Rel: "parent", Href: "../collection.json"},
+				{Rel: "collection", Href: "../collection.json"},
+				{Rel: "alternate", Href: "a.html", Type: "text/html"},
+				{Rel: "alternate", Href: "https://other.org/a.json"},
+			},
+			Assets: map[string]*Asset{
+				"data":      {Href: "./a.tif"},
+				"thumbnail": {Href: "https://example.com/catalog/s2/thumbs/a.png"},
+			},
+		}
+	}
+
+	t.Run("lookup", func(t *testing.T) {
+		item := newItem()
+		assert.Equal(t, "https://example.com/catalog/s2/items/a.json", item.Self().Href)
+		assert.Equal(t, "../../catalog.json", item.Root().Href)
+		assert.Equal(t, "../collection.json", item.Parent().Href)
+		assert.Equal(t, "../collection.json", item.CollectionLink().Href)
+		assert.Len(t, item.Alternates(), 2)
+		assert.Empty(t, item.LinksByRel("next"))
+
+		cat := &Catalog{Links: []*Link{{Rel: "child", Href: "a"}, {Rel: "child", Href: "b"}}}
+		assert.Nil(t, cat.Self())
+		assert.Len(t, cat.LinksByRel(RelChild), 2)
+	})
+
+	t.Run("resolve", func(t *testing.T) {
+		item := newItem()
+		href, err := item.Root().ResolveHref(item.Self().Href)
+		require.NoError(t, err)
+		assert.Equal(t, "https://example.com/catalog/catalog.json", href)
+
+		href, err = item.Assets["data"].ResolveHref("/data/s2/items/a.json")
+		require.NoError(t, err)
+		assert.Equal(t, "/data/s2/items/a.tif", href)
+
+		href, err = item.Root().ResolveHref("")
+		require.NoError(t, err)
+		assert.Equal(t, "../../catalog.json", href)
+	})
+
+	t.Run("absolute and relative", func(t *testing.T) {
+		item := newItem()
+		require.NoError(t, item.MakeHrefsAbsolute(""))
+		assert.Equal(t, "https://example.com/catalog/catalog.json", item.Root().Href)
+		assert.Equal(t, "https://example.com/catalog/s2/collection.json", item.Parent().Href)
+		assert.Equal(t, "https://example.com/catalog/s2/items/a.html", item.Alternates()[0].Href)
+		assert.Equal(t, "https://example.com/catalog/s2/items/a.tif", item.Assets["data"].Href)
+
+		require.NoError(t, item.MakeHrefsRelative(""))
+		assert.Equal(t, "https://example.com/catalog/s2/items/a.json", item.Self().Href)
+		assert.Equal(t, "../../catalog.json", item.Root().Href)
+		assert.Equal(t, "../collection.json", item.Parent().Href)
+		assert.Equal(t, "./a.html", item.Alternates()[0].Href)
+		assert.Equal(t, "https://other.org/a.json", item.Alternates()[1].Href)
+		assert.Equal(t, "./a.tif", item.Assets["data"].Href)
+		assert.Equal(t, "../thumbs/a.png", item.Assets["thumbnail"].Href)
+	})
+
+	t.Run("no base", func(t *testing.T) {
+		col := &Collection{Links: []*Link{{Rel: "root", Href: "../catalog.json"}}}
+		assert.ErrorIs(t, col.MakeHrefsAbsolute(""), ErrNoSelfLink)
+
+		require.NoError(t, col.MakeHrefsAbsolute("s3://bucket/stac/s2/collection.json"))
+		assert.Equal(t, "s3://bucket/stac/catalog.json", col.Root().Href)
+	})
+}
+
+func TestItemCollection(t *testing.T) {
+	t.Run("round-trip preserves search metadata", func(t *testing.T) {
+		original := `{
+			"type": "FeatureCollection",
+			"features": [{"type": "Feature", "stac_version": "1.0.0", "id": "a", "geometry": null, "properties": {}, "links": [], "assets": {}}],
+			"links": [{"rel": "next", "href": "https://example.com/search?page=2"}],
+			"numberMatched": 42,
+			"numberReturned": 1,
+			"context": {"returned": 1, "limit": 1, "matched": 42},
+			"search:metadata": {"next": "token"}
+		}`
+
+		var ic ItemCollection
+		require.NoError(t, json.Unmarshal([]byte(original), &ic))
+		require.Len(t, ic.Features, 1)
+		matched, ok := ic.Matched()
+		assert.True(t, ok)
+		assert.Equal(t, 42, matched)
+		assert.Equal(t, 1, *ic.NumberReturned)
+		assert.Equal(t, 1, *ic.Context.Limit)
+		assert.Contains(t, ic.AdditionalFields, "search:metadata")
+
+		output, err := json.Marshal(ic)
+		require.NoError(t, err)
+		assert.JSONEq(t, original, string(output))
+	})
+
+	t.Run("collect, dedupe and sort", func(t *testing.T) {
+		newItem := func(collection, id, datetime string) *Item {
+			props := map[string]any{"datetime": nil}
+			if datetime != "" {
+				props["datetime"] = datetime
+			}
+			return &Item{Id: id, Collection: collection, Properties: props}
+		}
+		items := []*Item{
+			newItem("s2", "b", "2024-03-01T00:00:00Z"),
+			newItem("s2", "a", ""),
+			newItem("s1", "a", "2024-01-01T00:00:00Z"),
+			newItem("s2", "b", "2024-03-01T00:00:00Z"),
+		}
+		seq := func(yield func(*Item, error) bool) {
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+		}
+
+		ic, err := CollectItems(seq)
+		require.NoError(t, err)
+		assert.Equal(t, "FeatureCollection", ic.Type)
+		assert.Equal(t, 1, ic.Dedupe())
+		require.Len(t, ic.Features, 3)
+
+		ids := func() []string {
+			var out []string
+			for _, item := range ic.Features {
+				out = append(out, item.Collection+"/"+item.Id)
+			}
+			return out
+		}
+		ic.SortByDatetime()
+		assert.Equal(t, []string{"s1/a", "s2/b", "s2/a"}, ids())
+		ic.SortByID()
+		assert.Equal(t, []string{"s1/a", "s2/a", "s2/b"}, ids())
+	})
+
+	t.Run("write and read GeoJSON", func(t *testing.T) {
+		matched := 2
+		ic := NewItemCollection([]*Item{{Type: "Feature", Id: "a", Properties: map[string]any{}}})
+		ic.NumberMatched = &matched
+
+		var buf bytes.Buffer
+		require.NoError(t, ic.WriteGeoJSON(&buf))
+
+		read, err := ReadItemCollection(&buf)
+		require.NoError(t, err)
+		assert.Equal(t, "FeatureCollection", read.Type)
+		assert.Equal(t, &matched, read.NumberMatched)
+		require.Len(t, read.Features, 1)
+		assert.Equal(t, "a", read.Features[0].Id)
+	})
+}
+
+func TestCollectionExtentAndSummaries(t *testing.T) {
+	var col Collection
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"type": "Collection",
+		"stac_version": "1.1.0",
+		"id": "s2",
+		"description": "S2",
+		"license": "other",
+		"extent": {
+			"spatial": {"bbox": [[-10, -10, 10, 10], [-10, -10, 0, 0], [0, 0, 10, 10]]},
+			"temporal": {"interval": [["2015-06-27T10:25:31Z", null], [null, "2016-01-01T00:00:00Z"]]}
+		},
+		"summaries": {
+			"platform": ["sentinel-2a", "sentinel-2b"],
+			"gsd": {"minimum": 10, "maximum": 60},
+			"eo:cloud_cover": {"type": "number", "minimum": 0}
+		},
+		"links": []
+	}`), &col))
+
+	t.Run("extent", func(t *testing.T) {
+		assert.Equal(t, []float64{-10, -10, 10, 10}, col.Extent.Spatial.Overall())
+		assert.Len(t, col.Extent.Spatial.SubBboxes(), 2)
+
+		ivs, err := col.Extent.Temporal.Intervals()
+		require.NoError(t, err)
+		require.Len(t, ivs, 2)
+		assert.Equal(t, time.Date(2015, 6, 27, 10, 25, 31, 0, time.UTC), ivs[0].Start)
+		assert.True(t, ivs[0].End.IsZero())
+		assert.True(t, ivs[1].Start.IsZero())
+
+		overall, err := col.Extent.Temporal.Overall()
+		require.NoError(t, err)
+		assert.Equal(t, ivs[0], overall)
+
+		var te TemporalExtent
+		te.SetIntervals(ivs)
+		assert.Equal(t, col.Extent.Temporal.Interval, te.Interval)
+
+		bad := &TemporalExtent{Interval: [][]any{{"yesterday", nil}}}
+		_, err = bad.Intervals()
+		assert.Error(t, err)
+	})
+
+	t.Run("summaries", func(t *testing.T) {
+		s, ok := col.Summary("platform")
+		require.True(t, ok)
+		assert.Equal(t, SummaryValues, s.Kind)
+		assert.Equal(t, []any{"sentinel-2a", "sentinel-2b"}, s.Values)
+
+		s, ok = col.Summary("gsd")
+		require.True(t, ok)
+		assert.Equal(t, SummaryRange, s.Kind)
+		lo, hi, ok := s.Range.Floats()
+		assert.True(t, ok)
+		assert.Equal(t, 10.0, lo)
+		assert.Equal(t, 60.0, hi)
+
+		s, ok = col.Summary("eo:cloud_cover")
+		require.True(t, ok)
+		assert.Equal(t, SummarySchema, s.Kind)
+		assert.Equal(t, "number", s.Schema["type"])
+
+		_, ok = col.Summary("missing")
+		assert.False(t, ok)
+
+		col.SetSummary("view:off_nadir", Summary{Kind: SummaryRange, Range: Range{Minimum: 0.0, Maximum: 5.0}})
+		assert.Equal(t, map[string]any{"minimum": 0.0, "maximum": 5.0}, col.Summaries["view:off_nadir"])
+	})
+}
+
+func TestComputeSummaries(t *testing.T) {
+	newItem := func(id string, bbox []float64, props map[string]any) *Item {
+		return &Item{Id: id, Bbox: bbox, Properties: props}
+	}
+	items := []*Item{
+		newItem("a", []float64{170, -10, 175, -5}, map[string]any{
+			"datetime": "2024-01-01T00:00:00Z", "platform": "sentinel-2a", "gsd": 10.0,
+			"instruments": []any{"msi"}, "eo:cloud_cover": 5.0, "id_like": "a",
+		}),
+		newItem("b", []float64{-175, 0, -170, 5}, map[string]any{
+			"datetime": "2024-02-01T00:00:00Z", "platform": "sentinel-2b", "gsd": 60,
+			"instruments": []any{"msi"}, "eo:cloud_cover": 50.0, "grid": map[string]any{"code": "x"},
+		}),
+		newItem("c", nil, map[string]any{
+			"datetime": nil, "start_datetime": "2023-12-01T00:00:00Z",
+			"platform": "sentinel-2a", "gsd": "mixed",
+		}),
+	}
+	items[2].SetOrbGeometry(orb.Point{172, 1})
+
+	extent, summaries, err := ComputeSummaries(items)
+	require.NoError(t, err)
+
+	assert.Equal(t, []float64{170, -10, -170, 5}, extent.Spatial.Overall())
+	overall, err := extent.Temporal.Overall()
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC), overall.Start)
+	assert.True(t, overall.End.IsZero(), "item c's range is open-ended")
+
+	assert.Equal(t, []any{"sentinel-2a", "sentinel-2b"}, summaries["platform"])
+	assert.Equal(t, []any{"msi"}, summaries["instruments"])
+	assert.Equal(t, map[string]any{"minimum": 5.0, "maximum": 50.0}, summaries["eo:cloud_cover"])
+	assert.Equal(t, []any{"a"}, summaries["id_like"])
+	assert.NotContains(t, summaries, "gsd", "mixed types are skipped")
+	assert.NotContains(t, summaries, "grid")
+	assert.NotContains(t, summaries, "datetime")
+}
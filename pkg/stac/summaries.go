@@ -0,0 +1,251 @@
+package stac
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/paulmach/orb"
+)
+
+// SummaryKind identifies the form of a Collection summary.
+type SummaryKind int
+
+const (
+	// SummaryValues is an array of the distinct values found in the items.
+	SummaryValues SummaryKind = iota + 1
+	// SummaryRange is a {"minimum", "maximum"} object.
+	SummaryRange
+	// SummarySchema is a JSON Schema the values validate against.
+	SummarySchema
+)
+
+// Summary is a typed view of one entry of Collection.Summaries. Only the
+// field matching Kind is set.
+type Summary struct {
+	Kind   SummaryKind
+	Values []any
+	Range  Range
+	Schema map[string]any
+}
+
+// Range is the minimum and maximum of a range summary. The bounds are
+// numbers, or strings for ranges of timestamps.
+type Range struct {
+	Minimum any
+	Maximum any
+}
+
+// Floats returns the bounds as numbers, or ok=false when either bound is
+// not numeric.
+func (r Range) Floats() (minimum, maximum float64, ok bool) {
+	minimum, okMin := toFloat(r.Minimum)
+	maximum, okMax := toFloat(r.Maximum)
+	return minimum, maximum, okMin && okMax
+}
+
+// Value returns the JSON form of the summary, as stored in
+// Collection.Summaries.
+func (s Summary) Value() any {
+	switch s.Kind {
+	case SummaryValues:
+		return s.Values
+	case SummaryRange:
+		return map[string]any{"minimum": s.Range.Minimum, "maximum": s.Range.Maximum}
+	case SummarySchema:
+		return s.Schema
+	}
+	return nil
+}
+
+// Summary returns the summary of the named field. An object with both
+// "minimum" and "maximum" is a range and any other object a JSON Schema.
+func (col *Collection) Summary(name string) (Summary, bool) {
+	switch v := col.Summaries[name].(type) {
+	case []any:
+		return Summary{Kind: SummaryValues, Values: v}, true
+	case map[string]any:
+		minimum, hasMin := v["minimum"]
+		maximum, hasMax := v["maximum"]
+		if hasMin && hasMax {
+			return Summary{Kind: SummaryRange, Range: Range{Minimum: minimum, Maximum: maximum}}, true
+		}
+		return Summary{Kind: SummarySchema, Schema: v}, true
+	}
+	return Summary{}, false
+}
+
+// SetSummary stores s as the summary of the named field.
+func (col *Collection) SetSummary(name string, s Summary) {
+	if col.Summaries == nil {
+		col.Summaries = make(map[string]any)
+	}
+	col.Summaries[name] = s.Value()
+}
+
+// maxSummaryValues caps the distinct values ComputeSummaries lists for a
+// field; fields with more, such as per-item IDs, are left out.
+const maxSummaryValues = 25
+
+// unsummarizedFields are item properties ComputeSummaries skips because the
+// extent covers them or they are unique per item.
+var unsummarizedFields = map[string]bool{
+	"datetime": true, "start_datetime": true, "end_datetime": true,
+	"created": true, "updated": true, "title": true, "description": true,
+}
+
+// ComputeSummaries derives a Collection extent and summaries from items.
+//
+// The spatial extent is the antimeridian-aware bbox of the items' bboxes
+// (computed from the geometry when an item has none) and the temporal
+// extent spans their time ranges, left open at the end if any item's range
+// is. Numeric properties are summarized as ranges; string and boolean
+// properties, and arrays of them, as the sorted distinct values, up to 25.
+// Properties holding objects or mixed types are skipped.
+func ComputeSummaries(items []*Item) (*Extent, map[string]any, error) {
+	var (
+		bounds     []orb.Bound
+		start, end time.Time
+		openEnd    bool
+		fields     = make(map[string]*summaryAccumulator)
+	)
+
+	for _, item := range items {
+		if item == nil {
+			continue
+		}
+
+		bbox := item.Bbox
+		if len(bbox) == 0 {
+			var err error
+			if bbox, err = item.ComputeBbox(); err != nil {
+				return nil, nil, fmt.Errorf("item %s: %w", item.Id, err)
+			}
+		}
+		bounds = append(bounds, BboxBounds(bbox)...)
+
+		iv, err := item.TimeRange()
+		if err != nil {
+			return nil, nil, fmt.Errorf("item %s: %w", item.Id, err)
+		}
+		if !iv.Start.IsZero() && (start.IsZero() || iv.Start.Before(start)) {
+			start = iv.Start
+		}
+		if iv.End.IsZero() {
+			openEnd = openEnd || !iv.Start.IsZero()
+		} else if iv.End.After(end) {
+			end = iv.End
+		}
+
+		for key, v := range item.Properties {
+			if unsummarizedFields[key] || v == nil {
+				continue
+			}
+			acc := fields[key]
+			if acc == nil {
+				acc = &summaryAccumulator{values: make(map[any]bool)}
+				fields[key] = acc
+			}
+			acc.add(v)
+		}
+	}
+
+	if openEnd {
+		end = time.Time{}
+	}
+	extent := &Extent{Temporal: &TemporalExtent{}}
+	extent.Temporal.SetIntervals([]TimeInterval{{Start: start, End: end}})
+	if bbox := boundsBbox(bounds); bbox != nil {
+		extent.Spatial = &SpatialExtent{Bbox: [][]float64{bbox}}
+	}
+
+	summaries := make(map[string]any)
+	for key, acc := range fields {
+		if s, ok := acc.summary(); ok {
+			summaries[key] = s.Value()
+		}
+	}
+	return extent, summaries, nil
+}
+
+// summaryAccumulator gathers the values of one property across items.
+type summaryAccumulator struct {
+	numeric  bool
+	discrete bool
+	invalid  bool
+	min, max float64
+	values   map[any]bool
+}
+
+func (a *summaryAccumulator) add(v any) {
+	if f, ok := toFloat(v); ok {
+		if !a.numeric {
+			a.min, a.max = f, f
+		}
+		a.numeric = true
+		a.min, a.max = min(a.min, f), max(a.max, f)
+		return
+	}
+
+	switch v := v.(type) {
+	case string, bool:
+		a.discrete = true
+		a.values[v] = true
+	case []any:
+		a.discrete = true
+		for _, e := range v {
+			switch e.(type) {
+			case string, bool:
+				a.values[e] = true
+			default:
+				a.invalid = true
+			}
+		}
+	case []string:
+		a.discrete = true
+		for _, e := range v {
+			a.values[e] = true
+		}
+	default:
+		a.invalid = true
+	}
+}
+
+func (a *summaryAccumulator) summary() (Summary, bool) {
+	switch {
+	case a.invalid || a.numeric == a.discrete:
+		return Summary{}, false
+	case a.numeric:
+		return Summary{Kind: SummaryRange, Range: Range{Minimum: a.min, Maximum: a.max}}, true
+	case len(a.values) > maxSummaryValues:
+		return Summary{}, false
+	}
+
+	values := make([]any, 0, len(a.values))
+	for v := range a.values {
+		values = append(values, v)
+	}
+	slices.SortFunc(values, func(x, y any) int {
+		return cmp.Compare(fmt.Sprint(x), fmt.Sprint(y))
+	})
+	return Summary{Kind: SummaryValues, Values: values}, true
+}
+
+func toFloat(v any) (float64, bool) {
+	switch v := v.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
@@ -0,0 +1,178 @@
+package validate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"github.com/robert-malhotra/go-stac-client/pkg/stac/ext/eo"
+	"github.com/robert-malhotra/go-stac-client/pkg/stac/ext/file"
+	"github.com/robert-malhotra/go-stac-client/pkg/stac/ext/proj"
+	"github.com/robert-malhotra/go-stac-client/pkg/stac/ext/raster"
+	"github.com/robert-malhotra/go-stac-client/pkg/stac/ext/sar"
+	"github.com/robert-malhotra/go-stac-client/pkg/stac/ext/sat"
+	"github.com/robert-malhotra/go-stac-client/pkg/stac/ext/timestamps"
+	"github.com/robert-malhotra/go-stac-client/pkg/stac/ext/view"
+)
+
+// maxSchemaSize bounds the size of a downloaded schema.
+const maxSchemaSize = 4 << 20
+
+// ExtensionSchemas lists the schemas of the extensions with typed views in
+// pkg/stac/ext.
+var ExtensionSchemas = []string{
+	eo.SchemaURI,
+	file.SchemaURI,
+	proj.SchemaURI,
+	raster.SchemaURI,
+	sar.SchemaURI,
+	sat.SchemaURI,
+	timestamps.SchemaURI,
+	view.SchemaURI,
+}
+
+// Extensions returns the stac_extensions of a JSON document, or of the
+// features of a FeatureCollection. It returns nil if data cannot be decoded.
+func Extensions(data []byte) []string {
+	var doc struct {
+		Extensions []string `json:"stac_extensions"`
+		Features   []struct {
+			Extensions []string `json:"stac_extensions"`
+		} `json:"features"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil
+	}
+	urls := doc.Extensions
+	for _, f := range doc.Features {
+		urls = append(urls, f.Extensions...)
+	}
+	return urls
+}
+
+// FetchSchemas downloads the schemas at urls, and the schemas they reference
+// with $ref, into the schema directory dir, so that a Validator created with
+// WithSchemaDir(dir) finds them offline. Schemas that are embedded or
+// already in dir are not downloaded again. It returns the URLs downloaded,
+// also when it fails part way. A nil client uses http.DefaultClient.
+func FetchSchemas(ctx context.Context, client *http.Client, dir string, urls ...string) ([]string, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	var fetched []string
+	seen := make(map[string]bool)
+	queue := append([]string(nil), urls...)
+	for len(queue) > 0 {
+		u := trimFragment(queue[0])
+		queue = queue[1:]
+		if seen[u] {
+			continue
+		}
+		seen[u] = true
+
+		rel, ok := cachePath(u)
+		if !ok {
+			return fetched, fmt.Errorf("cannot cache schema %s: not an http(s) URL", u)
+		}
+		path := filepath.Join(dir, filepath.FromSlash(rel))
+		data, err := os.ReadFile(path)
+		switch {
+		case err == nil:
+		case !errors.Is(err, fs.ErrNotExist):
+			return fetched, err
+		case isEmbedded(rel):
+			continue
+		default:
+			if data, err = fetchSchema(ctx, client, u); err != nil {
+				return fetched, err
+			}
+			if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+				return fetched, err
+			}
+			if err := os.WriteFile(path, data, 0o644); err != nil {
+				return fetched, err
+			}
+			fetched = append(fetched, u)
+		}
+
+		refs, err := schemaRefs(u, data)
+		if err != nil {
+			return fetched, err
+		}
+		queue = append(queue, refs...)
+	}
+	return fetched, nil
+}
+
+func fetchSchema(ctx context.Context, client *http.Client, u string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch schema %s: %w", u, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch schema %s: unexpected status %s", u, resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxSchemaSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch schema %s: %w", u, err)
+	}
+	if !json.Valid(data) {
+		return nil, fmt.Errorf("schema %s is not a JSON document", u)
+	}
+	return data, nil
+}
+
+// schemaRefs returns the http(s) documents referenced by the $ref keywords
+// of a schema, resolved against its URL.
+func schemaRefs(schemaURL string, data []byte) ([]string, error) {
+	base, err := url.Parse(schemaURL)
+	if err != nil {
+		return nil, err
+	}
+	var doc any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to decode schema %s: %w", schemaURL, err)
+	}
+
+	var refs []string
+	var walk func(v any)
+	walk = func(v any) {
+		switch v := v.(type) {
+		case map[string]any:
+			for key, child := range v {
+				if ref, ok := child.(string); ok && key == "$ref" {
+					if u, err := base.Parse(ref); err == nil && (u.Scheme == "http" || u.Scheme == "https") {
+						u.Fragment = ""
+						refs = append(refs, u.String())
+					}
+					continue
+				}
+				walk(child)
+			}
+		case []any:
+			for _, child := range v {
+				walk(child)
+			}
+		}
+	}
+	walk(doc)
+	return refs, nil
+}
+
+func isEmbedded(rel string) bool {
+	_, err := fs.Stat(embedded, "schemas/"+rel)
+	return err == nil
+}
@@ -0,0 +1,72 @@
+package validate_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/robert-malhotra/go-stac-client/pkg/stac/validate"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetchSchemas(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		requests []string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests = append(requests, r.URL.Path)
+		mu.Unlock()
+		switch r.URL.Path {
+		case "/ext/v1.0.0/schema.json":
+			w.Write([]byte(`{
+				"$schema": "http://json-schema.org/draft-07/schema#",
+				"type": "object",
+				"allOf": [
+					{"$ref": "https://schemas.stacspec.org/v1.0.0/item-spec/json-schema/basics.json"},
+					{"$ref": "../common.json#/definitions/fields"},
+					{"$ref": "#/definitions/self"}
+				],
+				"definitions": {"self": {"type": "object"}}
+			}`))
+		case "/ext/common.json":
+			w.Write([]byte(`{"definitions": {"fields": {"properties": {"properties": {"required": ["ext:level"]}}}}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	extURL := server.URL + "/ext/v1.0.0/schema.json"
+	item := mutate(t, validItem, func(m map[string]any) {
+		m["stac_extensions"] = []string{extURL}
+	})
+	assert.Equal(t, []string{extURL}, validate.Extensions(item))
+
+	dir := t.TempDir()
+	ctx := context.Background()
+	fetched, err := validate.FetchSchemas(ctx, server.Client(), dir, validate.Extensions(item)...)
+	require.NoError(t, err)
+	assert.Equal(t, []string{extURL, server.URL + "/ext/common.json"}, fetched)
+	assert.Equal(t, []string{"/ext/v1.0.0/schema.json", "/ext/common.json"}, requests, "embedded schemas are not fetched")
+
+	v := validate.New(validate.WithSchemaDir(dir))
+	errs := validationErrors(t, v.Validate(item))
+	assert.Equal(t, []string{"/properties"}, paths(errs), "the referenced schema applies")
+
+	t.Run("cached", func(t *testing.T) {
+		fetched, err := validate.FetchSchemas(ctx, server.Client(), dir, extURL)
+		require.NoError(t, err)
+		assert.Empty(t, fetched)
+		assert.Len(t, requests, 2)
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		_, err := validate.FetchSchemas(ctx, server.Client(), dir, server.URL+"/other/schema.json")
+		assert.ErrorContains(t, err, "404")
+	})
+}
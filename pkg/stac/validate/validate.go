@@ -0,0 +1,470 @@
+// Package validate checks STAC documents against the STAC JSON Schemas
+// without network access.
+//
+// The core Item, Collection and Catalog schemas for STAC 1.0.0 and 1.1.0 and
+// the GeoJSON schemas they reference are embedded in the binary. Extension
+// schemas listed in stac_extensions are resolved from local schema
+// directories (see WithSchemaDir) or schemas added with WithSchema, and
+// FetchSchemas downloads them into a schema directory. A schema directory
+// mirrors schema URLs by host and path, so
+// https://stac-extensions.github.io/eo/v1.1.0/schema.json is read from
+// <dir>/stac-extensions.github.io/eo/v1.1.0/schema.json. Files in a schema
+// directory take precedence over the embedded copies.
+//
+//	v, err := validate.New(validate.WithSchemaDir("/var/cache/stac-schemas"))
+//	if err := v.ValidateItem(item); err != nil {
+//		var verr *validate.ValidationError
+//		if errors.As(err, &verr) {
+//			for _, e := range verr.Errors {
+//				fmt.Println(e.Path, e.Message)
+//			}
+//		}
+//	}
+package validate
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/robert-malhotra/go-stac-client/pkg/stac"
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/santhosh-tekuri/jsonschema/v6/kind"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+//go:embed schemas
+var embedded embed.FS
+
+// ErrSchemaNotFound is returned by the schema loader when a schema is neither
+// embedded nor present in a schema directory.
+var ErrSchemaNotFound = errors.New("schema not available offline")
+
+// ErrUnsupportedVersion is returned for documents whose stac_version has no
+// embedded core schema.
+var ErrUnsupportedVersion = errors.New("unsupported stac_version")
+
+// SupportedVersions lists the STAC versions with embedded core schemas.
+var SupportedVersions = []string{"1.0.0", "1.1.0"}
+
+var printer = message.NewPrinter(language.English)
+
+// Error is a single schema violation.
+type Error struct {
+	// Path is a JSON pointer to the offending value, e.g.
+	// "/properties/datetime". The document root is "".
+	Path string `json:"path"`
+
+	// Schema is the schema URL and keyword that failed.
+	Schema string `json:"schema"`
+
+	Message string `json:"message"`
+}
+
+// String formats the error as "<path>: <message>".
+func (e Error) String() string {
+	path := e.Path
+	if path == "" {
+		path = "/"
+	}
+	return path + ": " + e.Message
+}
+
+// ValidationError lists every violation found in a document.
+type ValidationError struct {
+	Errors []Error
+}
+
+func (e *ValidationError) Error() string {
+	switch len(e.Errors) {
+	case 0:
+		return "invalid STAC document"
+	case 1:
+		return "invalid STAC document: " + e.Errors[0].String()
+	}
+	return fmt.Sprintf("invalid STAC document: %s (and %d more)", e.Errors[0], len(e.Errors)-1)
+}
+
+// Option configures a Validator.
+type Option func(*Validator)
+
+// WithSchemaDir adds a local schema cache directory. Directories are searched
+// in the order they were added, before the embedded schemas.
+func WithSchemaDir(dir string) Option {
+	return func(v *Validator) {
+		if dir != "" {
+			v.dirs = append(v.dirs, dir)
+		}
+	}
+}
+
+// DefaultSchemaDir returns the per-user schema cache directory,
+// <user cache dir>/go-stac-client/schemas, or "" if there is no cache
+// directory. The CLI and TUI read extension schemas from it.
+func DefaultSchemaDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "go-stac-client", "schemas")
+}
+
+// WithSchema registers the schema document for url.
+func WithSchema(url string, data []byte) Option {
+	return func(v *Validator) {
+		v.extra[trimFragment(url)] = data
+	}
+}
+
+// IgnoreMissingSchemas skips extensions whose schemas are not available
+// instead of reporting them as errors.
+func IgnoreMissingSchemas() Option {
+	return func(v *Validator) {
+		v.ignoreMissing = true
+	}
+}
+
+// Validator validates STAC documents. It compiles schemas on first use and
+// caches them; it is safe for concurrent use.
+type Validator struct {
+	dirs          []string
+	extra         map[string][]byte
+	ignoreMissing bool
+
+	mu       sync.Mutex
+	compiler *jsonschema.Compiler
+	schemas  map[string]*jsonschema.Schema
+}
+
+// New creates a Validator.
+func New(opts ...Option) *Validator {
+	v := &Validator{
+		extra:   make(map[string][]byte),
+		schemas: make(map[string]*jsonschema.Schema),
+	}
+	for _, opt := range opts {
+		opt(v)
+	}
+
+	v.compiler = jsonschema.NewCompiler()
+	v.compiler.DefaultDraft(jsonschema.Draft7)
+	v.compiler.AssertFormat()
+	v.compiler.UseLoader(loader{v})
+	return v
+}
+
+var (
+	defaultOnce      sync.Once
+	defaultValidator *Validator
+)
+
+// Validate checks a JSON document with a Validator that only knows the
+// embedded schemas.
+func Validate(data []byte) error {
+	defaultOnce.Do(func() { defaultValidator = New() })
+	return defaultValidator.Validate(data)
+}
+
+// ValidateItem checks item against the Item schema and its extensions.
+func (v *Validator) ValidateItem(item *stac.Item) error {
+	return v.validateValue(item)
+}
+
+// ValidateCollection checks c against the Collection schema and its
+// extensions.
+func (v *Validator) ValidateCollection(c *stac.Collection) error {
+	return v.validateValue(c)
+}
+
+// Validate checks a JSON document. Items, Collections and Catalogs are
+// validated against their core schema, and FeatureCollections item by item.
+// It returns a *ValidationError listing the violations if the document is
+// invalid, or another error if it cannot be validated at all.
+func (v *Validator) Validate(data []byte) error {
+	doc, err := jsonschema.UnmarshalJSON(bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to decode document: %w", err)
+	}
+	errs, err := v.validateDoc(doc, "")
+	if err != nil {
+		return err
+	}
+	if len(errs) > 0 {
+		return &ValidationError{Errors: errs}
+	}
+	return nil
+}
+
+func (v *Validator) validateValue(value any) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to encode document: %w", err)
+	}
+	return v.Validate(data)
+}
+
+func (v *Validator) validateDoc(doc any, prefix string) ([]Error, error) {
+	obj, ok := doc.(map[string]any)
+	if !ok {
+		return []Error{{Path: prefix, Message: "document must be a JSON object"}}, nil
+	}
+
+	typ, _ := obj["type"].(string)
+	if typ == "FeatureCollection" {
+		return v.validateFeatures(obj, prefix)
+	}
+
+	version, _ := obj["stac_version"].(string)
+	if !isSupported(version) {
+		return nil, fmt.Errorf("%w %q", ErrUnsupportedVersion, version)
+	}
+
+	var spec string
+	switch typ {
+	case "Feature":
+		spec = "item-spec/json-schema/item.json"
+	case "Collection":
+		spec = "collection-spec/json-schema/collection.json"
+	case "Catalog":
+		spec = "catalog-spec/json-schema/catalog.json"
+	default:
+		return []Error{{Path: prefix + "/type", Message: fmt.Sprintf("unknown STAC type %q", typ)}}, nil
+	}
+
+	core, err := v.schema("https://schemas.stacspec.org/v" + version + "/" + spec)
+	if err != nil {
+		return nil, err
+	}
+	errs := collect(core.Validate(doc), prefix)
+
+	exts, _ := obj["stac_extensions"].([]any)
+	for i, e := range exts {
+		url, ok := e.(string)
+		if !ok {
+			continue
+		}
+		sch, err := v.schema(url)
+		if errors.Is(err, ErrSchemaNotFound) {
+			if !v.ignoreMissing {
+				errs = append(errs, Error{
+					Path:    fmt.Sprintf("%s/stac_extensions/%d", prefix, i),
+					Schema:  url,
+					Message: fmt.Sprintf("extension schema %s is not available offline", url),
+				})
+			}
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		errs = append(errs, collect(sch.Validate(doc), prefix)...)
+	}
+	return errs, nil
+}
+
+func (v *Validator) validateFeatures(obj map[string]any, prefix string) ([]Error, error) {
+	features, ok := obj["features"].([]any)
+	if !ok {
+		return []Error{{Path: prefix + "/features", Message: "features must be an array"}}, nil
+	}
+	var errs []Error
+	for i, f := range features {
+		fe, err := v.validateDoc(f, fmt.Sprintf("%s/features/%d", prefix, i))
+		if err != nil {
+			return nil, fmt.Errorf("features[%d]: %w", i, err)
+		}
+		errs = append(errs, fe...)
+	}
+	return errs, nil
+}
+
+// schema returns the compiled schema for url, compiling it on first use.
+func (v *Validator) schema(url string) (*jsonschema.Schema, error) {
+	url = trimFragment(url)
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if sch, ok := v.schemas[url]; ok {
+		return sch, nil
+	}
+	sch, err := v.compiler.Compile(url)
+	if err != nil {
+		// The compiler does not wrap loader errors.
+		var lerr *jsonschema.LoadURLError
+		if errors.As(err, &lerr) && errors.Is(lerr.Err, ErrSchemaNotFound) {
+			return nil, fmt.Errorf("%s: %w", lerr.URL, ErrSchemaNotFound)
+		}
+		return nil, fmt.Errorf("failed to compile schema %s: %w", url, err)
+	}
+	v.schemas[url] = sch
+	return sch, nil
+}
+
+// collect flattens a jsonschema error tree into its leaf violations.
+func collect(err error, prefix string) []Error {
+	var verr *jsonschema.ValidationError
+	if !errors.As(err, &verr) {
+		return nil
+	}
+
+	var out []Error
+	seen := make(map[Error]bool)
+	var walk func(e *jsonschema.ValidationError)
+	walk = func(e *jsonschema.ValidationError) {
+		if len(e.Causes) > 0 {
+			for _, c := range relevantCauses(e) {
+				walk(c)
+			}
+			return
+		}
+		item := Error{
+			Path:    prefix + pointer(e.InstanceLocation),
+			Schema:  e.SchemaURL,
+			Message: e.ErrorKind.LocalizedString(printer),
+		}
+		if !seen[item] {
+			seen[item] = true
+			out = append(out, item)
+		}
+	}
+	walk(verr)
+	return out
+}
+
+// relevantCauses drops the oneOf/anyOf branches that were rejected outright
+// because the value has the wrong JSON type or a different "type" member,
+// e.g. the LineString branch for a malformed Point, unless that would drop
+// every branch.
+func relevantCauses(e *jsonschema.ValidationError) []*jsonschema.ValidationError {
+	switch e.ErrorKind.(type) {
+	case *kind.OneOf, *kind.AnyOf:
+	default:
+		return e.Causes
+	}
+
+	at := pointer(e.InstanceLocation)
+	var kept []*jsonschema.ValidationError
+	for _, c := range e.Causes {
+		if !mismatched(c, at) {
+			kept = append(kept, c)
+		}
+	}
+	if len(kept) == 0 {
+		return e.Causes
+	}
+	return kept
+}
+
+// mismatched reports whether a branch failed on the JSON type of the value
+// at path or of one of its members, or on the value of its "type" member.
+func mismatched(e *jsonschema.ValidationError, path string) bool {
+	loc := pointer(e.InstanceLocation)
+	switch e.ErrorKind.(type) {
+	case *kind.Type:
+		if loc == path || isChild(loc, path) {
+			return true
+		}
+	case *kind.Enum, *kind.Const:
+		if loc == path+"/type" {
+			return true
+		}
+	case *kind.OneOf, *kind.AnyOf:
+		// Nested alternatives are pruned on their own.
+		return false
+	}
+	for _, c := range e.Causes {
+		if mismatched(c, path) {
+			return true
+		}
+	}
+	return false
+}
+
+func isChild(loc, parent string) bool {
+	rest, ok := strings.CutPrefix(loc, parent+"/")
+	return ok && !strings.Contains(rest, "/")
+}
+
+func pointer(tokens []string) string {
+	var b strings.Builder
+	for _, t := range tokens {
+		b.WriteByte('/')
+		t = strings.ReplaceAll(t, "~", "~0")
+		b.WriteString(strings.ReplaceAll(t, "/", "~1"))
+	}
+	return b.String()
+}
+
+func isSupported(version string) bool {
+	for _, v := range SupportedVersions {
+		if v == version {
+			return true
+		}
+	}
+	return false
+}
+
+func trimFragment(url string) string {
+	url, _, _ = strings.Cut(url, "#")
+	return url
+}
+
+// loader resolves schema URLs from WithSchema documents, schema directories
+// and the embedded schemas, in that order.
+type loader struct {
+	v *Validator
+}
+
+func (l loader) Load(url string) (any, error) {
+	url = trimFragment(url)
+	if data, ok := l.v.extra[url]; ok {
+		return jsonschema.UnmarshalJSON(bytes.NewReader(data))
+	}
+
+	rel, ok := cachePath(url)
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", url, ErrSchemaNotFound)
+	}
+	for _, dir := range l.v.dirs {
+		f, err := os.Open(filepath.Join(dir, filepath.FromSlash(rel)))
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		return jsonschema.UnmarshalJSON(f)
+	}
+
+	f, err := embedded.Open("schemas/" + rel)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", url, ErrSchemaNotFound)
+	}
+	defer f.Close()
+	return jsonschema.UnmarshalJSON(f)
+}
+
+// cachePath maps an http(s) schema URL to its host/path location in a schema
+// directory.
+func cachePath(url string) (string, bool) {
+	for _, scheme := range []string{"https://", "http://"} {
+		if rest, ok := strings.CutPrefix(url, scheme); ok {
+			rest = strings.TrimSuffix(rest, "/")
+			if rest == "" || strings.Contains(rest, "..") {
+				return "", false
+			}
+			return rest, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,201 @@
+package validate_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/robert-malhotra/go-stac-client/pkg/stac"
+	"github.com/robert-malhotra/go-stac-client/pkg/stac/validate"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const validItem = `{
+	"type": "Feature",
+	"stac_version": "1.0.0",
+	"id": "item-1",
+	"geometry": {"type": "Point", "coordinates": [10, 50]},
+	"bbox": [10, 50, 10, 50],
+	"properties": {"datetime": "2024-01-01T00:00:00Z", "gsd": 10},
+	"links": [{"rel": "self", "href": "https://example.com/item-1.json"}],
+	"assets": {"data": {"href": "data.tif", "roles": ["data"]}}
+}`
+
+const eoSchemaURL = "https://stac-extensions.github.io/eo/v1.1.0/schema.json"
+
+// eoSchema is a cut-down stand-in for the eo extension schema.
+const eoSchema = `{
+	"$schema": "http://json-schema.org/draft-07/schema#",
+	"$id": "https://stac-extensions.github.io/eo/v1.1.0/schema.json",
+	"type": "object",
+	"properties": {
+		"properties": {
+			"type": "object",
+			"properties": {
+				"eo:cloud_cover": {"type": "number", "minimum": 0, "maximum": 100}
+			}
+		}
+	}
+}`
+
+func mutate(t *testing.T, doc string, fn func(m map[string]any)) []byte {
+	t.Helper()
+	var m map[string]any
+	require.NoError(t, json.Unmarshal([]byte(doc), &m))
+	fn(m)
+	data, err := json.Marshal(m)
+	require.NoError(t, err)
+	return data
+}
+
+func validationErrors(t *testing.T, err error) []validate.Error {
+	t.Helper()
+	var verr *validate.ValidationError
+	require.ErrorAs(t, err, &verr)
+	return verr.Errors
+}
+
+func paths(errs []validate.Error) []string {
+	out := make([]string, len(errs))
+	for i, e := range errs {
+		out[i] = e.Path
+	}
+	return out
+}
+
+func TestValidateItem(t *testing.T) {
+	t.Run("valid 1.0.0 and 1.1.0", func(t *testing.T) {
+		assert.NoError(t, validate.Validate([]byte(validItem)))
+
+		v11 := mutate(t, validItem, func(m map[string]any) { m["stac_version"] = "1.1.0" })
+		assert.NoError(t, validate.Validate(v11))
+	})
+
+	t.Run("path addressed errors", func(t *testing.T) {
+		data := mutate(t, validItem, func(m map[string]any) {
+			m["properties"].(map[string]any)["datetime"] = "2024-01-01"
+			m["properties"].(map[string]any)["gsd"] = -1
+			delete(m["assets"].(map[string]any)["data"].(map[string]any), "href")
+		})
+
+		errs := validationErrors(t, validate.Validate(data))
+		got := paths(errs)
+		assert.Contains(t, got, "/properties/datetime")
+		assert.Contains(t, got, "/properties/gsd")
+		assert.Contains(t, got, "/assets/data")
+		for _, e := range errs {
+			assert.NotEmpty(t, e.Message)
+			assert.NotEmpty(t, e.Schema)
+		}
+	})
+
+	t.Run("bbox required with geometry", func(t *testing.T) {
+		data := mutate(t, validItem, func(m map[string]any) { delete(m, "bbox") })
+		assert.Error(t, validate.Validate(data))
+	})
+
+	t.Run("typed item", func(t *testing.T) {
+		var item stac.Item
+		require.NoError(t, json.Unmarshal([]byte(validItem), &item))
+		assert.NoError(t, validate.New().ValidateItem(&item))
+	})
+
+	t.Run("unsupported version", func(t *testing.T) {
+		data := mutate(t, validItem, func(m map[string]any) { m["stac_version"] = "0.9.0" })
+		assert.ErrorIs(t, validate.Validate(data), validate.ErrUnsupportedVersion)
+	})
+
+	t.Run("item collection", func(t *testing.T) {
+		bad := mutate(t, validItem, func(m map[string]any) { m["id"] = "" })
+		fc := `{"type": "FeatureCollection", "features": [` + validItem + `,` + string(bad) + `]}`
+
+		errs := validationErrors(t, validate.Validate([]byte(fc)))
+		assert.Equal(t, []string{"/features/1/id"}, paths(errs))
+	})
+}
+
+func TestValidateExtensions(t *testing.T) {
+	withEO := func(cloudCover float64) []byte {
+		return mutate(t, validItem, func(m map[string]any) {
+			m["stac_extensions"] = []string{eoSchemaURL}
+			m["properties"].(map[string]any)["eo:cloud_cover"] = cloudCover
+		})
+	}
+
+	t.Run("missing schema", func(t *testing.T) {
+		errs := validationErrors(t, validate.Validate(withEO(10)))
+		require.Len(t, errs, 1)
+		assert.Equal(t, "/stac_extensions/0", errs[0].Path)
+
+		v := validate.New(validate.IgnoreMissingSchemas())
+		assert.NoError(t, v.Validate(withEO(10)))
+	})
+
+	t.Run("registered schema", func(t *testing.T) {
+		v := validate.New(validate.WithSchema(eoSchemaURL, []byte(eoSchema)))
+		assert.NoError(t, v.Validate(withEO(10)))
+
+		errs := validationErrors(t, v.Validate(withEO(150)))
+		assert.Equal(t, []string{"/properties/eo:cloud_cover"}, paths(errs))
+	})
+
+	t.Run("schema directory", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "stac-extensions.github.io", "eo", "v1.1.0", "schema.json")
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(eoSchema), 0o644))
+
+		v := validate.New(validate.WithSchemaDir(dir))
+		assert.NoError(t, v.Validate(withEO(10)))
+		assert.Error(t, v.Validate(withEO(-5)))
+	})
+}
+
+func TestValidateCollectionAndCatalog(t *testing.T) {
+	collection := `{
+		"type": "Collection",
+		"stac_version": "1.1.0",
+		"id": "sentinel-2",
+		"description": "Sentinel-2 L2A",
+		"license": "proprietary",
+		"extent": {
+			"spatial": {"bbox": [[-180, -90, 180, 90]]},
+			"temporal": {"interval": [["2015-06-27T10:25:31Z", null]]}
+		},
+		"links": []
+	}`
+	assert.NoError(t, validate.Validate([]byte(collection)))
+
+	bad := mutate(t, collection, func(m map[string]any) {
+		m["extent"].(map[string]any)["spatial"] = map[string]any{"bbox": []any{[]any{0, 0, 1}}}
+	})
+	errs := validationErrors(t, validate.Validate(bad))
+	assert.Contains(t, paths(errs), "/extent/spatial/bbox/0")
+
+	var c stac.Collection
+	require.NoError(t, json.Unmarshal([]byte(collection), &c))
+	assert.NoError(t, validate.New().ValidateCollection(&c))
+
+	catalog := `{"type": "Catalog", "stac_version": "1.0.0", "id": "root", "description": "Root", "links": [{"rel": "child"}]}`
+	errs = validationErrors(t, validate.Validate([]byte(catalog)))
+	assert.Equal(t, []string{"/links/0"}, paths(errs))
+}
+
+func TestValidateMigrated(t *testing.T) {
+	old := `{
+		"stac_version": "0.9.0",
+		"id": "old",
+		"geometry": {"type": "Point", "coordinates": [10, 50]},
+		"bbox": [10, 50, 10, 50],
+		"properties": {"datetime": "2020-01-01T00:00:00Z", "eo:gsd": 10},
+		"links": [],
+		"assets": {}
+	}`
+	assert.ErrorIs(t, validate.Validate([]byte(old)), validate.ErrUnsupportedVersion)
+
+	migrated, err := stac.MigrateJSON([]byte(old))
+	require.NoError(t, err)
+	assert.NoError(t, validate.Validate(migrated))
+}